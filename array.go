@@ -0,0 +1,265 @@
+package reform
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Array wraps a slice value of field stored as PostgreSQL array ("array" in "reform:" tag).
+// It encodes the slice as array literal on write and decodes the column into it on scan,
+// so V should be a pointer to slice for scanning. Generated Values() and Pointers() use it.
+// Elements may be strings, booleans, numbers or pointers to them (nil pointer is NULL element).
+// Multidimensional arrays are not supported.
+type Array struct {
+	V interface{}
+}
+
+// Value implements driver.Valuer. Nil pointers are stored as NULL, nil slices as empty arrays
+// (columns of slice fields are NOT NULL).
+func (a Array) Value() (driver.Value, error) {
+	v := reflect.ValueOf(a.V)
+	for v.IsValid() && v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil, nil
+	}
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("reform: Array.Value: expected slice, got %T", a.V)
+	}
+
+	elements := make([]string, v.Len())
+	for i := range elements {
+		e := v.Index(i)
+		if e.Kind() == reflect.Ptr {
+			if e.IsNil() {
+				elements[i] = "NULL"
+				continue
+			}
+			e = e.Elem()
+		}
+
+		var s string
+		switch e.Kind() {
+		case reflect.String:
+			s = e.String()
+		case reflect.Bool:
+			s = strconv.FormatBool(e.Bool())
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			s = strconv.FormatInt(e.Int(), 10)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			s = strconv.FormatUint(e.Uint(), 10)
+		case reflect.Float32, reflect.Float64:
+			s = strconv.FormatFloat(e.Float(), 'g', -1, e.Type().Bits())
+		default:
+			return nil, fmt.Errorf("reform: Array.Value: unsupported element type %s", e.Type())
+		}
+		elements[i] = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+	}
+	return "{" + strings.Join(elements, ",") + "}", nil
+}
+
+// Scan implements sql.Scanner. NULL sets the slice to nil.
+func (a Array) Scan(src interface{}) error {
+	v := reflect.ValueOf(a.V)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("reform: Array.Scan: expected non-nil pointer to slice, got %T", a.V)
+	}
+	slice := v.Elem()
+
+	var s string
+	switch src := src.(type) {
+	case nil:
+		slice.Set(reflect.Zero(slice.Type()))
+		return nil
+	case []byte:
+		s = string(src)
+	case string:
+		s = src
+	default:
+		return fmt.Errorf("reform: Array.Scan: unexpected type %T", src)
+	}
+
+	elements, err := parseArrayLiteral(s)
+	if err != nil {
+		return err
+	}
+
+	res := reflect.MakeSlice(slice.Type(), len(elements), len(elements))
+	for i, element := range elements {
+		e := res.Index(i)
+		if element == nil {
+			if e.Kind() != reflect.Ptr {
+				return fmt.Errorf("reform: Array.Scan: NULL element can't be stored in %s", e.Type())
+			}
+			continue
+		}
+		if e.Kind() == reflect.Ptr {
+			e.Set(reflect.New(e.Type().Elem()))
+			e = e.Elem()
+		}
+		if err = setArrayElement(e, *element); err != nil {
+			return err
+		}
+	}
+	slice.Set(res)
+	return nil
+}
+
+// setArrayElement sets e to the value parsed from array element text.
+func setArrayElement(e reflect.Value, s string) error {
+	var err error
+	switch e.Kind() {
+	case reflect.String:
+		e.SetString(s)
+	case reflect.Bool:
+		var b bool
+		b, err = strconv.ParseBool(s)
+		e.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		i, err = strconv.ParseInt(s, 10, e.Type().Bits())
+		e.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var u uint64
+		u, err = strconv.ParseUint(s, 10, e.Type().Bits())
+		e.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		f, err = strconv.ParseFloat(s, e.Type().Bits())
+		e.SetFloat(f)
+	default:
+		return fmt.Errorf("reform: Array.Scan: unsupported element type %s", e.Type())
+	}
+	if err != nil {
+		return fmt.Errorf("reform: Array.Scan: failed to convert %q to %s: %s", s, e.Type(), err)
+	}
+	return nil
+}
+
+// parseArrayLiteral parses one-dimensional PostgreSQL array literal like {a,"b c",NULL}.
+// Nil elements are NULLs.
+func parseArrayLiteral(s string) ([]*string, error) {
+	if i := strings.Index(s, "="); i >= 0 && strings.HasPrefix(s, "[") {
+		// skip explicit bounds like [0:1]={a,b}
+		s = s[i+1:]
+	}
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return nil, fmt.Errorf("reform: Array.Scan: invalid array literal %q", s)
+	}
+	body := s[1 : len(s)-1]
+	if body == "" {
+		return []*string{}, nil
+	}
+
+	var res []*string
+	for i := 0; i <= len(body); {
+		var element strings.Builder
+		var quoted bool
+		if i < len(body) && body[i] == '"' {
+			quoted = true
+			i++
+			for ; i < len(body) && body[i] != '"'; i++ {
+				if body[i] == '\\' && i+1 < len(body) {
+					i++
+				}
+				element.WriteByte(body[i])
+			}
+			if i == len(body) {
+				return nil, fmt.Errorf("reform: Array.Scan: unterminated quoted element in %q", s)
+			}
+			i++
+		} else {
+			for ; i < len(body) && body[i] != ','; i++ {
+				if body[i] == '{' {
+					return nil, fmt.Errorf("reform: Array.Scan: multidimensional arrays are not supported: %q", s)
+				}
+				element.WriteByte(body[i])
+			}
+		}
+		if i < len(body) && body[i] != ',' {
+			return nil, fmt.Errorf("reform: Array.Scan: invalid array literal %q", s)
+		}
+		i++
+
+		str := element.String()
+		if !quoted && strings.EqualFold(str, "NULL") {
+			res = append(res, nil)
+			continue
+		}
+		res = append(res, &str)
+	}
+	return res, nil
+}
+
+// isArrayField returns true if "reform:" (or "gorm:") tag of the field has "array" option.
+func isArrayField(tag reflect.StructTag, imitateGorm bool) bool {
+	parts := strings.Split(tag.Get("reform"), ",")[1:]
+	if imitateGorm {
+		parts = strings.Split(tag.Get("gorm"), ";")
+	}
+	for _, part := range parts {
+		if strings.TrimSpace(part) == "array" {
+			return true
+		}
+	}
+	return false
+}
+
+// ArrayDialect is implemented by dialects which support array columns.
+type ArrayDialect interface {
+	Dialect
+
+	// ArrayContains returns SQL condition which is true if array expression contains
+	// all elements of array value expression.
+	ArrayContains(expr, value string) string
+
+	// ArrayOverlaps returns SQL condition which is true if array expression and array value expression
+	// have common elements.
+	ArrayOverlaps(expr, value string) string
+
+	// ArrayAny returns SQL condition which is true if array expression contains element value expression.
+	ArrayAny(expr, value string) string
+}
+
+// arrayDialect returns q.Dialect as ArrayDialect, or panics.
+func (q *Querier) arrayDialect() ArrayDialect {
+	dialect, ok := q.Dialect.(ArrayDialect)
+	if !ok {
+		panic(fmt.Errorf("reform: dialect %s does not support arrays", q.Dialect))
+	}
+	return dialect
+}
+
+// ArrayContains returns SQL condition with single question mark placeholder for array column,
+// which is true if column contains all elements of given array, for conditions like:
+//
+//	Article.Where(db.ArrayContains("tags"), reform.Array{V: []string{"go", "sql"}})
+//
+// Dialect should implement ArrayDialect.
+func (q *Querier) ArrayContains(column string) string {
+	return q.arrayDialect().ArrayContains(q.QuoteIdentifier(column), "?")
+}
+
+// ArrayOverlaps returns SQL condition with single question mark placeholder for array column,
+// which is true if column has any element of given array, for conditions like:
+//
+//	Article.Where(db.ArrayOverlaps("tags"), reform.Array{V: []string{"go", "sql"}})
+//
+// Dialect should implement ArrayDialect.
+func (q *Querier) ArrayOverlaps(column string) string {
+	return q.arrayDialect().ArrayOverlaps(q.QuoteIdentifier(column), "?")
+}
+
+// ArrayAny returns SQL condition with single question mark placeholder for array column,
+// which is true if column contains given element, for conditions like:
+//
+//	Article.Where(db.ArrayAny("tags"), "go")
+//
+// Dialect should implement ArrayDialect.
+func (q *Querier) ArrayAny(column string) string {
+	return q.arrayDialect().ArrayAny(q.QuoteIdentifier(column), "?")
+}
@@ -0,0 +1,81 @@
+package reform_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xaionaro/reform"
+	"github.com/xaionaro/reform/dialects/postgresql"
+)
+
+func TestArray(t *testing.T) {
+	v, err := reform.Array{V: []string{"a", `b "c"`, `d\e`, "", "NULL"}}.Value()
+	require.NoError(t, err)
+	assert.Equal(t, `{"a","b \"c\"","d\\e","","NULL"}`, v)
+
+	v, err = reform.Array{V: []*int32{pointer.ToInt32(1), nil}}.Value()
+	require.NoError(t, err)
+	assert.Equal(t, `{"1",NULL}`, v)
+
+	v, err = reform.Array{V: []bool(nil)}.Value()
+	require.NoError(t, err)
+	assert.Equal(t, `{}`, v)
+
+	v, err = reform.Array{V: (*[]bool)(nil)}.Value()
+	require.NoError(t, err)
+	assert.Nil(t, v)
+
+	v, err = reform.Array{V: []float64{}}.Value()
+	require.NoError(t, err)
+	assert.Equal(t, `{}`, v)
+
+	_, err = reform.Array{V: []struct{}{{}}}.Value()
+	assert.Error(t, err)
+
+	var s []string
+	require.NoError(t, reform.Array{V: &s}.Scan([]byte(`{a,"b \"c\"","d\\e","",NULL_,"NULL"}`)))
+	assert.Equal(t, []string{"a", `b "c"`, `d\e`, "", "NULL_", "NULL"}, s)
+	require.NoError(t, reform.Array{V: &s}.Scan(`{}`))
+	assert.Equal(t, []string{}, s)
+	require.NoError(t, reform.Array{V: &s}.Scan(nil))
+	assert.Nil(t, s)
+
+	var ints []*int64
+	require.NoError(t, reform.Array{V: &ints}.Scan(`[0:1]={1,NULL}`))
+	assert.Equal(t, []*int64{pointer.ToInt64(1), nil}, ints)
+
+	var bools []bool
+	require.NoError(t, reform.Array{V: &bools}.Scan(`{t,f}`))
+	assert.Equal(t, []bool{true, false}, bools)
+
+	assert.Error(t, reform.Array{V: &bools}.Scan(`{t,NULL}`))
+	assert.Error(t, reform.Array{V: &bools}.Scan(`{{t},{f}}`))
+	assert.Error(t, reform.Array{V: &s}.Scan(`{"a}`))
+	assert.Error(t, reform.Array{V: &s}.Scan(`a,b`))
+	assert.Error(t, reform.Array{V: s}.Scan(`{}`))
+}
+
+func TestArrayColumns(t *testing.T) {
+	var article struct {
+		Tags   []string `reform:"tags,array"`
+		Scores []int32  `reform:"scores,array"`
+	}
+	fields := make([]reform.FieldInfo, 2)
+	for i := range fields {
+		f := reflect.TypeOf(article).Field(i)
+		fields[i].Type = f.Type.String()
+		require.NoError(t, fields[i].ConsiderTag(false, f.Name, f.Tag))
+		assert.True(t, fields[i].IsArray)
+	}
+	assert.Equal(t, "text[]", postgresql.Dialect.ColumnTypeForField(fields[0]))
+	assert.Equal(t, "integer[]", postgresql.Dialect.ColumnTypeForField(fields[1]))
+
+	q := reform.NewDB(nil, postgresql.Dialect, nil)
+	assert.Equal(t, `"tags" @> ?`, q.ArrayContains("tags"))
+	assert.Equal(t, `"tags" && ?`, q.ArrayOverlaps("tags"))
+	assert.Equal(t, `? = ANY("tags")`, q.ArrayAny("tags"))
+}
@@ -0,0 +1,135 @@
+package reform
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TableRebuilder is implemented by dialects which can't alter columns in place (like SQLite3).
+type TableRebuilder interface {
+	// RebuildTable recreates existing table for the model described by s: creates a new table,
+	// copies all data, drops the old table and renames the new one. Columns which exist in the table,
+	// but not in the model, and indexes are kept.
+	RebuildTable(db *DB, s StructInfo, actual *TableSchema) error
+}
+
+// AutoMigrateReport describes changes made by AutoMigrate.
+type AutoMigrateReport struct {
+	// Applied contains applied changes for each changed table.
+	Applied []SchemaDiff
+
+	// Skipped contains changes which were not applied because they may lose data
+	// or can't be applied automatically.
+	Skipped []SchemaDiff
+}
+
+// String returns a human-readable description of the report.
+func (r *AutoMigrateReport) String() string {
+	var res []string
+	for _, d := range r.Applied {
+		for _, c := range d.Changes {
+			res = append(res, fmt.Sprintf("applied: %s: %s", d.Table, c))
+		}
+	}
+	for _, d := range r.Skipped {
+		for _, c := range d.Changes {
+			res = append(res, fmt.Sprintf("skipped: %s: %s", d.Table, c))
+		}
+	}
+	return strings.Join(res, "\n")
+}
+
+// isAdditive returns true if the change never leads to a loss of data.
+func (c SchemaChange) isAdditive() bool {
+	switch c.Kind {
+	case MissingTable, MissingColumn, MissingIndex:
+		return true
+	case UniqueMismatch:
+		return c.Expected == "unique"
+	case ColumnNullabilityMismatch:
+		return c.Field.IsNullable()
+	default:
+		return false
+	}
+}
+
+// AutoMigrate creates missing tables, and adds missing columns and indexes to existing ones
+// (see DiffSchema), also dropping NOT NULL for columns of pointer fields. It never drops tables,
+// columns or data, and never changes column types: such differences are returned as skipped.
+// Changes which dialect can't express with ALTER TABLE (like dropping NOT NULL in SQLite3)
+// are applied by table rebuild if dialect implements TableRebuilder.
+// Tables should be generated by reform (implement StructInfo() method). Dialect should implement SchemaDialect.
+func (db *DB) AutoMigrate(tables ...Table) (*AutoMigrateReport, error) {
+	report := new(AutoMigrateReport)
+	for _, table := range tables {
+		t, ok := table.(interface{ StructInfo() StructInfo })
+		if !ok {
+			return report, fmt.Errorf("reform: %T has no StructInfo() method", table)
+		}
+		s := db.resolveStruct(t.StructInfo())
+
+		dialect, ok := db.Dialect.(SchemaDialect)
+		if !ok {
+			return report, fmt.Errorf("reform: dialect %s does not support schema inspection", db.Dialect)
+		}
+		diff, err := db.diffSchema(dialect, s)
+		if err != nil {
+			return report, err
+		}
+
+		applied := SchemaDiff{Table: diff.Table}
+		skipped := SchemaDiff{Table: diff.Table}
+		var rebuild []SchemaChange
+		for _, c := range diff.Changes {
+			switch {
+			case !c.isAdditive():
+				skipped.Changes = append(skipped.Changes, c)
+			case len(c.Queries) == 0:
+				rebuild = append(rebuild, c)
+			default:
+				for _, q := range c.Queries {
+					if _, err = db.Exec(q); err != nil {
+						return report, fmt.Errorf("reform: failed to apply %s: %s: %s", diff.Table, c, err)
+					}
+				}
+				applied.Changes = append(applied.Changes, c)
+			}
+		}
+
+		if len(rebuild) > 0 {
+			// rebuild would apply the model's column types, nullability and primary key too
+			var blocked bool
+			for _, c := range skipped.Changes {
+				switch c.Kind {
+				case ColumnTypeMismatch, ColumnNullabilityMismatch, PrimaryKeyMismatch:
+					blocked = true
+				}
+			}
+
+			rebuilder, ok := db.Dialect.(TableRebuilder)
+			switch {
+			case !ok || blocked:
+				skipped.Changes = append(skipped.Changes, rebuild...)
+			default:
+				// re-inspect the table: columns and indexes may be added above
+				actual, err := dialect.InspectTable(db, s.SQLSchema, s.SQLName)
+				if err != nil {
+					return report, err
+				}
+				if err = rebuilder.RebuildTable(db, s, actual); err != nil {
+					return report, fmt.Errorf("reform: failed to rebuild %s: %s", diff.Table, err)
+				}
+				applied.Changes = append(applied.Changes, rebuild...)
+			}
+		}
+
+		if len(applied.Changes) > 0 {
+			report.Applied = append(report.Applied, applied)
+		}
+		if len(skipped.Changes) > 0 {
+			report.Skipped = append(report.Skipped, skipped)
+		}
+	}
+
+	return report, nil
+}
@@ -0,0 +1,165 @@
+package reform_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xaionaro/reform"
+)
+
+// structInfoTable is a Table with StructInfo() method, like the generated ones.
+type structInfoTable struct {
+	reform.Table
+	s reform.StructInfo
+}
+
+func (t structInfoTable) StructInfo() reform.StructInfo {
+	return t.s
+}
+
+func TestAutoMigrate(t *testing.T) {
+	db := newSQLite3DB(t,
+		`CREATE TABLE people (id integer PRIMARY KEY AUTOINCREMENT, name text NOT NULL, email text NOT NULL UNIQUE, legacy text)`,
+		`INSERT INTO people (name, email, legacy) VALUES ('Alice', 'alice@example.com', 'old')`,
+	)
+
+	people := structInfoTable{s: reform.StructInfo{
+		Type:    "Person",
+		SQLName: "people",
+		Fields: []reform.FieldInfo{
+			{Name: "ID", Type: "int", Column: "id", IsPK: true},
+			{Name: "Name", Type: "string", Column: "name", HasIndex: true},
+			{Name: "Email", Type: "*string", Column: "email", IsUnique: true},
+			{Name: "Age", Type: "int32", Column: "age"},
+		},
+	}}
+	gadgets := structInfoTable{s: reform.StructInfo{
+		Type:    "Gadget",
+		SQLName: "gadgets",
+		Fields: []reform.FieldInfo{
+			{Name: "ID", Type: "int", Column: "id", IsPK: true},
+		},
+	}}
+
+	report, err := db.AutoMigrate(people, gadgets)
+	require.NoError(t, err)
+	require.Len(t, report.Applied, 2)
+	kinds := make(map[reform.SchemaChangeKind]bool)
+	for _, c := range report.Applied[0].Changes {
+		kinds[c.Kind] = true
+	}
+	assert.Equal(t, map[reform.SchemaChangeKind]bool{
+		reform.MissingColumn: true, reform.MissingIndex: true, reform.ColumnNullabilityMismatch: true,
+	}, kinds)
+	assert.Equal(t, reform.MissingTable, report.Applied[1].Changes[0].Kind)
+	require.Len(t, report.Skipped, 1)
+	assert.Equal(t, []reform.SchemaChange{{Kind: reform.ExtraColumn, Column: "legacy"}}, report.Skipped[0].Changes)
+
+	// data, extra columns and unique indexes are kept by the rebuild
+	var name, email, legacy string
+	err = db.QueryRow("SELECT name, email, legacy FROM people").Scan(&name, &email, &legacy)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Alice", "alice@example.com", "old"}, []string{name, email, legacy})
+	_, err = db.Exec("INSERT INTO people (name, email, age) VALUES ('Bob', 'alice@example.com', 1)")
+	assert.Error(t, err)
+	_, err = db.Exec("INSERT INTO people (name, age) VALUES ('Bob', 1)")
+	assert.NoError(t, err)
+
+	report, err = db.AutoMigrate(people, gadgets)
+	require.NoError(t, err)
+	assert.Empty(t, report.Applied)
+	assert.Len(t, report.Skipped, 1)
+
+	// type changes are never applied
+	people.s.Fields[3].Type = "string"
+	report, err = db.AutoMigrate(people)
+	require.NoError(t, err)
+	assert.Empty(t, report.Applied)
+	assert.Len(t, report.Skipped[0].Changes, 2)
+}
+
+func TestAutoMigrateSchema(t *testing.T) {
+	db := newSQLite3DB(t,
+		`ATTACH DATABASE ':memory:' AS tenant_42`,
+		`CREATE TABLE tenant_42.people (id integer PRIMARY KEY AUTOINCREMENT, name text NOT NULL, email text NOT NULL UNIQUE)`,
+		`CREATE INDEX tenant_42.people_name ON people (name)`,
+		`INSERT INTO tenant_42.people (name, email) VALUES ('Alice', 'alice@example.com')`,
+	)
+
+	people := structInfoTable{s: reform.StructInfo{
+		Type:    "Person",
+		SQLName: "people",
+		Fields: []reform.FieldInfo{
+			{Name: "ID", Type: "int", Column: "id", IsPK: true},
+			{Name: "Name", Type: "string", Column: "name"},
+			{Name: "Email", Type: "*string", Column: "email", IsUnique: true},
+		},
+	}}
+
+	// nullability change requires the rebuild of the table
+	report, err := db.WithSchema("tenant_42").AutoMigrate(people)
+	require.NoError(t, err)
+	require.Len(t, report.Applied, 1)
+	assert.Equal(t, reform.ColumnNullabilityMismatch, report.Applied[0].Changes[0].Kind)
+
+	rows, err := db.Query("SELECT name FROM tenant_42.sqlite_master WHERE type = 'index' AND tbl_name = 'people' ORDER BY name")
+	require.NoError(t, err)
+	var indexes []string
+	for rows.Next() {
+		var name string
+		require.NoError(t, rows.Scan(&name))
+		indexes = append(indexes, name)
+	}
+	require.NoError(t, rows.Err())
+	require.NoError(t, rows.Close())
+	assert.Equal(t, []string{"people_name", "uniq_people_email"}, indexes)
+
+	_, err = db.Exec("INSERT INTO tenant_42.people (name, email) VALUES ('Bob', 'alice@example.com')")
+	assert.Error(t, err)
+	_, err = db.Exec("INSERT INTO tenant_42.people (name) VALUES ('Bob')")
+	assert.NoError(t, err)
+}
+
+func TestAutoMigrateZeroValues(t *testing.T) {
+	db := newSQLite3DB(t,
+		`CREATE TABLE people (id integer PRIMARY KEY AUTOINCREMENT, name text NOT NULL)`,
+		`INSERT INTO people (name) VALUES ('Alice')`,
+	)
+
+	people := structInfoTable{s: reform.StructInfo{
+		Type:    "Person",
+		SQLName: "people",
+		Fields: []reform.FieldInfo{
+			{Name: "ID", Type: "int", Column: "id", IsPK: true},
+			{Name: "Name", Type: "string", Column: "name"},
+			{Name: "Score", Type: "float64", Column: "score"},
+			{Name: "Active", Type: "bool", Column: "active"},
+			{Name: "Avatar", Type: "[]uint8", Column: "avatar"},
+			{Name: "Bio", Type: "string", Column: "bio"},
+		},
+	}}
+
+	// NOT NULL columns are added to existing rows with typed zero values
+	report, err := db.AutoMigrate(people)
+	require.NoError(t, err)
+	require.Len(t, report.Applied, 1)
+	assert.Len(t, report.Applied[0].Changes, 4)
+
+	var score float64
+	var active bool
+	var avatar []byte
+	var bio string
+	err = db.QueryRow("SELECT score, active, avatar, bio FROM people").Scan(&score, &active, &avatar, &bio)
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, score)
+	assert.False(t, active)
+	assert.Equal(t, []byte{}, avatar)
+	assert.Equal(t, "", bio)
+
+	var types string
+	err = db.QueryRow("SELECT typeof(score) || ',' || typeof(active) || ',' || typeof(avatar) || ',' || typeof(bio) FROM people").Scan(&types)
+	require.NoError(t, err)
+	assert.Equal(t, "real,integer,blob,text", types)
+}
@@ -4,10 +4,13 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"errors"
+	"fmt"
 	"github.com/jinzhu/gorm"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 var (
@@ -24,16 +27,41 @@ var (
 
 // FieldInfo represents information about struct field.
 type FieldInfo struct {
-	Name       string      // field name as defined in source file, e.g. Name
-	IsPK       bool        // is this field a primary key field
-	IsUnique   bool        // this field uses unique index in RDBMS
-	HasIndex   bool        // this field uses index in RDBMS
-	Type       string      // field type as defined in source file, e.g. string
-	Column     string      // SQL database column name from "reform:" struct field tag, e.g. name
-	FieldsPath []FieldInfo // A path to the field via nested structures
-	SQLSize    int
-	Embedded   string
-	StructFile string
+	Name             string      // field name as defined in source file, e.g. Name
+	IsPK             bool        // is this field a primary key field
+	IsUnique         bool        // this field uses unique index in RDBMS
+	HasIndex         bool        // this field uses index in RDBMS
+	Type             string      // field type as defined in source file, e.g. string
+	Column           string      // SQL database column name from "reform:" struct field tag, e.g. name
+	FieldsPath       []FieldInfo // A path to the field via nested structures
+	SQLSize          int         // size of string and binary columns from "sql_size:" tag
+	SQLPrecision     int         // precision of decimal columns from "sql_precision:" tag
+	SQLScale         int         // scale of decimal columns from "sql_scale:" tag
+	Kind             string      // underlying type without pointer, e.g. int32 for *Integer (type Integer int32)
+	Embedded         string
+	IsJSON           bool           // field value is stored as JSON document ("json" in "reform:" tag)
+	IsArray          bool           // field value is stored as PostgreSQL array ("array" in "reform:" tag)
+	NullZero         NullZeroMode   // NULL is scanned as zero value ("nullzero" or "nullzero:write" in "reform:" tag)
+	Encryption       EncryptionMode // field value is encrypted ("encrypted" or "encrypted:deterministic" in "reform:" tag)
+	PKGenerator      string         // primary key generator from "pkgen:" option, e.g. uuidv7 or sequence:users_id_seq
+	IsTenant         bool           // queries are limited to rows of the current tenant by this field ("tenant" in "reform:" tag)
+	StructFile       string
+	Indexes          []FieldIndex // indexes including this field from "sql:" tag
+	SQLType          string       // column type from "sql:" tag overriding the dialect's one, e.g. varchar(64)
+	Default          string       // default value expression from "sql:" tag, e.g. CURRENT_TIMESTAMP
+	Check            string       // check constraint expression from "sql:" tag, e.g. age >= 0
+	ReferencesTable  string       // table referenced by the foreign key from "sql:" tag, e.g. users
+	ReferencesColumn string       // column referenced by the foreign key from "sql:" tag, e.g. id
+	OnDelete         string       // ON DELETE action of the foreign key, e.g. CASCADE
+	EnumValues       []string     // values of the field type with "//reform:enum" magic comment as stored in the database
+}
+
+// FieldIndex represents an index including the field.
+type FieldIndex struct {
+	Name     string // index name, empty for the default single-column index
+	Unique   bool   // is this index unique
+	Priority int    // position of the column in the composite index: lower goes first, equal ones go in fields order
+	Where    string // condition of the partial index
 }
 
 func (f FieldInfo) FullName() string {
@@ -45,50 +73,221 @@ func (f FieldInfo) FullName() string {
 	return prefix + f.Name
 }
 
-// parseStructFieldSQLTag is used by both file and runtime parsers to parse "sql" tags
-func parseStructFieldSQLTag(tag string) (isUnique bool, hasIndex bool) {
-	parts := strings.Split(tag, ",")
+// underlyingType returns Kind, or Type without pointer if Kind is not set (StructInfo is not made by parser).
+func (f FieldInfo) underlyingType() string {
+	if f.Kind != "" {
+		return f.Kind
+	}
+	return strings.TrimPrefix(f.Type, "*")
+}
+
+// sqlNullTypes maps sql.Null* types to types of their values.
+var sqlNullTypes = map[string]string{
+	"sql.NullBool":    "bool",
+	"sql.NullByte":    "uint8",
+	"sql.NullFloat64": "float64",
+	"sql.NullInt16":   "int16",
+	"sql.NullInt32":   "int32",
+	"sql.NullInt64":   "int64",
+	"sql.NullString":  "string",
+	"sql.NullTime":    "time.Time",
+}
+
+// BaseType returns Go type which is used to choose the column type: the field type without pointer,
+// with named types resolved to their underlying types and sql.Null* types resolved to types of their values.
+// For example, it is int32 for *Integer (type Integer int32) and sql.NullInt32, and []uint8 for []byte.
+func (f FieldInfo) BaseType() string {
+	t := f.underlyingType()
+	if valueType, ok := sqlNullTypes[t]; ok {
+		return valueType
+	}
+	return t
+}
+
+// IsNullable returns true if the column of this field can be NULL
+// (field has a pointer or sql.Null* type, or "nullzero" option).
+func (f FieldInfo) IsNullable() bool {
+	_, ok := sqlNullTypes[f.underlyingType()]
+	return ok || strings.HasPrefix(f.Type, "*") || f.NullZero.ScansNullAsZero()
+}
+
+// IsAutoIncrement returns true if this field is a primary key of integer type, generated by database
+// (without "pkgen:" option other than default sequence).
+func (f FieldInfo) IsAutoIncrement() bool {
+	if !f.IsPK {
+		return false
+	}
+	switch f.BaseType() {
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		// "sequence" generator without name uses the sequence of serial column
+		return f.PKGenerator == "" || f.PKGenerator == "sequence"
+	default:
+		return false
+	}
+}
+
+// splitSQLTag splits "sql:" tag into comma-separated parts, ignoring commas in parentheses and quotes.
+func splitSQLTag(tag string) []string {
+	var res []string
+	var depth int
+	var quoted bool
+	var start int
+	for i, c := range tag {
+		switch {
+		case c == '\'':
+			quoted = !quoted
+		case quoted:
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ',' && depth == 0:
+			res = append(res, tag[start:i])
+			start = i + 1
+		}
+	}
+	return append(res, tag[start:])
+}
 
-	for _, part := range parts {
-		switch part {
-		case "unique_index":
-			isUnique = true
-		case "index":
-			hasIndex = true
+// parseStructFieldSQLTag is used by both file and runtime parsers to parse "sql" tags, like
+// `sql:"index:idx_sensor_channel,priority:2,where:deleted_at IS NULL,default:0,check:channel >= 0"`.
+// "priority:" and "where:" modify the preceding index.
+func parseStructFieldSQLTag(tag string, f *FieldInfo) error {
+	lastIndex := -1
+	for _, part := range splitSQLTag(tag) {
+		key, value := strings.TrimSpace(part), ""
+		if i := strings.Index(key, ":"); i >= 0 {
+			key, value = strings.TrimSpace(key[:i]), strings.TrimSpace(key[i+1:])
+		}
+
+		switch key {
+		case "":
+		case "index", "unique_index":
+			unique := key == "unique_index"
+			f.IsUnique = f.IsUnique || unique
+			f.HasIndex = f.HasIndex || !unique
+			f.Indexes = append(f.Indexes, FieldIndex{Name: value, Unique: unique})
+			lastIndex = len(f.Indexes) - 1
+		case "priority", "where":
+			if lastIndex < 0 {
+				return fmt.Errorf("%q should follow index or unique_index", part)
+			}
+			if key == "where" {
+				f.Indexes[lastIndex].Where = value
+				break
+			}
+			priority, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("invalid priority %q", value)
+			}
+			f.Indexes[lastIndex].Priority = priority
+		case "type":
+			f.SQLType = value
+		case "default":
+			f.Default = value
+		case "check":
+			f.Check = value
+		case "references":
+			// table(column), table may be qualified with schema
+			i := strings.Index(value, "(")
+			if i <= 0 || !strings.HasSuffix(value, ")") {
+				return fmt.Errorf("invalid references %q, expected table(column)", value)
+			}
+			f.ReferencesTable = strings.TrimSpace(value[:i])
+			f.ReferencesColumn = strings.TrimSpace(value[i+1 : len(value)-1])
+		case "on_delete":
+			f.OnDelete = strings.ToUpper(value)
+			switch f.OnDelete {
+			case "CASCADE", "SET NULL", "SET DEFAULT", "RESTRICT", "NO ACTION":
+			default:
+				return fmt.Errorf("invalid on_delete action %q", value)
+			}
 		default:
-			// TODO: notify about the error
+			return fmt.Errorf("unknown part %q", part)
 		}
 	}
 
-	return
+	if f.OnDelete != "" && f.ReferencesTable == "" {
+		return fmt.Errorf("on_delete without references")
+	}
+	return nil
 }
 
-func (f *FieldInfo) ConsiderTag(imitateGorm bool, fieldName string, tag reflect.StructTag) {
-	var column string
-	var isPK bool
-	var embedded string
-	var structFile string
+// ConsiderTag sets column, primary key, indexes and constraints of the field from its struct tags.
+func (f *FieldInfo) ConsiderTag(imitateGorm bool, fieldName string, tag reflect.StructTag) error {
 	if imitateGorm {
-		column, isPK, embedded, structFile = ParseStructFieldGormTag(tag.Get("gorm"), fieldName)
+		f.Column, f.IsPK, f.Embedded, f.StructFile = ParseStructFieldGormTag(tag.Get("gorm"), fieldName)
 	} else {
-		column, isPK, embedded, structFile = ParseStructFieldTag(tag.Get("reform"))
+		f.Column, f.IsPK, f.Embedded, f.StructFile = ParseStructFieldTag(tag.Get("reform"))
 	}
-	isUnique, hasIndex := parseStructFieldSQLTag(tag.Get("sql"))
-	sqlSizeString := tag.Get("sql_size")
-	if sqlSizeString != "" {
+	f.IsJSON = isJSONField(tag, imitateGorm)
+	f.IsArray = isArrayField(tag, imitateGorm)
+	nullZero, err := nullZeroOption(tag, imitateGorm)
+	if err != nil {
+		return err
+	}
+	f.NullZero = nullZero
+	if f.Encryption, err = encryptionOption(tag, imitateGorm); err != nil {
+		return err
+	}
+	if f.PKGenerator = pkGeneratorOption(tag, imitateGorm); f.PKGenerator != "" && !f.IsPK {
+		return fmt.Errorf("pkgen option for non-primary key field")
+	}
+	if f.IsTenant = isTenantField(tag, imitateGorm); f.IsTenant && f.IsPK {
+		return fmt.Errorf("tenant option for primary key field")
+	}
+
+	if sqlSizeString := tag.Get("sql_size"); sqlSizeString != "" {
 		sqlSize, err := strconv.Atoi(sqlSizeString)
 		if err != nil {
-			panic(err)
+			return fmt.Errorf(`invalid "sql_size:" tag: %s`, err)
 		}
 		f.SQLSize = sqlSize
 	}
+	if precision := tag.Get("sql_precision"); precision != "" {
+		var err error
+		if f.SQLPrecision, err = strconv.Atoi(precision); err != nil {
+			return fmt.Errorf(`invalid "sql_precision:" tag: %s`, err)
+		}
+	}
+	if scale := tag.Get("sql_scale"); scale != "" {
+		var err error
+		if f.SQLScale, err = strconv.Atoi(scale); err != nil {
+			return fmt.Errorf(`invalid "sql_scale:" tag: %s`, err)
+		}
+	}
 
-	f.Column = column
-	f.IsPK = isPK
-	f.Embedded = embedded
-	f.StructFile = structFile
-	f.IsUnique = isUnique
-	f.HasIndex = hasIndex
+	if err := parseStructFieldSQLTag(tag.Get("sql"), f); err != nil {
+		return fmt.Errorf(`invalid "sql:" tag: %s`, err)
+	}
+	return nil
+}
+
+// ForeignKeyDefinition returns a table constraint definition of the field's foreign key
+// (see "references:" and "on_delete:" in "sql:" tag), or empty string if there is none.
+func (f FieldInfo) ForeignKeyDefinition(d Dialect) string {
+	if f.ReferencesTable == "" {
+		return ""
+	}
+
+	parts := strings.Split(f.ReferencesTable, ".")
+	for i, p := range parts {
+		parts[i] = d.QuoteIdentifier(p)
+	}
+	definition := fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s (%s)",
+		d.QuoteIdentifier(f.Column), strings.Join(parts, "."), d.QuoteIdentifier(f.ReferencesColumn))
+	if f.OnDelete != "" {
+		definition += " ON DELETE " + f.OnDelete
+	}
+	return definition
+}
+
+// IndexInfo represents information about index.
+type IndexInfo struct {
+	Name    string   // index name, e.g. idx_users_name
+	Columns []string // indexed columns
+	Unique  bool     // is this index unique
+	Where   string   // condition of the partial index
 }
 
 // StructInfo represents information about struct.
@@ -111,6 +310,70 @@ func (s *StructInfo) Columns() []string {
 	return res
 }
 
+// Indexes returns a new slice of indexes for fields with "index" and "unique_index" in "sql:" tag.
+// Fields with the same index name form a composite index. Unnamed single-column indexes
+// are named like idx_<table>_<column> or uniq_<table>_<column>, and skipped for primary key.
+func (s *StructInfo) Indexes() []IndexInfo {
+	type indexColumn struct {
+		column   string
+		priority int
+	}
+
+	var res []IndexInfo
+	var columns [][]indexColumn
+	byName := make(map[string]int)
+	for _, f := range s.Fields {
+		if f.Column == "" {
+			continue
+		}
+
+		indexes := f.Indexes
+		if len(indexes) == 0 {
+			// StructInfo was not made by parser
+			switch {
+			case f.IsUnique:
+				indexes = []FieldIndex{{Unique: true}}
+			case f.HasIndex:
+				indexes = []FieldIndex{{}}
+			}
+		}
+
+		for _, index := range indexes {
+			name := index.Name
+			switch {
+			case name != "":
+			case f.IsPK:
+				continue
+			case index.Unique:
+				name = "uniq_" + s.SQLName + "_" + f.Column
+			default:
+				name = "idx_" + s.SQLName + "_" + f.Column
+			}
+
+			i, ok := byName[name]
+			if !ok {
+				i = len(res)
+				byName[name] = i
+				res = append(res, IndexInfo{Name: name})
+				columns = append(columns, nil)
+			}
+			res[i].Unique = res[i].Unique || index.Unique
+			if index.Where != "" {
+				res[i].Where = index.Where
+			}
+			columns[i] = append(columns[i], indexColumn{column: f.Column, priority: index.Priority})
+		}
+	}
+
+	for i := range res {
+		sort.SliceStable(columns[i], func(a, b int) bool { return columns[i][a].priority < columns[i][b].priority })
+		for _, c := range columns[i] {
+			res[i].Columns = append(res[i].Columns, c.column)
+		}
+	}
+	return res
+}
+
 func (s *StructInfo) UnPointer() StructInfo {
 	return *s
 }
@@ -242,8 +505,14 @@ type QuerierI interface {
 	SplitConditionByPlaceholders(condition string) []string
 	GetDialect() Dialect
 	FlexSelectRows(view View, forceAnotherTable *string, forceFields []string, tail string, args ...interface{}) (*sql.Rows, error)
+	FlexSelectColumnsRows(view View, forceAnotherTable *string, columns string, columnsArgs []interface{}, tail string, args ...interface{}) (*sql.Rows, error)
 	FlexSelectOneTo(str Struct, forceAnotherTable *string, forceFields []string, tail string, args ...interface{}) error
+	ScanRow(rows *sql.Rows, str Struct, fieldNames []string) error
+	Count(view View, tail string, args ...interface{}) (int, error)
+	FlexExplain(view View, forceAnotherTable *string, forceFields []string, tail string, args ...interface{}) (*Plan, error)
 	QualifiedView(view View) string
+	FindByPrimaryKeyTo(record Record, pk interface{}) error
+	CachedQuery(view View, kind string, forceAnotherTable *string, forceFields []string, tail string, args []interface{}, ttl time.Duration, load func() (interface{}, error)) (interface{}, error)
 	Insert(str Struct) error
 	Replace(str Struct) error
 	Save(record Record) error
@@ -292,6 +561,23 @@ const (
 	EmptyLists
 )
 
+// ExplainMethod is a method of receiving an execution plan of a query.
+type ExplainMethod int
+
+const (
+	// ExplainJSON is a method using "EXPLAIN (FORMAT JSON)" SQL syntax.
+	ExplainJSON ExplainMethod = iota
+
+	// ExplainFormatJSON is a method using "EXPLAIN FORMAT=JSON" SQL syntax.
+	ExplainFormatJSON
+
+	// ExplainQueryPlan is a method using "EXPLAIN QUERY PLAN" SQL syntax.
+	ExplainQueryPlan
+
+	// ShowPlanXML is a method using "SET SHOWPLAN_XML ON" SQL syntax.
+	ShowPlanXML
+)
+
 // Dialect represents differences in various SQL dialects.
 type Dialect interface {
 	// String returns dialect name.
@@ -318,13 +604,25 @@ type Dialect interface {
 	// DefaultValuesMethod returns a method of inserting of row with all default values.
 	DefaultValuesMethod() DefaultValuesMethod
 
-	// ColumnDefinitionForField returns a string of column definition for a field
+	// ColumnDefinitionForField returns a column definition for a field for CREATE TABLE:
+	// quoted column name, type, auto-increment for integer primary key, PRIMARY KEY and nullability.
+	// Unique and other indexes are created by separate statements.
 	ColumnDefinitionForField(FieldInfo) string
 
-	// ColumnDefinitionForField returns a string of queries that should be executes after creating the field (like "CREATE INDEX" in sqlite)
+	// ColumnDefinitionPostQueryForField returns a string of queries that should be executed after creating the field.
+	//
+	// Deprecated: CreateTableIfNotExists creates indexes itself and does not call this method.
 	ColumnDefinitionPostQueryForField(StructInfo, FieldInfo) string
 }
 
+// ColumnTypeDialect is implemented by dialects which can return SQL column type for a field.
+type ColumnTypeDialect interface {
+	Dialect
+
+	// ColumnTypeForField returns SQL type of the column for a field, e.g. varchar(255).
+	ColumnTypeForField(FieldInfo) string
+}
+
 // Stringer represents any object with method "String() string" to stringify it's value
 type Stringer interface {
 	// Returns stringifier representation of the object
@@ -400,6 +698,8 @@ func ParseStructFieldTag(tag string) (sqlName string, isPK bool, embedded string
 			switch subParts[0] {
 			case "pk":
 				isPK = true
+			case "json", "array", "nullzero", "encrypted", "pkgen", "tenant":
+				// see FieldInfo.ConsiderTag
 			case "embedded":
 				embedded = subParts[1]
 			case "file":
@@ -447,6 +747,8 @@ func ParseStructFieldGormTag(tag string, fieldName string) (sqlName string, isPK
 		switch subParts[0] {
 		case "primary_key":
 			isPK = true
+		case "json", "array", "nullzero", "encrypted", "pkgen", "tenant":
+			// see FieldInfo.ConsiderTag
 		case "column":
 			sqlName = subParts[1]
 		case "embedded":
@@ -0,0 +1,320 @@
+package reform
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// BulkInsertMethod is a method of inserting many rows used by BulkInserter.
+type BulkInsertMethod int
+
+const (
+	// BulkMultiRow is a method using multi-row INSERT statements, see Querier.InsertMulti.
+	BulkMultiRow BulkInsertMethod = iota
+
+	// BulkPrepared is a method using a prepared single-row INSERT statement. All batches are inserted
+	// in a single transaction.
+	BulkPrepared
+
+	// BulkCopy is a method using "COPY ... FROM STDIN" SQL syntax. It requires github.com/lib/pq driver,
+	// BulkMultiRow is used with other drivers.
+	BulkCopy
+)
+
+// BulkDialect is implemented by dialects with specific bulk insert method and limits.
+// Other dialects use BulkMultiRow with at most 999 placeholders per statement.
+type BulkDialect interface {
+	Dialect
+
+	// BulkInsertMethod returns the fastest method of inserting many rows.
+	BulkInsertMethod() BulkInsertMethod
+
+	// BulkInsertLimits returns the maximum number of placeholders and rows in one INSERT statement
+	// (zero means no limit).
+	BulkInsertLimits() (placeholders, rows int)
+}
+
+// BulkProgress describes the result of one batch of BulkInserter.
+type BulkProgress struct {
+	Batch    int   // index of the batch, starting from 0
+	Rows     int   // number of rows in the batch
+	Inserted int   // total number of rows inserted so far
+	Err      error // error of the batch, its rows are not inserted
+}
+
+// BulkInserter inserts many structs of one view into SQL database table in batches with the fastest
+// method of the dialect (see BulkDialect). Every batch is inserted in its own transaction, except for
+// BulkPrepared method: all batches are inserted in a single transaction with one prepared statement,
+// failed batches are rolled back to savepoints, and the transaction is committed when Insert returns.
+// BeforeInsert hooks are called and primary keys of tables with "pkgen:" option are generated,
+// but, like with InsertMulti, primary keys generated by database are not filled and AfterInsert hooks
+// are not called. All structs of a batch should either have or not have primary key set.
+// Interceptors (see Use) are not called for BulkCopy and BulkPrepared methods.
+type BulkInserter struct {
+	// BatchSize is the maximum number of rows in one batch, 1000 by default.
+	// For BulkMultiRow it is also limited by the dialect.
+	BatchSize int
+
+	// Progress, if set, is called after every batch (before the commit for BulkPrepared).
+	Progress func(BulkProgress)
+
+	// ContinueOnError makes BulkInserter skip failed batches instead of stopping on the first error.
+	ContinueOnError bool
+
+	db   *DB
+	view View
+}
+
+// NewBulkInserter creates a new BulkInserter for given view.
+func (db *DB) NewBulkInserter(view View) *BulkInserter {
+	return &BulkInserter{db: db, view: view}
+}
+
+// Insert inserts all structs returned by next until it returns nil Struct or error, and returns
+// the number of inserted rows. It returns the error of next, or the first error of a batch.
+func (b *BulkInserter) Insert(next func() (Struct, error)) (int, error) {
+	method, size := b.method()
+	if method != BulkPrepared {
+		return b.insert(next, size, func(batch []Struct) error {
+			return b.db.InTransaction(func(tx *TX) error {
+				return tx.bulkInsert(method, b.view, batch, nil)
+			})
+		})
+	}
+
+	tx, err := b.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	stmts := make(map[string]*sql.Stmt)
+	inserted, err := b.insert(next, size, func(batch []Struct) error {
+		return tx.inSavepoint("reform_bulk", func() error {
+			return tx.bulkInsert(method, b.view, batch, stmts)
+		})
+	})
+	for _, stmt := range stmts {
+		stmt.Close()
+	}
+
+	// rows of successful batches are committed even if Insert stops on error
+	if commitErr := tx.Commit(); commitErr != nil {
+		_ = tx.Rollback()
+		return 0, commitErr
+	}
+	return inserted, err
+}
+
+// insert collects structs returned by next into batches of given size and inserts them with f.
+func (b *BulkInserter) insert(next func() (Struct, error), size int, f func([]Struct) error) (int, error) {
+	var inserted, n int
+	var firstErr error
+	batch := make([]Struct, 0, size)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		err := f(batch)
+		if err == nil {
+			inserted += len(batch)
+		}
+		if b.Progress != nil {
+			b.Progress(BulkProgress{Batch: n, Rows: len(batch), Inserted: inserted, Err: err})
+		}
+		n++
+		batch = batch[:0]
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		if b.ContinueOnError {
+			return nil
+		}
+		return err
+	}
+
+	for {
+		str, err := next()
+		if err != nil {
+			return inserted, err
+		}
+		if str == nil {
+			break
+		}
+		if str.View() != b.view {
+			return inserted, fmt.Errorf("reform: different tables in BulkInserter: %s and %s", b.view.Name(), str.View().Name())
+		}
+		if batch = append(batch, str); len(batch) == size {
+			if err = flush(); err != nil {
+				return inserted, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return inserted, err
+	}
+	return inserted, firstErr
+}
+
+// InsertChan inserts all structs received from ch until it is closed. See Insert.
+// If Insert stops on error, ch is not drained.
+func (b *BulkInserter) InsertChan(ch <-chan Struct) (int, error) {
+	return b.Insert(func() (Struct, error) {
+		return <-ch, nil
+	})
+}
+
+// method returns insert method and the batch size.
+func (b *BulkInserter) method() (BulkInsertMethod, int) {
+	method, placeholders, rows := BulkMultiRow, 999, 0
+	if dialect, ok := b.db.Dialect.(BulkDialect); ok {
+		method = dialect.BulkInsertMethod()
+		placeholders, rows = dialect.BulkInsertLimits()
+	}
+	if method == BulkCopy && !isLibPQ(b.db.db) {
+		method = BulkMultiRow
+	}
+
+	size := b.BatchSize
+	if size <= 0 {
+		size = 1000
+	}
+	if method == BulkMultiRow {
+		if columns := len(b.view.Columns()); placeholders > 0 && size*columns > placeholders {
+			size = placeholders / columns
+		}
+		if rows > 0 && size > rows {
+			size = rows
+		}
+		if size < 1 {
+			size = 1
+		}
+	}
+	return method, size
+}
+
+// isLibPQ returns true if db uses github.com/lib/pq driver.
+func isLibPQ(db DBInterface) bool {
+	sqlDB, ok := db.(*sql.DB)
+	return ok && fmt.Sprintf("%T", sqlDB.Driver()) == "*pq.Driver"
+}
+
+// txPreparer is implemented by *sql.Tx.
+type txPreparer interface {
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+// inSavepoint calls f after SAVEPOINT statement with given name, and rolls back to it if f fails.
+func (tx *TX) inSavepoint(name string, f func() error) error {
+	name = tx.QuoteIdentifier(name)
+	if _, err := tx.Exec("SAVEPOINT " + name); err != nil {
+		return err
+	}
+	err := f()
+	if err != nil {
+		if _, rbErr := tx.Exec("ROLLBACK TO SAVEPOINT " + name); rbErr != nil {
+			return rbErr
+		}
+	}
+	if _, relErr := tx.Exec("RELEASE SAVEPOINT " + name); relErr != nil && err == nil {
+		err = relErr
+	}
+	return err
+}
+
+// bulkInsert inserts structs of view with given method. BulkPrepared statements are cached in stmts,
+// if it is not nil, and should be closed by the caller.
+func (q *Querier) bulkInsert(method BulkInsertMethod, view View, structs []Struct, stmts map[string]*sql.Stmt) error {
+	if method == BulkMultiRow {
+		return q.InsertMulti(structs...)
+	}
+
+	for _, str := range structs {
+		if err := q.beforeInsert(str); err != nil {
+			return err
+		}
+		if _, err := q.generatePK(str); err != nil {
+			return err
+		}
+	}
+
+	// cut primary key if it is absent, like Insert does
+	record, _ := structs[0].(Record)
+	cutPK := record != nil && !record.HasPK()
+	var pk uint
+	columns := view.Columns()
+	if cutPK {
+		pk = view.(Table).PKColumnIndex()
+		columns = append(columns[:pk], columns[pk+1:]...)
+	}
+	for i, c := range columns {
+		columns[i] = q.QuoteIdentifier(c)
+	}
+
+	var query string
+	if method == BulkCopy {
+		query = fmt.Sprintf("COPY %s (%s) FROM STDIN", q.QualifiedView(view), strings.Join(columns, ", "))
+	} else {
+		query = fmt.Sprintf("%s INTO %s (%s) VALUES (%s)",
+			q.startQuery("INSERT"),
+			q.QualifiedView(view),
+			strings.Join(columns, ", "),
+			strings.Join(q.Placeholders(1, len(columns)), ", "),
+		)
+	}
+
+	ctx := q.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	exec := func(args ...interface{}) (sql.Result, error) {
+		return q.dbtx.Exec(query, args...)
+	}
+	if preparer, ok := q.dbtx.(txPreparer); ok {
+		stmt := stmts[query]
+		if stmt == nil {
+			var err error
+			if stmt, err = preparer.PrepareContext(ctx, query); err != nil {
+				return err
+			}
+			if stmts != nil && method == BulkPrepared {
+				stmts[query] = stmt
+			} else {
+				defer stmt.Close()
+			}
+		}
+		exec = func(args ...interface{}) (sql.Result, error) {
+			return stmt.ExecContext(ctx, args...)
+		}
+	} else if method == BulkCopy {
+		return fmt.Errorf("reform: %T can't prepare COPY statement", q.dbtx)
+	}
+
+	q.logBefore(query, nil)
+	start := time.Now()
+	err := func() error {
+		for _, str := range structs {
+			if rec, _ := str.(Record); rec != nil && rec.HasPK() == cutPK {
+				return fmt.Errorf("reform: PK is present in one struct and absent in other: first: %s, second: %s", record, rec)
+			}
+			values := str.Values()
+			if cutPK {
+				values = append(values[:pk], values[pk+1:]...)
+			}
+			if _, err := exec(q.prepareArgs(q.writeValues(values))...); err != nil {
+				return err
+			}
+		}
+		if method == BulkCopy {
+			// flush buffered rows
+			_, err := exec()
+			return err
+		}
+		return nil
+	}()
+	q.logAfter(query, nil, time.Since(start), err)
+	q.invalidateQueries(view)
+	return err
+}
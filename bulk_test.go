@@ -0,0 +1,82 @@
+package reform_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xaionaro/reform"
+	"github.com/xaionaro/reform/dialects/sqlite3"
+)
+
+func TestBulkInserter(t *testing.T) {
+	sqlDB := openSQLite3(t)
+
+	var begins, commits int
+	logger := reform.NewPrintfLogger(func(format string, args ...interface{}) {
+		switch msg := fmt.Sprintf(format, args...); {
+		case strings.HasPrefix(msg, ">>> BEGIN"):
+			begins++
+		case strings.HasPrefix(msg, ">>> COMMIT"):
+			commits++
+		}
+		t.Logf(format, args...)
+	})
+	db := reform.NewDB(sqlDB, sqlite3.Dialect, logger).WithTenant(int64(3))
+	_, err := db.Exec(`CREATE TABLE docs (id integer PRIMARY KEY, tenant_id integer NOT NULL, title text NOT NULL)`)
+	require.NoError(t, err)
+
+	var progress []reform.BulkProgress
+	b := db.NewBulkInserter(tenantDocs)
+	b.BatchSize = 4
+	b.Progress = func(p reform.BulkProgress) { progress = append(progress, p) }
+	b.ContinueOnError = true
+
+	ch := make(chan reform.Struct)
+	go func() {
+		for i := 1; i <= 10; i++ {
+			id := int64(i)
+			if i == 6 {
+				id = 1 // duplicate primary key fails the second batch
+			}
+			ch <- &tenantDoc{ID: id, Title: fmt.Sprint("doc ", i)}
+		}
+		close(ch)
+	}()
+	n, err := b.InsertChan(ch)
+	assert.Error(t, err)
+	assert.Equal(t, 6, n)
+
+	require.Len(t, progress, 3)
+	assert.Equal(t, reform.BulkProgress{Batch: 0, Rows: 4, Inserted: 4}, progress[0])
+	assert.Error(t, progress[1].Err)
+	assert.Equal(t, 4, progress[1].Inserted)
+	assert.Equal(t, reform.BulkProgress{Batch: 2, Rows: 2, Inserted: 6}, progress[2])
+
+	count, err := db.Count(tenantDocs, "")
+	require.NoError(t, err)
+	assert.Equal(t, 6, count, "rows of the failed batch should be rolled back, tenant should be stamped")
+
+	// SQLite batches are inserted in a single transaction
+	assert.Equal(t, 1, begins)
+	assert.Equal(t, 1, commits)
+
+	// the first error stops insertion
+	docs := []reform.Struct{&tenantDoc{ID: 1}, &tenantDoc{ID: 20}}
+	b = db.NewBulkInserter(tenantDocs)
+	b.BatchSize = 1
+	n, err = b.Insert(func() (reform.Struct, error) {
+		if len(docs) == 0 {
+			return nil, nil
+		}
+		str := docs[0]
+		docs = docs[1:]
+		return str, nil
+	})
+	assert.Error(t, err)
+	assert.Zero(t, n)
+	assert.Len(t, docs, 1)
+}
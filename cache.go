@@ -0,0 +1,302 @@
+package reform
+
+import (
+	"container/list"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache is a pluggable storage of cached values, like records cached by primary key (see DB.UseRecordCache)
+// and query results (see DB.UseQueryCache). The same Cache may be used for both.
+// Implementations should be safe for concurrent use. Stored values are never modified by reform.
+type Cache interface {
+	// Get returns the value stored with given key, if it is present and not expired.
+	Get(key string) (interface{}, bool)
+
+	// Set stores the value with given key. Zero ttl means no expiration.
+	Set(key string, value interface{}, ttl time.Duration)
+
+	// Delete removes the value with given key.
+	Delete(key string)
+
+	// DeletePrefix removes all values with keys starting with given prefix.
+	DeletePrefix(prefix string)
+}
+
+// LRUCache is an in-memory Cache of limited size evicting least recently used values.
+type LRUCache struct {
+	m     sync.Mutex
+	size  int
+	list  *list.List // front is the most recently used
+	items map[string]*list.Element
+}
+
+type lruEntry struct {
+	key     string
+	value   interface{}
+	expires time.Time
+}
+
+// NewLRUCache creates a new LRUCache holding up to size values.
+func NewLRUCache(size int) *LRUCache {
+	if size <= 0 {
+		panic("reform: NewLRUCache: size should be positive")
+	}
+	return &LRUCache{
+		size:  size,
+		list:  list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+// Get implements Cache.
+func (c *LRUCache) Get(key string) (interface{}, bool) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	e := c.items[key]
+	if e == nil {
+		return nil, false
+	}
+	entry := e.Value.(*lruEntry)
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		c.remove(e)
+		return nil, false
+	}
+	c.list.MoveToFront(e)
+	return entry.value, true
+}
+
+// Set implements Cache.
+func (c *LRUCache) Set(key string, value interface{}, ttl time.Duration) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	entry := &lruEntry{key: key, value: value}
+	if ttl > 0 {
+		entry.expires = time.Now().Add(ttl)
+	}
+	if e := c.items[key]; e != nil {
+		e.Value = entry
+		c.list.MoveToFront(e)
+		return
+	}
+	c.items[key] = c.list.PushFront(entry)
+	for c.list.Len() > c.size {
+		c.remove(c.list.Back())
+	}
+}
+
+// Delete implements Cache.
+func (c *LRUCache) Delete(key string) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	if e := c.items[key]; e != nil {
+		c.remove(e)
+	}
+}
+
+// DeletePrefix implements Cache.
+func (c *LRUCache) DeletePrefix(prefix string) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	for key, e := range c.items {
+		if strings.HasPrefix(key, prefix) {
+			c.remove(e)
+		}
+	}
+}
+
+// Len returns the number of stored values, including expired ones not removed yet.
+func (c *LRUCache) Len() int {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	return c.list.Len()
+}
+
+func (c *LRUCache) remove(e *list.Element) {
+	c.list.Remove(e)
+	delete(c.items, e.Value.(*lruEntry).key)
+}
+
+// recordCache is a Cache of records with their time-to-live.
+type recordCache struct {
+	cache Cache
+	ttl   time.Duration
+}
+
+// txCache collects cache invalidations made inside a transaction until it is committed.
+type txCache struct {
+	m             sync.Mutex
+	invalidations []func()
+}
+
+// UseRecordCache sets Cache for records found by primary key with FindByPrimaryKeyTo, FindByPrimaryKeyFrom,
+// Reload and generated First(pk). Cached records expire after ttl (zero means never), and are invalidated
+// by Update, UpdateColumns, Save, Replace, Delete and DeleteFrom made through reform, and by InvalidateTable;
+// changes made with raw Exec or by other applications are not noticed. Records are copied shallowly, so values referenced by
+// pointers, slices and maps of found records should not be modified in place. AfterFind is called once,
+// before the record is cached. Nil Cache disables caching.
+// Transactions started with Begin and copies made with With* methods after that inherit it.
+// Transactions don't use cached records, and their invalidations are applied only after Commit.
+func (db *DB) UseRecordCache(c Cache, ttl time.Duration) {
+	if c == nil {
+		db.recordCache = nil
+		return
+	}
+	db.recordCache = &recordCache{cache: c, ttl: ttl}
+}
+
+// UseQueryCache sets Cache for results of generated scopes' Select(), First() and Count() with Cache(ttl),
+// see CachedQuery. Nil Cache disables caching.
+// Transactions started with Begin and copies made with With* methods after that inherit it.
+func (db *DB) UseQueryCache(c Cache) {
+	db.queryCache = c
+}
+
+// CachedQuery returns the result of load cached with the query cache (see DB.UseQueryCache) for ttl
+// (zero means no expiration). Results are keyed by the table, kind (like "select" or "count") and
+// the full SELECT query with arguments built from view, forceAnotherTable, forceFields, tail and args
+// (see FlexSelectRows), so they are separate for tenants and schemas. They are invalidated by Insert,
+// InsertColumns, InsertMulti, Replace, Update, UpdateColumns, Save, Delete and DeleteFrom of that table
+// made through reform, and by InvalidateTable; changes of other tables used by forceAnotherTable are not noticed.
+// Cached results are shared, so they should not be modified. Errors are not cached.
+// Without the query cache or inside a transaction it just calls load.
+func (q *Querier) CachedQuery(view View, kind string, forceAnotherTable *string, forceFields []string, tail string, args []interface{}, ttl time.Duration, load func() (interface{}, error)) (interface{}, error) {
+	if q.queryCache == nil || q.txCache != nil {
+		return load()
+	}
+	query, args, err := q.selectQuery(view, tail, args, kind == "first", forceAnotherTable, forceFields)
+	if err != nil {
+		return nil, err
+	}
+
+	key := q.cacheKey(view, "query", kind+"\x00"+query+"\x00"+fmt.Sprintf("%#v", args))
+	if v, ok := q.queryCache.Get(key); ok {
+		return v, nil
+	}
+	v, err := load()
+	if err != nil {
+		return nil, err
+	}
+	q.queryCache.Set(key, v, ttl)
+	return v, nil
+}
+
+// cacheKey returns a key of the cache for given view, kind of cached values and their identifier.
+func (q *Querier) cacheKey(view View, kind string, id string) string {
+	return q.QualifiedView(view) + "\x00" + kind + "\x00" + id
+}
+
+// recordCacheKey returns a key of the record cache for given table and primary key.
+func (q *Querier) recordCacheKey(table Table, pk interface{}) string {
+	return q.cacheKey(table, "record", fmt.Sprint(pk))
+}
+
+// useRecordCache returns true if records should be read from and stored in the cache.
+func (q *Querier) useRecordCache() bool {
+	return q.recordCache != nil && q.txCache == nil
+}
+
+// cachedRecord copies the cached record with given key to record. It returns false if there is none.
+// ErrNoRows is returned for records of another tenant.
+func (q *Querier) cachedRecord(record Record, key string) (bool, error) {
+	cached, ok := q.recordCache.cache.Get(key)
+	if !ok || reflect.TypeOf(cached) != reflect.TypeOf(record) {
+		return false, nil
+	}
+
+	c := copyStruct(cached.(Struct))
+	field, tenant, err := q.tenantFilter(record.View())
+	if err != nil {
+		return true, err
+	}
+	if field != nil {
+		v, t, err := tenantFieldValue(c, field, tenant)
+		if err != nil {
+			return true, err
+		}
+		if !reflect.DeepEqual(v.Interface(), t.Interface()) {
+			return true, ErrNoRows
+		}
+	}
+
+	reflect.ValueOf(record).Elem().Set(reflect.ValueOf(c).Elem())
+	return true, nil
+}
+
+// cacheRecord stores a copy of record with given key.
+func (q *Querier) cacheRecord(record Record, key string) {
+	q.recordCache.cache.Set(key, copyStruct(record), q.recordCache.ttl)
+}
+
+// invalidateRecord removes cached record of given table with given primary key,
+// and cached query results of that table.
+func (q *Querier) invalidateRecord(table Table, pk interface{}) {
+	if q.recordCache != nil {
+		c, key := q.recordCache.cache, q.recordCacheKey(table, pk)
+		q.invalidate(func() { c.Delete(key) })
+	}
+	q.invalidateQueries(table)
+}
+
+// invalidateQueries removes cached query results of given view.
+func (q *Querier) invalidateQueries(view View) {
+	if q.queryCache != nil {
+		c, prefix := q.queryCache, q.cacheKey(view, "query", "")
+		q.invalidate(func() { c.DeletePrefix(prefix) })
+	}
+}
+
+// InvalidateTable removes all cached records (see DB.UseRecordCache) and query results (see DB.UseQueryCache)
+// of given table or view. It should be called after changes made with raw Exec or outside of the application.
+// Inside a transaction cache is changed only after Commit.
+func (q *Querier) InvalidateTable(view View) {
+	if q.recordCache != nil {
+		c, prefix := q.recordCache.cache, q.cacheKey(view, "record", "")
+		q.invalidate(func() { c.DeletePrefix(prefix) })
+	}
+	q.invalidateQueries(view)
+}
+
+// invalidate calls f now, or after Commit inside a transaction.
+func (q *Querier) invalidate(f func()) {
+	if q.txCache == nil {
+		f()
+		return
+	}
+	q.txCache.m.Lock()
+	q.txCache.invalidations = append(q.txCache.invalidations, f)
+	q.txCache.m.Unlock()
+}
+
+// finish applies collected invalidations if commit is true, and discards them.
+func (c *txCache) finish(commit bool) {
+	if c == nil {
+		return
+	}
+	c.m.Lock()
+	invalidations := c.invalidations
+	c.invalidations = nil
+	c.m.Unlock()
+
+	if commit {
+		for _, f := range invalidations {
+			f()
+		}
+	}
+}
+
+// copyStruct returns a shallow copy of str.
+func copyStruct(str Struct) Struct {
+	v := reflect.ValueOf(str)
+	c := reflect.New(v.Type().Elem())
+	c.Elem().Set(v.Elem())
+	return c.Interface().(Struct)
+}
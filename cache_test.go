@@ -0,0 +1,159 @@
+package reform_test
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xaionaro/reform"
+)
+
+func TestLRUCache(t *testing.T) {
+	c := reform.NewLRUCache(2)
+	c.Set("a", 1, 0)
+	c.Set("b", 2, 0)
+	_, ok := c.Get("a")
+	assert.True(t, ok)
+	c.Set("c", 3, 0)
+	_, ok = c.Get("b")
+	assert.False(t, ok, "least recently used value should be evicted")
+	assert.Equal(t, 2, c.Len())
+
+	c.Set("d", 4, time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	_, ok = c.Get("d")
+	assert.False(t, ok, "value should expire")
+
+	c.Set("x/1", 1, 0)
+	c.Set("x/2", 2, 0)
+	c.DeletePrefix("x/")
+	assert.Zero(t, c.Len())
+}
+
+func TestRecordCache(t *testing.T) {
+	var queries int
+	db := newSQLite3DB(t)
+	db.Use(reform.InterceptorFuncs{
+		OnQueryRow: func(query string, args []interface{}, next reform.QueryRowFunc) *sql.Row {
+			queries++
+			return next(query, args)
+		},
+	})
+	db.UseRecordCache(reform.NewLRUCache(100), time.Minute)
+	_, err := db.Exec(`CREATE TABLE docs (id integer PRIMARY KEY, tenant_id integer NOT NULL, title text NOT NULL)`)
+	require.NoError(t, err)
+
+	t1 := db.WithTenant(int64(1))
+	for i := 1; i <= 3; i++ {
+		require.NoError(t, t1.Insert(&tenantDoc{ID: int64(i), Title: fmt.Sprint("doc ", i)}))
+	}
+
+	find := func(q *reform.Querier, pk int64) (*tenantDoc, error) {
+		doc := new(tenantDoc)
+		err := q.FindByPrimaryKeyTo(doc, pk)
+		return doc, err
+	}
+
+	doc, err := find(t1.Querier, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, queries)
+	doc.Title = "changed in memory"
+	doc, err = find(t1.Querier, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "doc 1", doc.Title)
+	assert.Equal(t, 1, queries, "record should be found in cache")
+
+	_, err = find(db.WithTenant(int64(2)).Querier, 1)
+	assert.Equal(t, reform.ErrNoRows, err, "cached record of another tenant should not be found")
+	assert.Equal(t, 1, queries)
+
+	doc.Title = "updated"
+	require.NoError(t, t1.Update(doc))
+	doc, err = find(t1.Querier, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "updated", doc.Title)
+	assert.Equal(t, 2, queries)
+
+	// writes inside transactions are applied to cache after commit
+	tx, err := t1.Begin()
+	require.NoError(t, err)
+	doc.Title = "rolled back"
+	require.NoError(t, tx.Update(doc))
+	require.NoError(t, tx.Rollback())
+	doc, err = find(t1.Querier, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "updated", doc.Title)
+	assert.Equal(t, 2, queries)
+
+	require.NoError(t, t1.InTransaction(func(tx *reform.TX) error {
+		doc.Title = "committed"
+		if err := tx.Update(doc); err != nil {
+			return err
+		}
+		d, err := find(tx.Querier, 1)
+		assert.Equal(t, "committed", d.Title, "transaction should not read cache")
+		return err
+	}))
+	assert.Equal(t, 3, queries)
+	doc, err = find(t1.Querier, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "committed", doc.Title)
+	assert.Equal(t, 4, queries)
+
+	require.NoError(t, t1.Delete(doc))
+	_, err = find(t1.Querier, 1)
+	assert.Equal(t, reform.ErrNoRows, err)
+
+	for _, pk := range []int64{2, 3} {
+		_, err = find(t1.Querier, pk)
+		require.NoError(t, err)
+	}
+	n, err := t1.DeleteFrom(tenantDocs, "")
+	require.NoError(t, err)
+	assert.Equal(t, uint(2), n)
+	_, err = t1.FindByPrimaryKeyFrom(tenantDocs, int64(2))
+	assert.Equal(t, reform.ErrNoRows, err)
+}
+
+func TestQueryCache(t *testing.T) {
+	db := newSQLite3DB(t, `CREATE TABLE docs (id integer PRIMARY KEY, tenant_id integer NOT NULL, title text NOT NULL)`).WithoutTenant()
+	db.UseQueryCache(reform.NewLRUCache(100))
+
+	var loads int
+	count := func(q *reform.Querier, title string) int {
+		v, err := q.CachedQuery(tenantDocs, "count", nil, nil, "WHERE title = ?", []interface{}{title}, time.Minute, func() (interface{}, error) {
+			loads++
+			return q.Count(tenantDocs, "WHERE title = ?", title)
+		})
+		require.NoError(t, err)
+		return v.(int)
+	}
+
+	assert.Equal(t, 0, count(db.Querier, "a"))
+	assert.Equal(t, 0, count(db.Querier, "a"))
+	assert.Equal(t, 0, count(db.Querier, "b"))
+	assert.Equal(t, 2, loads)
+
+	require.NoError(t, db.Insert(&tenantDoc{ID: 1, Title: "a"}))
+	assert.Equal(t, 1, count(db.Querier, "a"))
+	assert.Equal(t, 3, loads)
+
+	_, err := db.Exec(`DELETE FROM docs`)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count(db.Querier, "a"), "raw Exec should not invalidate cache")
+	db.InvalidateTable(tenantDocs)
+	assert.Equal(t, 0, count(db.Querier, "a"))
+	assert.Equal(t, 4, loads)
+
+	tx, err := db.Begin()
+	require.NoError(t, err)
+	require.NoError(t, tx.Insert(&tenantDoc{ID: 2, Title: "a"}))
+	assert.Equal(t, 1, count(tx.Querier, "a"), "transaction should not use cache")
+	require.NoError(t, tx.Commit())
+	assert.Equal(t, 1, count(db.Querier, "a"))
+	assert.Equal(t, 6, loads)
+}
@@ -39,6 +39,13 @@ func (db *DB) DBInterface() DBInterface {
 	return db.db
 }
 
+// withQuerier returns a copy of DB which uses given Querier.
+func (db *DB) withQuerier(q *Querier) *DB {
+	newDB := *db
+	newDB.Querier = q
+	return &newDB
+}
+
 // Begin starts a transaction.
 func (db *DB) Begin() (*TX, error) {
 	db.logBefore("BEGIN", nil)
@@ -48,7 +55,14 @@ func (db *DB) Begin() (*TX, error) {
 	if err != nil {
 		return nil, err
 	}
-	return NewTX(tx, db.Dialect, db.Logger, db), nil
+
+	// inherit interceptors, tag and other settings of this DB
+	querier := db.Querier.clone()
+	querier.dbtx = tx
+	if querier.recordCache != nil || querier.queryCache != nil {
+		querier.txCache = new(txCache)
+	}
+	return &TX{Querier: querier, tx: tx}, nil
 }
 
 // InTransaction wraps function execution in transaction, rolling back it in case of error or panic,
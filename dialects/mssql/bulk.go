@@ -0,0 +1,18 @@
+package mssql
+
+import (
+	"github.com/xaionaro/reform"
+)
+
+// BulkInsertMethod returns reform.BulkMultiRow, see reform.BulkDialect.
+func (mssql) BulkInsertMethod() reform.BulkInsertMethod {
+	return reform.BulkMultiRow
+}
+
+// BulkInsertLimits returns limits of placeholders and rows, see reform.BulkDialect.
+func (mssql) BulkInsertLimits() (placeholders, rows int) {
+	return 2000, 1000
+}
+
+// check interface
+var _ reform.BulkDialect = Dialect
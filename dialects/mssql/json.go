@@ -0,0 +1,20 @@
+package mssql
+
+import (
+	"strings"
+
+	"github.com/xaionaro/reform"
+)
+
+// JSONPath returns JSON_VALUE() expression, see reform.JSONDialect.
+func (mssql) JSONPath(expr string, path []string) string {
+	return "JSON_VALUE(" + expr + ", N'" + strings.Replace(reform.JSONPathString(path), "'", "''", -1) + "')"
+}
+
+// JSONPlaceholder returns the placeholder as is: JSON documents are stored as text, see reform.JSONDialect.
+func (mssql) JSONPlaceholder(placeholder string) string {
+	return placeholder
+}
+
+// check interface
+var _ reform.JSONDialect = Dialect
@@ -1,7 +1,13 @@
 // Package mssql implements reform.Dialect for Microsoft SQL Server (mssql driver).
 package mssql
 
-import "github.com/xaionaro/reform"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/xaionaro/reform"
+)
 
 type mssql struct{}
 
@@ -37,13 +43,111 @@ func (mssql) DefaultValuesMethod() reform.DefaultValuesMethod {
 	return reform.DefaultValues
 }
 
+func (mssql) ExplainMethod() reform.ExplainMethod {
+	return reform.ShowPlanXML
+}
+
+func (mssql) ColumnTypeForField(field reform.FieldInfo) string {
+	if field.SQLType != "" {
+		return field.SQLType
+	}
+	if field.IsJSON {
+		return "nvarchar(max)"
+	}
+	if field.Encryption.Enabled() {
+		return "nvarchar(max)"
+	}
+	if field.IsUUID() {
+		return "uniqueidentifier"
+	}
+
+	// keys can't use (max) columns, 900 bytes is a maximum key size
+	keyed := field.IsPK || field.IsUnique || field.HasIndex
+
+	switch t := field.BaseType(); t {
+	case "time.Time", "extime.Time":
+		return "datetime2"
+	case "bool":
+		return "bit"
+	case "uint8":
+		return "tinyint"
+	case "int8", "int16":
+		return "smallint"
+	case "int", "int32", "uint16":
+		return "int"
+	case "int64", "uint", "uint32", "uint64":
+		return "bigint"
+	case "float32", "float64":
+		if field.SQLPrecision > 0 {
+			return fmt.Sprintf("decimal(%d,%d)", field.SQLPrecision, field.SQLScale)
+		}
+		if t == "float32" {
+			return "real"
+		}
+		return "float"
+	case "string":
+		if field.SQLSize > 0 && field.SQLSize <= 4000 {
+			return fmt.Sprintf("nvarchar(%d)", field.SQLSize)
+		}
+		if keyed {
+			return "nvarchar(450)"
+		}
+		return "nvarchar(max)"
+	case "[]uint8":
+		if field.SQLSize > 0 && field.SQLSize <= 8000 {
+			return fmt.Sprintf("varbinary(%d)", field.SQLSize)
+		}
+		if keyed {
+			return "varbinary(900)"
+		}
+		return "varbinary(max)"
+	default:
+		if n := byteArrayLen(t); n > 0 {
+			return fmt.Sprintf("binary(%d)", n)
+		}
+		return "nvarchar(max)"
+	}
+}
+
 func (mssql) ColumnDefinitionForField(field reform.FieldInfo) string {
-	panic("Is not implemented, yet")
-	return ""
+	definition := Dialect.QuoteIdentifier(field.Column) + " " + Dialect.ColumnTypeForField(field)
+
+	if field.IsAutoIncrement() {
+		definition += " IDENTITY(1,1)"
+	}
+
+	if field.IsNullable() {
+		definition += " NULL"
+	} else {
+		definition += " NOT NULL"
+	}
+
+	if field.IsPK {
+		definition += " PRIMARY KEY"
+	}
+
+	if field.Default != "" {
+		definition += " DEFAULT " + field.Default
+	}
+
+	for _, check := range field.Checks(Dialect, false) {
+		definition += " CHECK (" + check + ")"
+	}
+
+	return definition
+}
+
+// byteArrayLen returns N for [N]uint8 type, or 0.
+func byteArrayLen(t string) int {
+	if !strings.HasPrefix(t, "[") || !strings.HasSuffix(t, "]uint8") {
+		return 0
+	}
+	n, _ := strconv.Atoi(t[1 : len(t)-len("]uint8")])
+	return n
 }
 
 func (mssql) ColumnDefinitionPostQueryForField(structInfo reform.StructInfo, field reform.FieldInfo) string {
-	panic("Is not implemented, yet")
+	// indexes are created by reform.Querier.CreateTableIfNotExists
 	return ""
 }
 
@@ -51,4 +155,8 @@ func (mssql) ColumnDefinitionPostQueryForField(structInfo reform.StructInfo, fie
 var Dialect mssql
 
 // check interface
-var _ reform.Dialect = Dialect
+var (
+	_ reform.Dialect           = Dialect
+	_ reform.ColumnTypeDialect = Dialect
+	_ reform.ExplainDialect    = Dialect
+)
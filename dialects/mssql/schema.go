@@ -0,0 +1,127 @@
+package mssql
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/xaionaro/reform"
+)
+
+// literal returns N'...' string literal. Queries below use literals instead of placeholders,
+// so they work with both mssql and sqlserver drivers.
+func literal(s string) string {
+	return "N'" + strings.Replace(s, "'", "''", -1) + "'"
+}
+
+// InspectTable returns a schema of existing table using INFORMATION_SCHEMA and sys views, or nil.
+// Empty schema means the default one.
+func (mssql) InspectTable(q reform.DBTX, schema, table string) (*reform.TableSchema, error) {
+	rows, err := q.Query(`SELECT COLUMN_NAME, DATA_TYPE, CHARACTER_MAXIMUM_LENGTH, NUMERIC_PRECISION, NUMERIC_SCALE, IS_NULLABLE
+		FROM INFORMATION_SCHEMA.COLUMNS
+		WHERE TABLE_SCHEMA = COALESCE(NULLIF(` + literal(schema) + `, ''), SCHEMA_NAME()) AND TABLE_NAME = ` + literal(table) + `
+		ORDER BY ORDINAL_POSITION`)
+	if err != nil {
+		return nil, err
+	}
+	res := &reform.TableSchema{Schema: schema, Name: table}
+	for rows.Next() {
+		var name, typ, nullable string
+		var length, precision, scale sql.NullInt64
+		if err = rows.Scan(&name, &typ, &length, &precision, &scale, &nullable); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		typ = strings.ToLower(typ)
+		switch typ {
+		case "nvarchar", "varchar", "nchar", "char", "varbinary", "binary":
+			if length.Int64 == -1 {
+				typ += "(max)"
+			} else {
+				typ = fmt.Sprintf("%s(%d)", typ, length.Int64)
+			}
+		case "decimal", "numeric":
+			typ = fmt.Sprintf("%s(%d,%d)", typ, precision.Int64, scale.Int64)
+		}
+		res.Columns = append(res.Columns, reform.ColumnSchema{Name: name, Type: typ, Nullable: nullable == "YES"})
+	}
+	if err = rows.Close(); err != nil {
+		return nil, err
+	}
+	if len(res.Columns) == 0 {
+		return nil, nil
+	}
+
+	object := Dialect.QuoteIdentifier(table)
+	if schema != "" {
+		object = Dialect.QuoteIdentifier(schema) + "." + object
+	}
+	rows, err = q.Query(`SELECT i.name, i.is_unique, i.is_primary_key, c.name
+		FROM sys.indexes i
+		JOIN sys.index_columns ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id
+		JOIN sys.columns c ON c.object_id = ic.object_id AND c.column_id = ic.column_id
+		WHERE i.object_id = OBJECT_ID(` + literal(object) + `) AND ic.is_included_column = 0
+		ORDER BY i.name, ic.key_ordinal`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name, column string
+		var unique, primary bool
+		if err = rows.Scan(&name, &unique, &primary, &column); err != nil {
+			return nil, err
+		}
+		if l := len(res.Indexes); l == 0 || res.Indexes[l-1].Name != name {
+			res.Indexes = append(res.Indexes, reform.IndexSchema{Name: name, Unique: unique, Primary: primary})
+		}
+		index := &res.Indexes[len(res.Indexes)-1]
+		index.Columns = append(index.Columns, column)
+	}
+	return res, rows.Err()
+}
+
+// AlterTableQueries returns queries applying given change.
+func (mssql) AlterTableQueries(table string, change reform.SchemaChange) []string {
+	column := Dialect.QuoteIdentifier(change.Column)
+	definition := column + " " + Dialect.ColumnTypeForField(change.Field)
+	if change.Field.IsNullable() {
+		definition += " NULL"
+	} else {
+		definition += " NOT NULL"
+	}
+
+	switch change.Kind {
+	case reform.MissingColumn:
+		if change.Field.Default != "" {
+			definition += " DEFAULT " + change.Field.Default
+		}
+		for _, check := range change.Field.Checks(Dialect, false) {
+			definition += " CHECK (" + check + ")"
+		}
+		res := []string{fmt.Sprintf("ALTER TABLE %s ADD %s", table, definition)}
+		if fk := change.Field.ForeignKeyDefinition(Dialect); fk != "" {
+			res = append(res, fmt.Sprintf("ALTER TABLE %s ADD %s", table, fk))
+		}
+		return res
+
+	case reform.ColumnTypeMismatch, reform.ColumnNullabilityMismatch:
+		return []string{fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s", table, definition)}
+
+	case reform.PrimaryKeyMismatch:
+		if change.Column == "" {
+			return nil
+		}
+		var res []string
+		if change.Index != "" {
+			res = append(res, fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s", table, Dialect.QuoteIdentifier(change.Index)))
+		}
+		return append(res, fmt.Sprintf("ALTER TABLE %s ADD PRIMARY KEY (%s)", table, column))
+
+	default:
+		return nil
+	}
+}
+
+// check interface
+var _ reform.SchemaDialect = Dialect
@@ -0,0 +1,13 @@
+package mssql
+
+import (
+	"github.com/xaionaro/reform"
+)
+
+// NextValQuery returns NEXT VALUE FOR query, see reform.SequenceDialect.
+func (mssql) NextValQuery(sequence string) string {
+	return "SELECT NEXT VALUE FOR " + sequence
+}
+
+// check interface
+var _ reform.SequenceDialect = Dialect
@@ -0,0 +1,18 @@
+package mysql
+
+import (
+	"github.com/xaionaro/reform"
+)
+
+// BulkInsertMethod returns reform.BulkMultiRow, see reform.BulkDialect.
+func (mysql) BulkInsertMethod() reform.BulkInsertMethod {
+	return reform.BulkMultiRow
+}
+
+// BulkInsertLimits returns limits of placeholders and rows, see reform.BulkDialect.
+func (mysql) BulkInsertLimits() (placeholders, rows int) {
+	return 65535, 0
+}
+
+// check interface
+var _ reform.BulkDialect = Dialect
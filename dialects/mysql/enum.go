@@ -0,0 +1,21 @@
+package mysql
+
+import (
+	"strings"
+
+	"github.com/xaionaro/reform"
+)
+
+// nativeEnum returns true if the enum field is stored as ENUM column: only string enums are.
+func nativeEnum(field reform.FieldInfo) bool {
+	return field.IsEnum() && field.BaseType() == "string" && field.SQLType == ""
+}
+
+// enumType returns ENUM column type for the enum field, in the same form as information_schema shows it.
+func enumType(field reform.FieldInfo) string {
+	values := make([]string, len(field.EnumValues))
+	for i, v := range field.EnumValues {
+		values[i] = "'" + strings.NewReplacer(`\`, `\\`, "'", "''").Replace(v) + "'"
+	}
+	return "enum(" + strings.Join(values, ",") + ")"
+}
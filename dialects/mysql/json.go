@@ -0,0 +1,21 @@
+package mysql
+
+import (
+	"strings"
+
+	"github.com/xaionaro/reform"
+)
+
+// JSONPath returns JSON_EXTRACT() expression, see reform.JSONDialect.
+func (mysql) JSONPath(expr string, path []string) string {
+	literal := strings.NewReplacer(`\`, `\\`, "'", "''").Replace(reform.JSONPathString(path))
+	return "JSON_UNQUOTE(JSON_EXTRACT(" + expr + ", '" + literal + "'))"
+}
+
+// JSONPlaceholder returns CAST(... AS JSON) expression, see reform.JSONDialect.
+func (mysql) JSONPlaceholder(placeholder string) string {
+	return "CAST(" + placeholder + " AS JSON)"
+}
+
+// check interface
+var _ reform.JSONDialect = Dialect
@@ -3,6 +3,9 @@ package mysql
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/xaionaro/reform"
 )
 
@@ -40,64 +43,129 @@ func (mysql) DefaultValuesMethod() reform.DefaultValuesMethod {
 	return reform.EmptyLists
 }
 
+func (mysql) ExplainMethod() reform.ExplainMethod {
+	return reform.ExplainFormatJSON
+}
+
 func (mysql) ColumnTypeForField(field reform.FieldInfo) string {
-	if len(field.Type) == 0 {
+	if field.SQLType != "" {
+		return field.SQLType
+	}
+	if field.IsJSON {
+		return "json"
+	}
+	if nativeEnum(field) {
+		return enumType(field)
+	}
+
+	// keys can't use TEXT and BLOB columns without prefix length
+	keyed := field.IsPK || field.IsUnique || field.HasIndex
+
+	if field.Encryption.Enabled() {
+		// ciphertext is text longer than the value
+		if keyed {
+			return "varchar(512)"
+		}
 		return "text"
 	}
-	if field.Type[:1] == "*" {
-		field.Type = field.Type[1:]
+	if field.IsUUID() {
+		if strings.TrimPrefix(field.Type, "*") == "reform.UUID" {
+			return "binary(16)"
+		}
+		return "char(36)"
 	}
-	switch field.Type {
+
+	switch t := field.BaseType(); t {
 	case "time.Time", "extime.Time":
 		return "datetime"
-	case "int":
-		return "integer"
+	case "bool":
+		return "tinyint(1)"
+	case "int8":
+		return "tinyint"
+	case "uint8":
+		return "tinyint unsigned"
+	case "int16":
+		return "smallint"
+	case "uint16":
+		return "smallint unsigned"
+	case "int", "int32":
+		return "int"
+	case "uint32":
+		return "int unsigned"
 	case "int64":
 		return "bigint"
+	case "uint", "uint64":
+		return "bigint unsigned"
+	case "float32", "float64":
+		if field.SQLPrecision > 0 {
+			return fmt.Sprintf("decimal(%d,%d)", field.SQLPrecision, field.SQLScale)
+		}
+		if t == "float32" {
+			return "float"
+		}
+		return "double"
 	case "string":
 		if field.SQLSize > 0 && field.SQLSize < 256 {
 			return fmt.Sprintf("varchar(%d)", field.SQLSize)
 		}
+		if keyed {
+			return "varchar(255)"
+		}
 		return "text"
+	case "[]uint8":
+		if field.SQLSize > 0 && field.SQLSize < 256 {
+			return fmt.Sprintf("varbinary(%d)", field.SQLSize)
+		}
+		if keyed {
+			return "varbinary(255)"
+		}
+		return "blob"
 	default:
+		if n := byteArrayLen(t); n > 0 {
+			return fmt.Sprintf("binary(%d)", n)
+		}
 		return "text"
 	}
 }
 
 func (mysql) ColumnDefinitionForField(field reform.FieldInfo) string {
-	canBeNull := false
-	fieldType := field.Type
-	if fieldType[0:1] == "*" {
-		canBeNull = true
-		fieldType = fieldType[1:]
-	}
+	definition := Dialect.QuoteIdentifier(field.Column) + " " + Dialect.ColumnTypeForField(field)
 
-	columnType := Dialect.ColumnTypeForField(field)
-
-	definition := field.Column + " " + columnType // TODO: escape everything
+	if field.IsNullable() {
+		definition += " NULL"
+	} else {
+		definition += " NOT NULL"
+	}
 
 	if field.IsPK {
-		definition += " PRIMARY KEY"
-		if fieldType == "int" {
+		if field.IsAutoIncrement() {
 			definition += " AUTO_INCREMENT"
 		}
+		definition += " PRIMARY KEY"
 	}
 
-	if field.IsUnique {
-		definition += " UNIQUE"
+	if field.Default != "" {
+		definition += " DEFAULT " + field.Default
 	}
 
-	if !canBeNull {
-		definition += " NOT NULL"
-	}
-	if field.HasIndex {
-		definition += ", INDEX (`" + field.Column + "`)"
+	for _, check := range field.Checks(Dialect, nativeEnum(field)) {
+		definition += " CHECK (" + check + ")"
 	}
 
 	return definition
 }
 
+// byteArrayLen returns N for [N]uint8 type, or 0.
+func byteArrayLen(t string) int {
+	if !strings.HasPrefix(t, "[") || !strings.HasSuffix(t, "]uint8") {
+		return 0
+	}
+	n, _ := strconv.Atoi(t[1 : len(t)-len("]uint8")])
+	return n
+}
+
 func (mysql) ColumnDefinitionPostQueryForField(structInfo reform.StructInfo, field reform.FieldInfo) string {
+	// indexes are created by reform.Querier.CreateTableIfNotExists
 	return ""
 }
 
@@ -105,4 +173,8 @@ func (mysql) ColumnDefinitionPostQueryForField(structInfo reform.StructInfo, fie
 var Dialect mysql
 
 // check interface
-var _ reform.Dialect = Dialect
+var (
+	_ reform.Dialect           = Dialect
+	_ reform.ColumnTypeDialect = Dialect
+	_ reform.ExplainDialect    = Dialect
+)
@@ -0,0 +1,119 @@
+package mysql
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/xaionaro/reform"
+)
+
+// displayWidth matches integer types with display width, like int(11).
+var displayWidth = regexp.MustCompile(`^(tinyint|smallint|mediumint|int|bigint)\(\d+\)`)
+
+// InspectTable returns a schema of existing table using information_schema, or nil.
+// Empty schema means the current database.
+func (mysql) InspectTable(q reform.DBTX, schema, table string) (*reform.TableSchema, error) {
+	rows, err := q.Query(`SELECT COLUMN_NAME, COLUMN_TYPE, IS_NULLABLE
+		FROM information_schema.COLUMNS
+		WHERE TABLE_SCHEMA = COALESCE(NULLIF(?, ''), DATABASE()) AND TABLE_NAME = ?
+		ORDER BY ORDINAL_POSITION`, schema, table)
+	if err != nil {
+		return nil, err
+	}
+	res := &reform.TableSchema{Schema: schema, Name: table}
+	for rows.Next() {
+		var name, typ, nullable string
+		if err = rows.Scan(&name, &typ, &nullable); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		typ = strings.ToLower(typ)
+		if typ != "tinyint(1)" {
+			// display width is deprecated and not shown since MySQL 8.0.19
+			typ = displayWidth.ReplaceAllString(typ, "$1")
+		}
+		res.Columns = append(res.Columns, reform.ColumnSchema{Name: name, Type: typ, Nullable: nullable == "YES"})
+	}
+	if err = rows.Close(); err != nil {
+		return nil, err
+	}
+	if len(res.Columns) == 0 {
+		return nil, nil
+	}
+
+	rows, err = q.Query(`SELECT INDEX_NAME, NON_UNIQUE, COLUMN_NAME
+		FROM information_schema.STATISTICS
+		WHERE TABLE_SCHEMA = COALESCE(NULLIF(?, ''), DATABASE()) AND TABLE_NAME = ?
+		ORDER BY INDEX_NAME, SEQ_IN_INDEX`, schema, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name, column string
+		var nonUnique bool
+		if err = rows.Scan(&name, &nonUnique, &column); err != nil {
+			return nil, err
+		}
+		if l := len(res.Indexes); l == 0 || res.Indexes[l-1].Name != name {
+			res.Indexes = append(res.Indexes, reform.IndexSchema{Name: name, Unique: !nonUnique, Primary: name == "PRIMARY"})
+		}
+		index := &res.Indexes[len(res.Indexes)-1]
+		index.Columns = append(index.Columns, column)
+	}
+	return res, rows.Err()
+}
+
+// AlterTableQueries returns queries applying given change.
+func (mysql) AlterTableQueries(table string, change reform.SchemaChange) []string {
+	column := Dialect.QuoteIdentifier(change.Column)
+	definition := column + " " + Dialect.ColumnTypeForField(change.Field)
+	if change.Field.IsNullable() {
+		definition += " NULL"
+	} else {
+		definition += " NOT NULL"
+	}
+	if change.Field.Default != "" {
+		definition += " DEFAULT " + change.Field.Default
+	}
+
+	switch change.Kind {
+	case reform.MissingColumn:
+		for _, check := range change.Field.Checks(Dialect, nativeEnum(change.Field)) {
+			definition += " CHECK (" + check + ")"
+		}
+		res := []string{fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", table, definition)}
+		if fk := change.Field.ForeignKeyDefinition(Dialect); fk != "" {
+			res = append(res, fmt.Sprintf("ALTER TABLE %s ADD %s", table, fk))
+		}
+		return res
+
+	case reform.ColumnTypeMismatch, reform.ColumnNullabilityMismatch:
+		return []string{fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s", table, definition)}
+
+	case reform.PrimaryKeyMismatch:
+		if change.Column == "" {
+			return nil
+		}
+		if change.Index == "" {
+			return []string{fmt.Sprintf("ALTER TABLE %s ADD PRIMARY KEY (%s)", table, column)}
+		}
+		return []string{fmt.Sprintf("ALTER TABLE %s DROP PRIMARY KEY, ADD PRIMARY KEY (%s)", table, column)}
+
+	default:
+		return nil
+	}
+}
+
+// TransactionalDDL implements reform.DDLTransactionDialect: MySQL commits
+// the current transaction implicitly before and after DDL statements.
+func (mysql) TransactionalDDL() bool {
+	return false
+}
+
+// check interface
+var (
+	_ reform.SchemaDialect         = Dialect
+	_ reform.DDLTransactionDialect = Dialect
+)
@@ -0,0 +1,13 @@
+package mysql
+
+import (
+	"github.com/xaionaro/reform"
+)
+
+// UUIDValue returns 16 bytes of UUID for BINARY(16) column, see reform.UUIDDialect.
+func (mysql) UUIDValue(u reform.UUID) interface{} {
+	return u[:]
+}
+
+// check interface
+var _ reform.UUIDDialect = Dialect
@@ -0,0 +1,23 @@
+package postgresql
+
+import (
+	"github.com/xaionaro/reform"
+)
+
+// ArrayContains returns @> operator expression, see reform.ArrayDialect.
+func (postgresql) ArrayContains(expr, value string) string {
+	return expr + " @> " + value
+}
+
+// ArrayOverlaps returns && operator expression, see reform.ArrayDialect.
+func (postgresql) ArrayOverlaps(expr, value string) string {
+	return expr + " && " + value
+}
+
+// ArrayAny returns = ANY(...) expression, see reform.ArrayDialect.
+func (postgresql) ArrayAny(expr, value string) string {
+	return value + " = ANY(" + expr + ")"
+}
+
+// check interface
+var _ reform.ArrayDialect = Dialect
@@ -0,0 +1,18 @@
+package postgresql
+
+import (
+	"github.com/xaionaro/reform"
+)
+
+// BulkInsertMethod returns reform.BulkCopy, see reform.BulkDialect.
+func (postgresql) BulkInsertMethod() reform.BulkInsertMethod {
+	return reform.BulkCopy
+}
+
+// BulkInsertLimits returns limits of placeholders and rows, see reform.BulkDialect.
+func (postgresql) BulkInsertLimits() (placeholders, rows int) {
+	return 65535, 0
+}
+
+// check interface
+var _ reform.BulkDialect = Dialect
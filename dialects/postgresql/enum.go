@@ -0,0 +1,25 @@
+package postgresql
+
+import (
+	"strings"
+
+	"github.com/xaionaro/reform"
+)
+
+// nativeEnum returns true if the enum field is stored as enum type: only string enums are.
+func nativeEnum(field reform.FieldInfo) bool {
+	return field.IsEnum() && field.BaseType() == "string" && field.SQLType == ""
+}
+
+// CreateEnumTypeQuery returns CREATE TYPE ... AS ENUM query ignoring existing type, see reform.EnumTypeDialect.
+// Values added to existing type are not considered.
+func (postgresql) CreateEnumTypeQuery(field reform.FieldInfo) string {
+	if !nativeEnum(field) {
+		return ""
+	}
+	return "DO $$ BEGIN CREATE TYPE " + Dialect.QuoteIdentifier(field.EnumTypeName()) +
+		" AS ENUM (" + strings.Join(field.EnumLiterals(), ", ") + "); EXCEPTION WHEN duplicate_object THEN NULL; END $$"
+}
+
+// check interface
+var _ reform.EnumTypeDialect = Dialect
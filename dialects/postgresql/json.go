@@ -0,0 +1,25 @@
+package postgresql
+
+import (
+	"strings"
+
+	"github.com/xaionaro/reform"
+)
+
+// JSONPath returns #>> operator expression, see reform.JSONDialect.
+func (postgresql) JSONPath(expr string, path []string) string {
+	elements := make([]string, len(path))
+	for i, p := range path {
+		elements[i] = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(p) + `"`
+	}
+	literal := strings.Replace("{"+strings.Join(elements, ",")+"}", "'", "''", -1)
+	return "(" + expr + " #>> '" + literal + "')"
+}
+
+// JSONPlaceholder returns CAST(... AS jsonb) expression, see reform.JSONDialect.
+func (postgresql) JSONPlaceholder(placeholder string) string {
+	return "CAST(" + placeholder + " AS jsonb)"
+}
+
+// check interface
+var _ reform.JSONDialect = Dialect
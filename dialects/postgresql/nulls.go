@@ -0,0 +1,13 @@
+package postgresql
+
+import (
+	"github.com/xaionaro/reform"
+)
+
+// NullsLast returns true: NULL values are larger than all other values, see reform.NullsOrderDialect.
+func (postgresql) NullsLast() bool {
+	return true
+}
+
+// check interface
+var _ reform.NullsOrderDialect = Dialect
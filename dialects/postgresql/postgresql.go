@@ -2,7 +2,9 @@
 package postgresql
 
 import (
+	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/xaionaro/reform"
 )
@@ -41,13 +43,104 @@ func (postgresql) DefaultValuesMethod() reform.DefaultValuesMethod {
 	return reform.DefaultValues
 }
 
+func (postgresql) ExplainMethod() reform.ExplainMethod {
+	return reform.ExplainJSON
+}
+
+func (postgresql) ColumnTypeForField(field reform.FieldInfo) string {
+	if field.SQLType != "" {
+		return field.SQLType
+	}
+	if field.IsJSON {
+		return "jsonb"
+	}
+	if field.Encryption.Enabled() {
+		return "text"
+	}
+	if field.IsUUID() {
+		return "uuid"
+	}
+	if nativeEnum(field) {
+		return field.EnumTypeName()
+	}
+	if field.IsArray {
+		element := field
+		element.Type, element.Kind, element.IsArray = strings.TrimPrefix(field.BaseType(), "[]"), "", false
+		return Dialect.ColumnTypeForField(element) + "[]"
+	}
+
+	switch t := field.BaseType(); t {
+	case "time.Time", "extime.Time":
+		// time.Time carries a time zone, timestamp without it is read back as UTC
+		return "timestamptz"
+	case "bool":
+		return "boolean"
+	case "int8", "int16", "uint8":
+		return "smallint"
+	case "int", "int32", "uint16":
+		return "integer"
+	case "int64", "uint32":
+		return "bigint"
+	case "uint", "uint64":
+		// bigint can't store values above 1<<63-1
+		return "numeric(20,0)"
+	case "float32", "float64":
+		if field.SQLPrecision > 0 {
+			return fmt.Sprintf("numeric(%d,%d)", field.SQLPrecision, field.SQLScale)
+		}
+		if t == "float32" {
+			return "real"
+		}
+		return "double precision"
+	case "string":
+		if field.SQLSize > 0 {
+			return fmt.Sprintf("varchar(%d)", field.SQLSize)
+		}
+		return "text"
+	default:
+		if strings.HasSuffix(t, "]uint8") {
+			return "bytea"
+		}
+		return "text"
+	}
+}
+
 func (postgresql) ColumnDefinitionForField(field reform.FieldInfo) string {
-	panic("Is not implemented, yet")
-	return ""
+	columnType := Dialect.ColumnTypeForField(field)
+	if field.IsAutoIncrement() {
+		switch columnType {
+		case "smallint":
+			columnType = "smallserial"
+		case "integer":
+			columnType = "serial"
+		case "bigint", "numeric(20,0)":
+			columnType = "bigserial"
+		}
+	}
+
+	definition := Dialect.QuoteIdentifier(field.Column) + " " + columnType
+
+	if !field.IsNullable() {
+		definition += " NOT NULL"
+	}
+
+	if field.IsPK {
+		definition += " PRIMARY KEY"
+	}
+
+	if field.Default != "" {
+		definition += " DEFAULT " + field.Default
+	}
+
+	for _, check := range field.Checks(Dialect, nativeEnum(field)) {
+		definition += " CHECK (" + check + ")"
+	}
+
+	return definition
 }
 
 func (postgresql) ColumnDefinitionPostQueryForField(structInfo reform.StructInfo, field reform.FieldInfo) string {
-	panic("Is not implemented, yet")
+	// indexes are created by reform.Querier.CreateTableIfNotExists
 	return ""
 }
 
@@ -55,4 +148,8 @@ func (postgresql) ColumnDefinitionPostQueryForField(structInfo reform.StructInfo
 var Dialect postgresql
 
 // check interface
-var _ reform.Dialect = Dialect
+var (
+	_ reform.Dialect           = Dialect
+	_ reform.ColumnTypeDialect = Dialect
+	_ reform.ExplainDialect    = Dialect
+)
@@ -0,0 +1,162 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/xaionaro/reform"
+)
+
+// udtTypes maps internal names of types to ones used in DDL.
+var udtTypes = map[string]string{
+	"bool":   "boolean",
+	"int2":   "smallint",
+	"int4":   "integer",
+	"int8":   "bigint",
+	"float4": "real",
+	"float8": "double precision",
+	"bpchar": "char",
+}
+
+// InspectTable returns a schema of existing table using information_schema and pg_catalog, or nil.
+// Empty schema means the current one.
+func (postgresql) InspectTable(q reform.DBTX, schema, table string) (*reform.TableSchema, error) {
+	rows, err := q.Query(`SELECT column_name, data_type, udt_name, character_maximum_length, numeric_precision, numeric_scale, is_nullable
+		FROM information_schema.columns
+		WHERE table_schema = COALESCE(NULLIF($1, ''), current_schema()) AND table_name = $2
+		ORDER BY ordinal_position`, schema, table)
+	if err != nil {
+		return nil, err
+	}
+	res := &reform.TableSchema{Schema: schema, Name: table}
+	for rows.Next() {
+		var name, typ, udt, nullable string
+		var length, precision, scale sql.NullInt64
+		if err = rows.Scan(&name, &typ, &udt, &length, &precision, &scale, &nullable); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		switch typ {
+		case "character varying", "character":
+			typ = "varchar"
+			if udt == "bpchar" {
+				typ = "char"
+			}
+			if length.Valid {
+				typ = fmt.Sprintf("%s(%d)", typ, length.Int64)
+			}
+		case "numeric":
+			if precision.Valid {
+				typ = fmt.Sprintf("numeric(%d,%d)", precision.Int64, scale.Int64)
+			}
+		case "timestamp without time zone":
+			typ = "timestamp"
+		case "timestamp with time zone":
+			typ = "timestamptz"
+		case "ARRAY":
+			// udt_name of array is element's one prefixed with underscore, e.g. _int4
+			typ = strings.TrimPrefix(udt, "_")
+			if t, ok := udtTypes[typ]; ok {
+				typ = t
+			}
+			typ += "[]"
+		case "USER-DEFINED":
+			typ = udt
+		}
+		res.Columns = append(res.Columns, reform.ColumnSchema{Name: name, Type: typ, Nullable: nullable == "YES"})
+	}
+	if err = rows.Close(); err != nil {
+		return nil, err
+	}
+	if len(res.Columns) == 0 {
+		return nil, nil
+	}
+
+	rows, err = q.Query(`SELECT i.relname, ix.indisunique, ix.indisprimary, a.attname
+		FROM pg_index ix
+		JOIN pg_class t ON t.oid = ix.indrelid
+		JOIN pg_class i ON i.oid = ix.indexrelid
+		JOIN pg_namespace n ON n.oid = t.relnamespace
+		JOIN LATERAL unnest(ix.indkey) WITH ORDINALITY AS k(attnum, ord) ON true
+		JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = k.attnum
+		WHERE n.nspname = COALESCE(NULLIF($1, ''), current_schema()) AND t.relname = $2
+		ORDER BY i.relname, k.ord`, schema, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name, column string
+		var unique, primary bool
+		if err = rows.Scan(&name, &unique, &primary, &column); err != nil {
+			return nil, err
+		}
+		if l := len(res.Indexes); l == 0 || res.Indexes[l-1].Name != name {
+			res.Indexes = append(res.Indexes, reform.IndexSchema{Name: name, Unique: unique, Primary: primary})
+		}
+		index := &res.Indexes[len(res.Indexes)-1]
+		index.Columns = append(index.Columns, column)
+	}
+	return res, rows.Err()
+}
+
+// AlterTableQueries returns queries applying given change.
+func (postgresql) AlterTableQueries(table string, change reform.SchemaChange) []string {
+	column := Dialect.QuoteIdentifier(change.Column)
+	columnType := Dialect.ColumnTypeForField(change.Field)
+
+	switch change.Kind {
+	case reform.MissingColumn:
+		definition := column + " " + columnType
+		if !change.Field.IsNullable() {
+			definition += " NOT NULL"
+		}
+		if change.Field.Default != "" {
+			definition += " DEFAULT " + change.Field.Default
+		}
+		for _, check := range change.Field.Checks(Dialect, nativeEnum(change.Field)) {
+			definition += " CHECK (" + check + ")"
+		}
+		var res []string
+		if query := Dialect.CreateEnumTypeQuery(change.Field); query != "" {
+			res = append(res, query)
+		}
+		res = append(res, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", table, definition))
+		if fk := change.Field.ForeignKeyDefinition(Dialect); fk != "" {
+			res = append(res, fmt.Sprintf("ALTER TABLE %s ADD %s", table, fk))
+		}
+		return res
+
+	case reform.ColumnTypeMismatch:
+		if query := Dialect.CreateEnumTypeQuery(change.Field); query != "" {
+			// text columns are not converted to enum types implicitly
+			return []string{query, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s USING %s::text::%s",
+				table, column, columnType, column, columnType)}
+		}
+		return []string{fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s", table, column, columnType)}
+
+	case reform.ColumnNullabilityMismatch:
+		action := "SET"
+		if change.Field.IsNullable() {
+			action = "DROP"
+		}
+		return []string{fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s NOT NULL", table, column, action)}
+
+	case reform.PrimaryKeyMismatch:
+		if change.Column == "" {
+			return nil
+		}
+		if change.Index == "" {
+			return []string{fmt.Sprintf("ALTER TABLE %s ADD PRIMARY KEY (%s)", table, column)}
+		}
+		return []string{fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s, ADD PRIMARY KEY (%s)",
+			table, Dialect.QuoteIdentifier(change.Index), column)}
+
+	default:
+		return nil
+	}
+}
+
+// check interface
+var _ reform.SchemaDialect = Dialect
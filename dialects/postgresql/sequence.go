@@ -0,0 +1,15 @@
+package postgresql
+
+import (
+	"strings"
+
+	"github.com/xaionaro/reform"
+)
+
+// NextValQuery returns nextval() query, see reform.SequenceDialect.
+func (postgresql) NextValQuery(sequence string) string {
+	return "SELECT nextval('" + strings.Replace(sequence, "'", "''", -1) + "')"
+}
+
+// check interface
+var _ reform.SequenceDialect = Dialect
@@ -0,0 +1,18 @@
+package sqlite3
+
+import (
+	"github.com/xaionaro/reform"
+)
+
+// BulkInsertMethod returns reform.BulkPrepared, see reform.BulkDialect.
+func (sqlite3) BulkInsertMethod() reform.BulkInsertMethod {
+	return reform.BulkPrepared
+}
+
+// BulkInsertLimits returns limits of placeholders and rows, see reform.BulkDialect.
+func (sqlite3) BulkInsertLimits() (placeholders, rows int) {
+	return 999, 0
+}
+
+// check interface
+var _ reform.BulkDialect = Dialect
@@ -0,0 +1,20 @@
+package sqlite3
+
+import (
+	"strings"
+
+	"github.com/xaionaro/reform"
+)
+
+// JSONPath returns json_extract() expression, see reform.JSONDialect.
+func (sqlite3) JSONPath(expr string, path []string) string {
+	return "json_extract(" + expr + ", '" + strings.Replace(reform.JSONPathString(path), "'", "''", -1) + "')"
+}
+
+// JSONPlaceholder returns json() expression, see reform.JSONDialect.
+func (sqlite3) JSONPlaceholder(placeholder string) string {
+	return "json(" + placeholder + ")"
+}
+
+// check interface
+var _ reform.JSONDialect = Dialect
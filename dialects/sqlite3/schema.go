@@ -0,0 +1,300 @@
+package sqlite3
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/xaionaro/reform"
+)
+
+// InspectTable returns a schema of existing table using PRAGMA table_info and index_list, or nil.
+func (sqlite3) InspectTable(q reform.DBTX, schema, table string) (*reform.TableSchema, error) {
+	var prefix string
+	if schema != "" {
+		prefix = Dialect.QuoteIdentifier(schema) + "."
+	}
+
+	rows, err := q.Query("PRAGMA " + prefix + "table_info(" + Dialect.QuoteIdentifier(table) + ")")
+	if err != nil {
+		return nil, err
+	}
+	res := &reform.TableSchema{Schema: schema, Name: table}
+	pk := make(map[int]string)
+	for rows.Next() {
+		var cid, notNull, pkIndex int
+		var name, typ string
+		var defaultValue sql.NullString
+		if err = rows.Scan(&cid, &name, &typ, &notNull, &defaultValue, &pkIndex); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		res.Columns = append(res.Columns, reform.ColumnSchema{
+			Name:     name,
+			Type:     strings.ToLower(strings.TrimSpace(typ)),
+			Nullable: notNull == 0,
+		})
+		if pkIndex > 0 {
+			pk[pkIndex] = name
+		}
+	}
+	if err = rows.Close(); err != nil {
+		return nil, err
+	}
+	if len(res.Columns) == 0 {
+		return nil, nil
+	}
+
+	if len(pk) > 0 {
+		primary := reform.IndexSchema{Unique: true, Primary: true}
+		for i := 1; i <= len(pk); i++ {
+			primary.Columns = append(primary.Columns, pk[i])
+		}
+		res.Indexes = append(res.Indexes, primary)
+	}
+
+	// read index names first: a connection may be the only one
+	rows, err = q.Query("PRAGMA " + prefix + "index_list(" + Dialect.QuoteIdentifier(table) + ")")
+	if err != nil {
+		return nil, err
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		rows.Close()
+		return nil, err
+	}
+	var indexes []reform.IndexSchema
+	for rows.Next() {
+		// seq, name, unique, and, for newer versions, origin and partial
+		values := make([]interface{}, len(columns))
+		var name, origin string
+		var unique bool
+		values[1], values[2] = &name, &unique
+		for i := range values {
+			if values[i] == nil {
+				values[i] = new(interface{})
+			}
+		}
+		if len(values) > 3 {
+			values[3] = &origin
+		}
+		if err = rows.Scan(values...); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if origin == "pk" {
+			continue
+		}
+		indexes = append(indexes, reform.IndexSchema{Name: name, Unique: unique})
+	}
+	if err = rows.Close(); err != nil {
+		return nil, err
+	}
+
+	for _, index := range indexes {
+		rows, err = q.Query("PRAGMA " + prefix + "index_info(" + Dialect.QuoteIdentifier(index.Name) + ")")
+		if err != nil {
+			return nil, err
+		}
+		byRank := make(map[int]string)
+		for rows.Next() {
+			var rank, cid int
+			var name sql.NullString // NULL for expressions
+			if err = rows.Scan(&rank, &cid, &name); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			byRank[rank] = name.String
+		}
+		if err = rows.Close(); err != nil {
+			return nil, err
+		}
+
+		ranks := make([]int, 0, len(byRank))
+		for rank := range byRank {
+			ranks = append(ranks, rank)
+		}
+		sort.Ints(ranks)
+		for _, rank := range ranks {
+			index.Columns = append(index.Columns, byRank[rank])
+		}
+		res.Indexes = append(res.Indexes, index)
+	}
+
+	return res, nil
+}
+
+// zeroValue returns SQL literal of zero value for given column type.
+// Types other than those returned by ColumnTypeForField are handled by SQLite type affinity rules.
+func zeroValue(columnType string) string {
+	t := strings.ToLower(columnType)
+	switch {
+	case t == "datetime" || t == "date" || strings.HasPrefix(t, "timestamp"):
+		return "'0001-01-01 00:00:00'"
+	case strings.Contains(t, "int"):
+		return "0"
+	case strings.Contains(t, "char") || strings.Contains(t, "clob") || strings.Contains(t, "text"):
+		return "''"
+	case strings.Contains(t, "blob"):
+		return "X''"
+	default:
+		// real, decimal and boolean
+		return "0"
+	}
+}
+
+// AlterTableQueries returns queries applying given change. SQLite can only add columns
+// (without foreign keys), other changes require table rebuild.
+func (sqlite3) AlterTableQueries(table string, change reform.SchemaChange) []string {
+	if change.Kind != reform.MissingColumn || change.Field.IsPK {
+		return nil
+	}
+
+	columnType := Dialect.ColumnTypeForField(change.Field)
+	definition := Dialect.QuoteIdentifier(change.Column) + " " + columnType
+	if !change.Field.IsNullable() {
+		definition += " NOT NULL"
+	}
+	switch {
+	case change.Field.Default != "":
+		definition += " DEFAULT " + change.Field.Default
+	case !change.Field.IsNullable():
+		// SQLite requires a default value for NOT NULL columns
+		definition += " DEFAULT " + zeroValue(columnType)
+	}
+	for _, check := range change.Field.Checks(Dialect, false) {
+		definition += " CHECK (" + check + ")"
+	}
+	return []string{fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", table, definition)}
+}
+
+// qualifySchemaObject qualifies the name of index or trigger in its SQL stored in sqlite_master
+// with schema prefix: stored SQL always starts with CREATE [UNIQUE] INDEX or CREATE TRIGGER and has no schema.
+func qualifySchemaObject(query, prefix string) string {
+	for _, start := range []string{"CREATE INDEX ", "CREATE UNIQUE INDEX ", "CREATE TRIGGER "} {
+		if strings.HasPrefix(strings.ToUpper(query), start) {
+			return query[:len(start)] + prefix + strings.TrimLeft(query[len(start):], " ")
+		}
+	}
+	return query
+}
+
+// RebuildTable recreates existing table for the model, see reform.TableRebuilder.
+// Foreign keys checks are disabled during rebuild, so DB should use a single connection.
+func (sqlite3) RebuildTable(db *reform.DB, s reform.StructInfo, actual *reform.TableSchema) error {
+	var foreignKeysEnabled bool
+	if err := db.QueryRow("PRAGMA foreign_keys").Scan(&foreignKeysEnabled); err != nil {
+		return err
+	}
+	if foreignKeysEnabled {
+		// it can't be changed inside transaction
+		if _, err := db.Exec("PRAGMA foreign_keys = OFF"); err != nil {
+			return err
+		}
+		defer db.Exec("PRAGMA foreign_keys = ON")
+	}
+
+	var prefix string
+	if s.SQLSchema != "" {
+		prefix = Dialect.QuoteIdentifier(s.SQLSchema) + "."
+	}
+	table := prefix + Dialect.QuoteIdentifier(s.SQLName)
+	newTable := prefix + Dialect.QuoteIdentifier(s.SQLName+"_reform_rebuild")
+
+	// model columns first, then columns unknown to the model, then foreign keys
+	var definitions, columns, foreignKeys []string
+	known := make(map[string]bool)
+	for _, f := range s.Fields {
+		if f.Column == "" {
+			continue
+		}
+		known[strings.ToLower(f.Column)] = true
+		definitions = append(definitions, Dialect.ColumnDefinitionForField(f))
+		if fk := f.ForeignKeyDefinition(Dialect); fk != "" {
+			foreignKeys = append(foreignKeys, fk)
+		}
+		if actual.Column(f.Column) != nil {
+			columns = append(columns, Dialect.QuoteIdentifier(f.Column))
+		}
+	}
+	for _, c := range actual.Columns {
+		if known[strings.ToLower(c.Name)] {
+			continue
+		}
+		definition := Dialect.QuoteIdentifier(c.Name) + " " + c.Type
+		if !c.Nullable {
+			definition += " NOT NULL"
+		}
+		definitions = append(definitions, definition)
+		columns = append(columns, Dialect.QuoteIdentifier(c.Name))
+	}
+	definitions = append(definitions, foreignKeys...)
+
+	queries := []string{
+		fmt.Sprintf("CREATE TABLE %s (%s)", newTable, strings.Join(definitions, ", ")),
+		fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s", newTable, strings.Join(columns, ", "), strings.Join(columns, ", "), table),
+		fmt.Sprintf("DROP TABLE %s", table),
+		fmt.Sprintf("ALTER TABLE %s RENAME TO %s", newTable, Dialect.QuoteIdentifier(s.SQLName)),
+	}
+
+	// indexes created for UNIQUE constraints have no SQL, others are recreated as is
+	for _, index := range actual.Indexes {
+		if index.Primary || !strings.HasPrefix(index.Name, "sqlite_autoindex_") {
+			continue
+		}
+		quoted := make([]string, len(index.Columns))
+		for i, c := range index.Columns {
+			quoted[i] = Dialect.QuoteIdentifier(c)
+		}
+		// index name (and not table name) is qualified with schema
+		name := prefix + Dialect.QuoteIdentifier("uniq_"+s.SQLName+"_"+strings.Join(index.Columns, "_"))
+		queries = append(queries, fmt.Sprintf("CREATE UNIQUE INDEX %s ON %s (%s)", name, Dialect.QuoteIdentifier(s.SQLName), strings.Join(quoted, ", ")))
+	}
+	rows, err := db.Query("SELECT sql FROM "+prefix+"sqlite_master WHERE tbl_name = ? AND type IN ('index', 'trigger') AND sql IS NOT NULL", s.SQLName)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var query string
+		if err = rows.Scan(&query); err != nil {
+			rows.Close()
+			return err
+		}
+		queries = append(queries, qualifySchemaObject(query, prefix))
+	}
+	if err = rows.Close(); err != nil {
+		return err
+	}
+
+	return db.InTransaction(func(tx *reform.TX) error {
+		for _, q := range queries {
+			if _, err := tx.Exec(q); err != nil {
+				return err
+			}
+		}
+
+		rows, err := tx.Query("PRAGMA " + prefix + "foreign_key_check(" + Dialect.QuoteIdentifier(s.SQLName) + ")")
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		if rows.Next() {
+			return fmt.Errorf("foreign key constraints are violated after rebuild of %s", s.SQLName)
+		}
+		return rows.Err()
+	})
+}
+
+// QualifiesIndexWithSchema implements reform.IndexSchemaDialect: SQLite3 expects
+// CREATE INDEX "schema"."index" ON "table".
+func (sqlite3) QualifiesIndexWithSchema() bool {
+	return true
+}
+
+// check interface
+var (
+	_ reform.SchemaDialect      = Dialect
+	_ reform.TableRebuilder     = Dialect
+	_ reform.IndexSchemaDialect = Dialect
+)
@@ -2,6 +2,9 @@
 package sqlite3
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/xaionaro/reform"
 )
 
@@ -39,54 +42,73 @@ func (sqlite3) DefaultValuesMethod() reform.DefaultValuesMethod {
 	return reform.DefaultValues
 }
 
+func (sqlite3) ExplainMethod() reform.ExplainMethod {
+	return reform.ExplainQueryPlan
+}
+
 func (sqlite3) ColumnTypeForField(field reform.FieldInfo) string {
-	switch field.Type {
+	if field.SQLType != "" {
+		return field.SQLType
+	}
+	if field.IsJSON {
+		return "text"
+	}
+	if field.Encryption.Enabled() {
+		return "text"
+	}
+	if field.IsUUID() {
+		return "text"
+	}
+
+	switch t := field.BaseType(); t {
 	case "time.Time", "extime.Time":
 		return "datetime"
-	case "int":
+	case "bool":
+		return "boolean"
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
 		return "integer"
+	case "float32", "float64":
+		if field.SQLPrecision > 0 {
+			return fmt.Sprintf("decimal(%d,%d)", field.SQLPrecision, field.SQLScale)
+		}
+		return "real"
 	case "string":
 		return "text"
 	default:
+		if strings.HasSuffix(t, "]uint8") {
+			return "blob"
+		}
 		return "text"
 	}
 }
 
 func (sqlite3) ColumnDefinitionForField(field reform.FieldInfo) string {
-	canBeNull := false
-	fieldType := field.Type
-	if fieldType[0:1] == "*" {
-		canBeNull = true
-		fieldType = fieldType[1:]
-	}
-
-	columnType := Dialect.ColumnTypeForField(field)
-
-	definition := field.Column + " " + columnType // TODO: escape everything
+	definition := Dialect.QuoteIdentifier(field.Column) + " " + Dialect.ColumnTypeForField(field)
 
 	if field.IsPK {
 		definition += " PRIMARY KEY"
-		if fieldType == "int" {
+		if field.IsAutoIncrement() {
 			definition += " AUTOINCREMENT"
 		}
 	}
 
-	if field.IsUnique {
-		definition += " UNIQUE"
+	if !field.IsNullable() {
+		definition += " NOT NULL"
 	}
 
-	if !canBeNull {
-		definition += " NOT NULL"
+	if field.Default != "" {
+		definition += " DEFAULT " + field.Default
+	}
+
+	for _, check := range field.Checks(Dialect, false) {
+		definition += " CHECK (" + check + ")"
 	}
 
 	return definition
 }
 
 func (sqlite3) ColumnDefinitionPostQueryForField(structInfo reform.StructInfo, field reform.FieldInfo) string {
-	if field.HasIndex {
-		return "CREATE INDEX IF NOT EXISTS idx_" + structInfo.SQLName + "_" + field.Column + " ON " + structInfo.SQLName + "(" + field.Column + ")" // TODO: escape everything
-	}
-
+	// indexes are created by reform.Querier.CreateTableIfNotExists
 	return ""
 }
 
@@ -94,4 +116,8 @@ func (sqlite3) ColumnDefinitionPostQueryForField(structInfo reform.StructInfo, f
 var Dialect sqlite3
 
 // check interface
-var _ reform.Dialect = Dialect
+var (
+	_ reform.Dialect           = Dialect
+	_ reform.ColumnTypeDialect = Dialect
+	_ reform.ExplainDialect    = Dialect
+)
@@ -0,0 +1,19 @@
+package sqlserver
+
+import (
+	"github.com/xaionaro/reform"
+	"github.com/xaionaro/reform/dialects/mssql"
+)
+
+// BulkInsertMethod returns bulk insert method. See mssql dialect.
+func (sqlserver) BulkInsertMethod() reform.BulkInsertMethod {
+	return mssql.Dialect.BulkInsertMethod()
+}
+
+// BulkInsertLimits returns limits of placeholders and rows. See mssql dialect.
+func (sqlserver) BulkInsertLimits() (placeholders, rows int) {
+	return mssql.Dialect.BulkInsertLimits()
+}
+
+// check interface
+var _ reform.BulkDialect = Dialect
@@ -0,0 +1,19 @@
+package sqlserver
+
+import (
+	"github.com/xaionaro/reform"
+	"github.com/xaionaro/reform/dialects/mssql"
+)
+
+// JSONPath returns JSON_VALUE() expression. See mssql dialect.
+func (sqlserver) JSONPath(expr string, path []string) string {
+	return mssql.Dialect.JSONPath(expr, path)
+}
+
+// JSONPlaceholder returns the placeholder as is. See mssql dialect.
+func (sqlserver) JSONPlaceholder(placeholder string) string {
+	return mssql.Dialect.JSONPlaceholder(placeholder)
+}
+
+// check interface
+var _ reform.JSONDialect = Dialect
@@ -0,0 +1,19 @@
+package sqlserver
+
+import (
+	"github.com/xaionaro/reform"
+	"github.com/xaionaro/reform/dialects/mssql"
+)
+
+// InspectTable returns a schema of existing table, or nil. See mssql dialect.
+func (sqlserver) InspectTable(q reform.DBTX, schema, table string) (*reform.TableSchema, error) {
+	return mssql.Dialect.InspectTable(q, schema, table)
+}
+
+// AlterTableQueries returns queries applying given change. See mssql dialect.
+func (sqlserver) AlterTableQueries(table string, change reform.SchemaChange) []string {
+	return mssql.Dialect.AlterTableQueries(table, change)
+}
+
+// check interface
+var _ reform.SchemaDialect = Dialect
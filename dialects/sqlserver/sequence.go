@@ -0,0 +1,14 @@
+package sqlserver
+
+import (
+	"github.com/xaionaro/reform"
+	"github.com/xaionaro/reform/dialects/mssql"
+)
+
+// NextValQuery returns NEXT VALUE FOR query. See mssql dialect.
+func (sqlserver) NextValQuery(sequence string) string {
+	return mssql.Dialect.NextValQuery(sequence)
+}
+
+// check interface
+var _ reform.SequenceDialect = Dialect
@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/xaionaro/reform"
+	"github.com/xaionaro/reform/dialects/mssql"
 )
 
 type sqlserver struct{}
@@ -41,13 +42,20 @@ func (sqlserver) DefaultValuesMethod() reform.DefaultValuesMethod {
 	return reform.DefaultValues
 }
 
+func (sqlserver) ExplainMethod() reform.ExplainMethod {
+	return reform.ShowPlanXML
+}
+
+func (sqlserver) ColumnTypeForField(field reform.FieldInfo) string {
+	return mssql.Dialect.ColumnTypeForField(field)
+}
+
 func (sqlserver) ColumnDefinitionForField(field reform.FieldInfo) string {
-	panic("Is not implemented, yet")
-	return ""
+	return mssql.Dialect.ColumnDefinitionForField(field)
 }
 
 func (sqlserver) ColumnDefinitionPostQueryForField(structInfo reform.StructInfo, field reform.FieldInfo) string {
-	panic("Is not implemented, yet")
+	// indexes are created by reform.Querier.CreateTableIfNotExists
 	return ""
 }
 
@@ -55,4 +63,8 @@ func (sqlserver) ColumnDefinitionPostQueryForField(structInfo reform.StructInfo,
 var Dialect sqlserver
 
 // check interface
-var _ reform.Dialect = Dialect
+var (
+	_ reform.Dialect           = Dialect
+	_ reform.ColumnTypeDialect = Dialect
+	_ reform.ExplainDialect    = Dialect
+)
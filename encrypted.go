@@ -0,0 +1,393 @@
+package reform
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Cipher encrypts and decrypts values of fields with "encrypted" option. It is set with DB.UseCipher.
+// Associated data (table and column names, see Encrypted) is authenticated but not encrypted:
+// ciphertext can be decrypted only with the same associated data.
+type Cipher interface {
+	// Encrypt encrypts plaintext with the current key. Deterministic encryption returns the same ciphertext
+	// for the same plaintext, associated data and key, so it can be used in equality conditions.
+	Encrypt(plaintext, associatedData []byte, deterministic bool) (string, error)
+
+	// Decrypt decrypts ciphertext encrypted with any known key.
+	Decrypt(ciphertext string, associatedData []byte) ([]byte, error)
+}
+
+// EncryptionMode defines how field values are encrypted.
+type EncryptionMode int
+
+const (
+	// EncryptionOff means the field is not encrypted.
+	EncryptionOff EncryptionMode = iota
+
+	// EncryptionRandom means the field is encrypted with random nonce ("encrypted" in "reform:" tag).
+	EncryptionRandom
+
+	// EncryptionDeterministic means the field is encrypted so equal values have equal ciphertexts
+	// and can be found with equality conditions ("encrypted:deterministic" in "reform:" tag).
+	EncryptionDeterministic
+)
+
+// Enabled returns true if the field is encrypted in this mode.
+func (m EncryptionMode) Enabled() bool {
+	return m != EncryptionOff
+}
+
+// IsDeterministic returns true if equal values have equal ciphertexts in this mode.
+func (m EncryptionMode) IsDeterministic() bool {
+	return m == EncryptionDeterministic
+}
+
+// encryptionOption returns the mode from "encrypted" option of "reform:" (or "gorm:") tag of the field.
+func encryptionOption(tag reflect.StructTag, imitateGorm bool) (EncryptionMode, error) {
+	parts := strings.Split(tag.Get("reform"), ",")[1:]
+	if imitateGorm {
+		parts = strings.Split(tag.Get("gorm"), ";")
+	}
+	for _, part := range parts {
+		switch part = strings.TrimSpace(part); {
+		case part == "encrypted":
+			return EncryptionRandom, nil
+		case part == "encrypted:deterministic":
+			return EncryptionDeterministic, nil
+		case strings.HasPrefix(part, "encrypted:"):
+			return EncryptionOff, fmt.Errorf("invalid encrypted option %q, expected encrypted or encrypted:deterministic", part)
+		}
+	}
+	return EncryptionOff, nil
+}
+
+// Encrypted wraps a value of field with "encrypted" option (string, []byte or pointer to them).
+// Querier replaces it with ciphertext made by its Cipher before the query reaches interceptors, Logger
+// and the database, so they never see the plaintext. On scan, V should be a pointer; NULL sets it to zero value.
+// Table and Column are bound to the ciphertext as associated data, so it can't be copied to another
+// column or table. The primary key is not bound: it is unknown before insert for keys generated by
+// database, and equality conditions don't have it.
+// Generated Values() and Pointers() use it; it also can be used as an argument of equality conditions
+// for fields with "encrypted:deterministic" option:
+//
+//	Person.Where("email = ?", reform.Encrypted{V: email, Deterministic: true, Table: "people", Column: "email"})
+type Encrypted struct {
+	V             interface{}
+	Deterministic bool
+	Table         string
+	Column        string
+	cipher        Cipher
+}
+
+// errNoCipher is returned for encrypted fields if DB has no Cipher.
+var errNoCipher = errors.New("reform: no Cipher for encrypted field, see DB.UseCipher")
+
+// String returns a placeholder instead of the value, so it is never printed.
+func (e Encrypted) String() string {
+	return "[ENCRYPTED]"
+}
+
+// GoString returns a placeholder instead of the value, so it is never printed.
+func (e Encrypted) GoString() string {
+	return e.String()
+}
+
+// associatedData returns table and column names bound to the ciphertext.
+func (e Encrypted) associatedData() []byte {
+	return []byte(e.Table + "." + e.Column)
+}
+
+// Value implements driver.Valuer. It returns ciphertext, or NULL for nil pointers.
+func (e Encrypted) Value() (driver.Value, error) {
+	if e.cipher == nil {
+		return nil, errNoCipher
+	}
+	v := reflect.ValueOf(e.V)
+	for v.IsValid() && v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	var plaintext []byte
+	switch {
+	case !v.IsValid():
+		return nil, nil
+	case v.Kind() == reflect.String:
+		plaintext = []byte(v.String())
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+		if v.IsNil() {
+			return nil, nil
+		}
+		plaintext = v.Bytes()
+	default:
+		return nil, fmt.Errorf("reform: Encrypted.Value: unsupported type %T", e.V)
+	}
+	return e.cipher.Encrypt(plaintext, e.associatedData(), e.Deterministic)
+}
+
+// Scan implements sql.Scanner.
+func (e Encrypted) Scan(src interface{}) error {
+	v := reflect.ValueOf(e.V)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("reform: Encrypted.Scan: expected non-nil pointer, got %T", e.V)
+	}
+	v = v.Elem()
+
+	var ciphertext string
+	switch src := src.(type) {
+	case nil:
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	case []byte:
+		ciphertext = string(src)
+	case string:
+		ciphertext = src
+	default:
+		return fmt.Errorf("reform: Encrypted.Scan: unexpected type %T", src)
+	}
+	if e.cipher == nil {
+		return errNoCipher
+	}
+	plaintext, err := e.cipher.Decrypt(ciphertext, e.associatedData())
+	if err != nil {
+		return err
+	}
+
+	if v.Kind() == reflect.Ptr {
+		v.Set(reflect.New(v.Type().Elem()))
+		v = v.Elem()
+	}
+	switch {
+	case v.Kind() == reflect.String:
+		v.SetString(string(plaintext))
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+		v.SetBytes(plaintext)
+	default:
+		return fmt.Errorf("reform: Encrypted.Scan: unsupported type %T", e.V)
+	}
+	return nil
+}
+
+// encryptionError is passed to the database instead of Encrypted value which can't be encrypted,
+// so the query fails without leaking the plaintext.
+type encryptionError struct {
+	err error
+}
+
+// Value implements driver.Valuer.
+func (e encryptionError) Value() (driver.Value, error) {
+	return nil, e.err
+}
+
+// String returns a placeholder.
+func (e encryptionError) String() string {
+	return "[ENCRYPTED]"
+}
+
+// prepareArgs returns a copy of args with Encrypted values replaced by ciphertexts,
+// and UUIDs replaced by dialect's values for dialects implementing UUIDDialect.
+func (q *Querier) prepareArgs(args []interface{}) []interface{} {
+	uuidDialect, _ := q.Dialect.(UUIDDialect)
+	var res []interface{}
+	for i, arg := range args {
+		var value interface{}
+		switch arg := arg.(type) {
+		case Encrypted:
+			arg.cipher = q.cipher
+			v, err := arg.Value()
+			if err != nil {
+				value = encryptionError{err}
+			} else {
+				value = v
+			}
+		case UUID:
+			if uuidDialect == nil {
+				continue
+			}
+			value = uuidDialect.UUIDValue(arg)
+		case *UUID:
+			if uuidDialect == nil || arg == nil {
+				continue
+			}
+			value = uuidDialect.UUIDValue(*arg)
+		default:
+			continue
+		}
+		if res == nil {
+			res = append([]interface{}(nil), args...)
+		}
+		res[i] = value
+	}
+	if res == nil {
+		return args
+	}
+	return res
+}
+
+// WithCipher returns a copy of Querier with given Cipher for encrypted fields.
+// Returned Querier is tied to the same DB or TX.
+func (q *Querier) WithCipher(c Cipher) *Querier {
+	newQ := q.clone()
+	newQ.cipher = c
+	return newQ
+}
+
+// UseCipher sets Cipher for encrypted fields of this DB.
+// Transactions started with Begin and copies made with With* methods after that inherit it.
+func (db *DB) UseCipher(c Cipher) {
+	db.cipher = c
+}
+
+// ReEncrypt re-encrypts encrypted fields of table rows selected by tail and args with the current key
+// of Cipher, and returns the number of updated rows. It is used for key rotation after the new key
+// becomes current while old keys are still known to Cipher. Rows are loaded into memory before updating,
+// so big tables should be processed in batches, like "WHERE id > ? ORDER BY id LIMIT 1000".
+// Table should be generated by reform (implement StructInfo() method).
+func (q *Querier) ReEncrypt(table Table, tail string, args ...interface{}) (int, error) {
+	t, ok := table.(interface{ StructInfo() StructInfo })
+	if !ok {
+		return 0, fmt.Errorf("reform: %T has no StructInfo() method", table)
+	}
+	var columns []string
+	for _, f := range t.StructInfo().Fields {
+		if f.Encryption.Enabled() {
+			columns = append(columns, f.Column)
+		}
+	}
+	if len(columns) == 0 {
+		return 0, fmt.Errorf("reform: %s has no encrypted fields", table.Name())
+	}
+
+	rows, err := q.SelectRows(table, tail, args...)
+	if err != nil {
+		return 0, err
+	}
+	var records []Record
+	for {
+		record := table.NewRecord()
+		if err = q.NextRow(record, rows); err != nil {
+			break
+		}
+		records = append(records, record)
+	}
+	rows.Close()
+	if err != ErrNoRows {
+		return 0, err
+	}
+
+	for i, record := range records {
+		if err = q.UpdateColumns(record, columns...); err != nil {
+			return i, err
+		}
+	}
+	return len(records), nil
+}
+
+// AESCipher is a Cipher using AES-GCM with versioned keys. Ciphertexts are stored as text like
+// "v2:<base64>" where 2 is the key version, so old keys can decrypt existing values after rotation.
+// Deterministic encryption derives the nonce from HMAC-SHA256 of associated data and plaintext; such ciphertexts
+// reveal only equality of values, and they change with the current key, so lookups find only values
+// encrypted with it (use ReEncrypt after rotation).
+type AESCipher struct {
+	current uint32
+	keys    map[uint32]aesKey
+}
+
+// aesKey is AES-GCM key with HMAC key for deterministic nonces.
+type aesKey struct {
+	aead cipher.AEAD
+	mac  []byte
+}
+
+// NewAESCipher creates AESCipher with given keys (16, 24 or 32 bytes for AES-128, AES-192 or AES-256) by versions,
+// and the version of the current key used for encryption.
+func NewAESCipher(current uint32, keys map[uint32][]byte) (*AESCipher, error) {
+	if _, ok := keys[current]; !ok {
+		return nil, fmt.Errorf("reform: no key for current version %d", current)
+	}
+	c := &AESCipher{current: current, keys: make(map[uint32]aesKey, len(keys))}
+	for version, key := range keys {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("reform: key version %d: %s", version, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("reform: key version %d: %s", version, err)
+		}
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte("reform deterministic nonce"))
+		c.keys[version] = aesKey{aead: aead, mac: mac.Sum(nil)}
+	}
+	return c, nil
+}
+
+// Encrypt implements Cipher.
+func (c *AESCipher) Encrypt(plaintext, associatedData []byte, deterministic bool) (string, error) {
+	key := c.keys[c.current]
+	nonce := make([]byte, key.aead.NonceSize())
+	if deterministic {
+		// associated data is length-prefixed, so different splits of the same bytes give different nonces
+		var length [8]byte
+		binary.BigEndian.PutUint64(length[:], uint64(len(associatedData)))
+		mac := hmac.New(sha256.New, key.mac)
+		mac.Write(length[:])
+		mac.Write(associatedData)
+		mac.Write(plaintext)
+		copy(nonce, mac.Sum(nil))
+	} else if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := key.aead.Seal(nonce, nonce, plaintext, associatedData)
+	return "v" + strconv.FormatUint(uint64(c.current), 10) + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt implements Cipher.
+func (c *AESCipher) Decrypt(ciphertext string, associatedData []byte) ([]byte, error) {
+	version, err := KeyVersion(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := c.keys[version]
+	if !ok {
+		return nil, fmt.Errorf("reform: no key for version %d", version)
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(ciphertext[strings.Index(ciphertext, ":")+1:])
+	if err != nil || len(sealed) < key.aead.NonceSize() {
+		return nil, fmt.Errorf("reform: invalid ciphertext")
+	}
+	nonce, sealed := sealed[:key.aead.NonceSize()], sealed[key.aead.NonceSize():]
+	plaintext, err := key.aead.Open(nil, nonce, sealed, associatedData)
+	if err != nil {
+		return nil, fmt.Errorf("reform: failed to decrypt with key version %d: %s", version, err)
+	}
+	if plaintext == nil {
+		plaintext = []byte{}
+	}
+	return plaintext, nil
+}
+
+// KeyVersion returns the key version of AESCipher ciphertext.
+func KeyVersion(ciphertext string) (uint32, error) {
+	i := strings.Index(ciphertext, ":")
+	if i < 2 || ciphertext[0] != 'v' {
+		return 0, fmt.Errorf("reform: invalid ciphertext")
+	}
+	version, err := strconv.ParseUint(ciphertext[1:i], 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("reform: invalid ciphertext")
+	}
+	return uint32(version), nil
+}
+
+// check interface
+var _ Cipher = (*AESCipher)(nil)
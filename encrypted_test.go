@@ -0,0 +1,156 @@
+package reform_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xaionaro/reform"
+	"github.com/xaionaro/reform/dialects/mysql"
+	"github.com/xaionaro/reform/dialects/postgresql"
+	"github.com/xaionaro/reform/dialects/sqlite3"
+)
+
+func TestAESCipher(t *testing.T) {
+	key1, key2 := make([]byte, 32), make([]byte, 16)
+	key2[0] = 1
+	_, err := reform.NewAESCipher(2, map[uint32][]byte{1: key1})
+	assert.Error(t, err)
+	_, err = reform.NewAESCipher(1, map[uint32][]byte{1: key1[:5]})
+	assert.Error(t, err)
+
+	c1, err := reform.NewAESCipher(1, map[uint32][]byte{1: key1})
+	require.NoError(t, err)
+
+	ad := []byte("people.ssn")
+	random1, err := c1.Encrypt([]byte("secret"), ad, false)
+	require.NoError(t, err)
+	random2, err := c1.Encrypt([]byte("secret"), ad, false)
+	require.NoError(t, err)
+	assert.NotEqual(t, random1, random2)
+	assert.True(t, strings.HasPrefix(random1, "v1:"))
+	assert.NotContains(t, random1, "secret")
+
+	det1, err := c1.Encrypt([]byte("secret"), ad, true)
+	require.NoError(t, err)
+	det2, err := c1.Encrypt([]byte("secret"), ad, true)
+	require.NoError(t, err)
+	assert.Equal(t, det1, det2)
+
+	// ciphertexts are bound to associated data
+	det4, err := c1.Encrypt([]byte("secret"), []byte("people.email"), true)
+	require.NoError(t, err)
+	assert.NotEqual(t, det1, det4)
+	for _, ciphertext := range []string{random1, det1} {
+		plaintext, err := c1.Decrypt(ciphertext, ad)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("secret"), plaintext)
+		_, err = c1.Decrypt(ciphertext, []byte("people.email"))
+		assert.Error(t, err)
+	}
+	empty, err := c1.Encrypt(nil, ad, false)
+	require.NoError(t, err)
+	plaintext, err := c1.Decrypt(empty, ad)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{}, plaintext)
+
+	// rotation: old ciphertexts are still decrypted, new ones use the current key
+	c2, err := reform.NewAESCipher(2, map[uint32][]byte{1: key1, 2: key2})
+	require.NoError(t, err)
+	plaintext, err = c2.Decrypt(random1, ad)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("secret"), plaintext)
+	det3, err := c2.Encrypt([]byte("secret"), ad, true)
+	require.NoError(t, err)
+	assert.NotEqual(t, det1, det3)
+	version, err := reform.KeyVersion(det3)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), version)
+	_, err = c1.Decrypt(det3, ad)
+	assert.Error(t, err)
+
+	_, err = c1.Decrypt("v1:"+strings.Repeat("A", 40), ad)
+	assert.Error(t, err)
+	_, err = reform.KeyVersion("plain")
+	assert.Error(t, err)
+}
+
+func TestEncryptedArgs(t *testing.T) {
+	sqlDB := openSQLite3(t)
+
+	var logged []string
+	db := reform.NewDB(sqlDB, sqlite3.Dialect, reform.NewPrintfLogger(func(format string, args ...interface{}) {
+		logged = append(logged, fmt.Sprintf(format, args...))
+	}))
+	_, err := db.Exec(`CREATE TABLE people (email text)`)
+	require.NoError(t, err)
+
+	email := reform.Encrypted{V: "alice@example.com", Deterministic: true, Table: "people", Column: "email"}
+	_, err = db.Exec(`INSERT INTO people (email) VALUES (?)`, email)
+	require.Error(t, err, "no cipher")
+
+	c, err := reform.NewAESCipher(1, map[uint32][]byte{1: make([]byte, 32)})
+	require.NoError(t, err)
+	db.UseCipher(c)
+	_, err = db.Exec(`INSERT INTO people (email) VALUES (?), (?)`, email, reform.Encrypted{V: (*string)(nil), Table: "people", Column: "email"})
+	require.NoError(t, err)
+
+	var count int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM people WHERE email = ?`, email).Scan(&count))
+	assert.Equal(t, 1, count)
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM people WHERE email IS NULL`).Scan(&count))
+	assert.Equal(t, 1, count)
+
+	var stored string
+	require.NoError(t, db.QueryRow(`SELECT email FROM people WHERE email IS NOT NULL`).Scan(&stored))
+	plaintext, err := c.Decrypt(stored, []byte("people.email"))
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", string(plaintext))
+
+	// ciphertext copied to another column can't be decrypted
+	var copied string
+	err = db.QueryRow(`SELECT email FROM people WHERE email IS NOT NULL`).Scan(reform.Encrypted{V: &copied, Table: "people", Column: "name"})
+	assert.Error(t, err)
+
+	require.NotEmpty(t, logged)
+	for _, l := range logged {
+		assert.NotContains(t, l, "alice")
+	}
+	assert.Equal(t, "[ENCRYPTED] [ENCRYPTED]", fmt.Sprintf("%v %#v", email, email))
+}
+
+// encryptedFilter is a filter with encrypted fields of a model, like generated ones.
+type encryptedFilter struct {
+	SSN   string `reform:"ssn,encrypted"`
+	Email string `reform:"email,encrypted:deterministic"`
+}
+
+func (*encryptedFilter) View() reform.View { return tenantDocs }
+
+func TestEncryptedTag(t *testing.T) {
+	var f reform.FieldInfo
+	require.NoError(t, f.ConsiderTag(false, "SSN", reflect.StructTag(`reform:"ssn,encrypted"`)))
+	assert.Equal(t, "ssn", f.Column)
+	assert.Equal(t, reform.EncryptionRandom, f.Encryption)
+	assert.False(t, f.Encryption.IsDeterministic())
+
+	f = reform.FieldInfo{Name: "Email", Type: "string", Kind: "string", IsUnique: true}
+	require.NoError(t, f.ConsiderTag(false, "Email", reflect.StructTag(`reform:"email,encrypted:deterministic"`)))
+	assert.Equal(t, reform.EncryptionDeterministic, f.Encryption)
+	assert.Equal(t, "text", postgresql.Dialect.ColumnTypeForField(f))
+	assert.Equal(t, "varchar(512)", mysql.Dialect.ColumnTypeForField(f))
+
+	assert.Error(t, f.ConsiderTag(false, "Email", reflect.StructTag(`reform:"email,encrypted:aes"`)))
+
+	db := reform.NewDB(nil, postgresql.Dialect, nil)
+	tail, args, err := db.GetWhereTailForFilter(encryptedFilter{Email: "a@b"}, nil, "", false)
+	require.NoError(t, err)
+	assert.Equal(t, `"email" = $1`, tail)
+	assert.Equal(t, []interface{}{reform.Encrypted{V: "a@b", Deterministic: true, Table: "docs", Column: "email"}}, args)
+	_, _, err = db.GetWhereTailForFilter(encryptedFilter{SSN: "1"}, nil, "", false)
+	assert.Error(t, err)
+}
@@ -0,0 +1,119 @@
+package reform
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Enum is implemented by types with "//reform:enum" magic comment; reform command generates those methods.
+// Value and Scan methods reject values other than declared constants.
+type Enum interface {
+	driver.Valuer
+	fmt.Stringer
+
+	// IsValid returns true if the value is one of declared constants.
+	IsValid() bool
+
+	// EnumValues returns values of all declared constants as stored in the database.
+	EnumValues() []string
+}
+
+// EnumString converts src from the database to the value of string enum.
+// It is used by generated Scan methods.
+func EnumString(src interface{}) (string, error) {
+	switch src := src.(type) {
+	case string:
+		return src, nil
+	case []byte:
+		return string(src), nil
+	case nil:
+		return "", fmt.Errorf("reform: can't scan NULL into enum, use pointer")
+	default:
+		return "", fmt.Errorf("reform: can't scan %T into string enum", src)
+	}
+}
+
+// EnumInt converts src from the database to the value of integer enum.
+// It is used by generated Scan methods.
+func EnumInt(src interface{}) (int64, error) {
+	switch src := src.(type) {
+	case int64:
+		return src, nil
+	case []byte:
+		return strconv.ParseInt(string(src), 10, 64)
+	case string:
+		return strconv.ParseInt(src, 10, 64)
+	case nil:
+		return 0, fmt.Errorf("reform: can't scan NULL into enum, use pointer")
+	default:
+		return 0, fmt.Errorf("reform: can't scan %T into integer enum", src)
+	}
+}
+
+// EnumTypeDialect is implemented by dialects which store string enums as native types created separately,
+// like PostgreSQL's CREATE TYPE ... AS ENUM.
+type EnumTypeDialect interface {
+	Dialect
+
+	// CreateEnumTypeQuery returns a query creating type of given enum field if it does not exist yet,
+	// or empty string if the field does not need it.
+	CreateEnumTypeQuery(field FieldInfo) string
+}
+
+// IsEnum returns true if the field type has "//reform:enum" magic comment.
+func (f FieldInfo) IsEnum() bool {
+	return len(f.EnumValues) > 0
+}
+
+// EnumTypeName returns SQL name of the field enum type for native enum types, e.g. order_status for OrderStatus.
+func (f FieldInfo) EnumTypeName() string {
+	t := strings.TrimPrefix(f.Type, "*")
+	if i := strings.LastIndex(t, "."); i >= 0 {
+		t = t[i+1:]
+	}
+	return toGormFieldName(t)
+}
+
+// EnumLiterals returns SQL literals of the field enum values.
+func (f FieldInfo) EnumLiterals() []string {
+	res := make([]string, len(f.EnumValues))
+	for i, v := range f.EnumValues {
+		if f.BaseType() == "string" {
+			v = "'" + strings.Replace(v, "'", "''", -1) + "'"
+		}
+		res[i] = v
+	}
+	return res
+}
+
+// Checks returns CHECK constraint expressions of the column: the one from "sql:" tag, and,
+// for enum fields which are not stored as native enum types, the one allowing only enum values.
+func (f FieldInfo) Checks(d Dialect, nativeEnum bool) []string {
+	var res []string
+	if f.Check != "" {
+		res = append(res, f.Check)
+	}
+	if f.IsEnum() && !nativeEnum {
+		res = append(res, d.QuoteIdentifier(f.Column)+" IN ("+strings.Join(f.EnumLiterals(), ", ")+")")
+	}
+	return res
+}
+
+// createTypeQueries returns queries creating native enum types used by the model.
+func (q *Querier) createTypeQueries(s StructInfo) []string {
+	dialect, ok := q.Dialect.(EnumTypeDialect)
+	if !ok {
+		return nil
+	}
+	var res []string
+	seen := make(map[string]bool)
+	for _, f := range s.Fields {
+		if query := dialect.CreateEnumTypeQuery(f); query != "" && !seen[query] {
+			seen[query] = true
+			res = append(res, query)
+		}
+	}
+	return res
+}
@@ -0,0 +1,55 @@
+package reform_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xaionaro/reform"
+	"github.com/xaionaro/reform/dialects/mssql"
+	"github.com/xaionaro/reform/dialects/mysql"
+	"github.com/xaionaro/reform/dialects/postgresql"
+	"github.com/xaionaro/reform/dialects/sqlite3"
+)
+
+func TestEnumColumns(t *testing.T) {
+	status := reform.FieldInfo{Name: "Status", Type: "OrderStatus", Kind: "string", Column: "status", EnumValues: []string{"new", "it's"}}
+	priority := reform.FieldInfo{Name: "Priority", Type: "*Priority", Kind: "int16", Column: "priority", EnumValues: []string{"1", "2"}}
+
+	assert.Equal(t, "order_status", status.EnumTypeName())
+	assert.Equal(t, `"status" text NOT NULL CHECK ("status" IN ('new', 'it''s'))`, sqlite3.Dialect.ColumnDefinitionForField(status))
+	assert.Equal(t, `"priority" integer CHECK ("priority" IN (1, 2))`, sqlite3.Dialect.ColumnDefinitionForField(priority))
+	assert.Equal(t, "`status` enum('new','it''s') NOT NULL", mysql.Dialect.ColumnDefinitionForField(status))
+	assert.Equal(t, "`priority` smallint NULL CHECK (`priority` IN (1, 2))", mysql.Dialect.ColumnDefinitionForField(priority))
+	assert.Equal(t, `"status" order_status NOT NULL`, postgresql.Dialect.ColumnDefinitionForField(status))
+	assert.Equal(t, `"priority" smallint CHECK ("priority" IN (1, 2))`, postgresql.Dialect.ColumnDefinitionForField(priority))
+	assert.Equal(t, `DO $$ BEGIN CREATE TYPE "order_status" AS ENUM ('new', 'it''s'); EXCEPTION WHEN duplicate_object THEN NULL; END $$`,
+		postgresql.Dialect.CreateEnumTypeQuery(status))
+	assert.Empty(t, postgresql.Dialect.CreateEnumTypeQuery(priority))
+	assert.Contains(t, mssql.Dialect.ColumnDefinitionForField(status), `CHECK ([status] IN ('new', 'it''s'))`)
+
+	status.Check = "status <> ''"
+	assert.Equal(t, []string{"status <> ''", `"status" IN ('new', 'it''s')`}, status.Checks(sqlite3.Dialect, false))
+	assert.Equal(t, []string{"status <> ''"}, status.Checks(postgresql.Dialect, true))
+}
+
+func TestEnumScan(t *testing.T) {
+	s, err := reform.EnumString([]byte("new"))
+	require.NoError(t, err)
+	assert.Equal(t, "new", s)
+	_, err = reform.EnumString(nil)
+	assert.Error(t, err)
+	_, err = reform.EnumString(int64(1))
+	assert.Error(t, err)
+
+	for _, src := range []interface{}{int64(2), []byte("2"), "2"} {
+		i, err := reform.EnumInt(src)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), i)
+	}
+	_, err = reform.EnumInt("two")
+	assert.Error(t, err)
+	_, err = reform.EnumInt(nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,490 @@
+package reform
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PlanNode represents a single step of a query execution plan.
+type PlanNode struct {
+	Operation     string      // operation as reported by the database, e.g. "Seq Scan", "ALL", "SEARCH", "Clustered Index Seek"
+	Table         string      // table (or alias) the operation reads, if any
+	Index         string      // index the operation uses, if any
+	Detail        string      // additional details: filter condition, SQLite plan line, etc.
+	EstimatedRows float64     // estimated number of rows, 0 if unknown
+	Cost          float64     // estimated cost in database-specific units, 0 if unknown
+	FullScan      bool        // operation reads the whole table
+	MissingIndex  bool        // operation filters rows without an index, or the database reported a missing index
+	Children      []*PlanNode // nested operations
+}
+
+// Plan represents a query execution plan as returned by Querier.Explain.
+type Plan struct {
+	Nodes []*PlanNode // top-level operations
+	Raw   string      // plan as returned by the database (JSON, XML or text)
+}
+
+// Walk calls f for each node of the plan, parents first.
+func (p *Plan) Walk(f func(node *PlanNode, depth int)) {
+	var walk func(nodes []*PlanNode, depth int)
+	walk = func(nodes []*PlanNode, depth int) {
+		for _, n := range nodes {
+			f(n, depth)
+			walk(n.Children, depth+1)
+		}
+	}
+	walk(p.Nodes, 0)
+}
+
+// FullScans returns all nodes reading whole tables.
+func (p *Plan) FullScans() (nodes []*PlanNode) {
+	p.Walk(func(node *PlanNode, _ int) {
+		if node.FullScan {
+			nodes = append(nodes, node)
+		}
+	})
+	return
+}
+
+// MissingIndexes returns all nodes which would benefit from an index.
+func (p *Plan) MissingIndexes() (nodes []*PlanNode) {
+	p.Walk(func(node *PlanNode, _ int) {
+		if node.MissingIndex {
+			nodes = append(nodes, node)
+		}
+	})
+	return
+}
+
+// String returns a human-readable representation of the plan tree.
+func (p *Plan) String() string {
+	var buf bytes.Buffer
+	p.Walk(func(n *PlanNode, depth int) {
+		buf.WriteString(strings.Repeat("  ", depth))
+		buf.WriteString(n.Operation)
+		if n.Table != "" {
+			buf.WriteString(" on " + n.Table)
+		}
+		if n.Index != "" {
+			buf.WriteString(" using " + n.Index)
+		}
+		if n.EstimatedRows != 0 || n.Cost != 0 {
+			fmt.Fprintf(&buf, " (rows=%g cost=%g)", n.EstimatedRows, n.Cost)
+		}
+		if n.FullScan {
+			buf.WriteString(" [FULL SCAN]")
+		}
+		if n.MissingIndex {
+			buf.WriteString(" [MISSING INDEX]")
+		}
+		if n.Detail != "" && n.Detail != n.Operation {
+			buf.WriteString(": " + n.Detail)
+		}
+		buf.WriteString("\n")
+	})
+	return buf.String()
+}
+
+// ExplainDialect is implemented by dialects which can return an execution plan of a query.
+type ExplainDialect interface {
+	Dialect
+
+	// ExplainMethod returns a method of receiving an execution plan of a query.
+	ExplainMethod() ExplainMethod
+}
+
+// Explain returns the execution plan of a query with given args, using dialect's EXPLAIN statement.
+// Query is not executed. Dialect should implement ExplainDialect.
+//
+// For SQL Server plans are received with SET SHOWPLAN_XML, which is a session option: it is turned on
+// and off on a single connection of *sql.DB, or in the current transaction. Other DBInterface
+// implementations should be limited to a single connection.
+func (q *Querier) Explain(query string, args ...interface{}) (*Plan, error) {
+	dialect, ok := q.Dialect.(ExplainDialect)
+	if !ok {
+		return nil, fmt.Errorf("reform: dialect %s does not support EXPLAIN", q.Dialect)
+	}
+
+	switch dialect.ExplainMethod() {
+	case ExplainJSON:
+		var raw string
+		if err := q.QueryRow("EXPLAIN (FORMAT JSON) "+query, args...).Scan(&raw); err != nil {
+			return nil, err
+		}
+		return parsePostgreSQLPlan(raw)
+
+	case ExplainFormatJSON:
+		var raw string
+		if err := q.QueryRow("EXPLAIN FORMAT=JSON "+query, args...).Scan(&raw); err != nil {
+			return nil, err
+		}
+		return parseMySQLPlan(raw)
+
+	case ExplainQueryPlan:
+		return q.explainSQLite3(query, args)
+
+	case ShowPlanXML:
+		return q.explainSQLServer(query, args)
+
+	default:
+		panic("reform: Unhandled ExplainMethod. Please report this bug.")
+	}
+}
+
+// FlexExplain returns the execution plan of a SELECT query for given view, forceAnotherTable, forceFields, tail and args.
+// Arguments have the same meaning as for FlexSelectRows.
+func (q *Querier) FlexExplain(view View, forceAnotherTable *string, forceFields []string, tail string, args ...interface{}) (*Plan, error) {
+	query, args, err := q.selectQuery(view, tail, args, false, forceAnotherTable, forceFields)
+	if err != nil {
+		return nil, err
+	}
+	return q.Explain(query, args...)
+}
+
+type postgreSQLPlanNode struct {
+	NodeType     string               `json:"Node Type"`
+	RelationName string               `json:"Relation Name"`
+	Alias        string               `json:"Alias"`
+	IndexName    string               `json:"Index Name"`
+	Filter       string               `json:"Filter"`
+	IndexCond    string               `json:"Index Cond"`
+	PlanRows     float64              `json:"Plan Rows"`
+	TotalCost    float64              `json:"Total Cost"`
+	Plans        []postgreSQLPlanNode `json:"Plans"`
+}
+
+func (n *postgreSQLPlanNode) toPlanNode() *PlanNode {
+	res := &PlanNode{
+		Operation:     n.NodeType,
+		Table:         n.RelationName,
+		Index:         n.IndexName,
+		Detail:        n.Filter,
+		EstimatedRows: n.PlanRows,
+		Cost:          n.TotalCost,
+		FullScan:      n.NodeType == "Seq Scan",
+	}
+	if res.Detail == "" {
+		res.Detail = n.IndexCond
+	}
+	res.MissingIndex = res.FullScan && n.Filter != ""
+	for i := range n.Plans {
+		res.Children = append(res.Children, n.Plans[i].toPlanNode())
+	}
+	return res
+}
+
+// parsePostgreSQLPlan parses output of EXPLAIN (FORMAT JSON).
+func parsePostgreSQLPlan(raw string) (*Plan, error) {
+	var plans []struct {
+		Plan postgreSQLPlanNode `json:"Plan"`
+	}
+	if err := json.Unmarshal([]byte(raw), &plans); err != nil {
+		return nil, fmt.Errorf("reform: failed to parse PostgreSQL plan: %s", err)
+	}
+
+	res := &Plan{Raw: raw}
+	for i := range plans {
+		res.Nodes = append(res.Nodes, plans[i].Plan.toPlanNode())
+	}
+	return res, nil
+}
+
+// mysqlPlanOperations are MySQL plan objects shown as separate nodes.
+var mysqlPlanOperations = map[string]bool{
+	"ordering_operation":         true,
+	"grouping_operation":         true,
+	"duplicates_removal":         true,
+	"windowing":                  true,
+	"union_result":               true,
+	"materialized_from_subquery": true,
+}
+
+// parseMySQLPlan parses output of EXPLAIN FORMAT=JSON.
+func parseMySQLPlan(raw string) (*Plan, error) {
+	var root map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &root); err != nil {
+		return nil, fmt.Errorf("reform: failed to parse MySQL plan: %s", err)
+	}
+
+	res := &Plan{Raw: raw}
+	parent := &PlanNode{}
+	walkMySQLPlan(root, parent)
+	res.Nodes = parent.Children
+	return res, nil
+}
+
+func walkMySQLPlan(value interface{}, parent *PlanNode) {
+	switch value := value.(type) {
+	case []interface{}:
+		for _, v := range value {
+			walkMySQLPlan(v, parent)
+		}
+
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for k := range value {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			v := value[k]
+			switch {
+			case k == "table":
+				t, _ := v.(map[string]interface{})
+				node := mysqlTableNode(t)
+				parent.Children = append(parent.Children, node)
+				walkMySQLPlan(v, node)
+			case mysqlPlanOperations[k]:
+				node := &PlanNode{Operation: k}
+				if op, ok := v.(map[string]interface{}); ok && op["using_filesort"] == true {
+					node.Detail = "using filesort"
+				}
+				parent.Children = append(parent.Children, node)
+				walkMySQLPlan(v, node)
+			default:
+				walkMySQLPlan(v, parent)
+			}
+		}
+	}
+}
+
+func mysqlTableNode(t map[string]interface{}) *PlanNode {
+	str := func(key string) string {
+		s, _ := t[key].(string)
+		return s
+	}
+	num := func(v interface{}) float64 {
+		switch v := v.(type) {
+		case float64:
+			return v
+		case string:
+			f, _ := strconv.ParseFloat(v, 64)
+			return f
+		}
+		return 0
+	}
+
+	node := &PlanNode{
+		Operation:     str("access_type"),
+		Table:         str("table_name"),
+		Index:         str("key"),
+		Detail:        str("attached_condition"),
+		EstimatedRows: num(t["rows_examined_per_scan"]),
+	}
+	if costInfo, ok := t["cost_info"].(map[string]interface{}); ok {
+		node.Cost = num(costInfo["prefix_cost"])
+	}
+	node.FullScan = node.Operation == "ALL"
+	_, hasPossibleKeys := t["possible_keys"]
+	node.MissingIndex = node.FullScan && !hasPossibleKeys && node.Detail != ""
+	return node
+}
+
+// explainSQLite3 runs EXPLAIN QUERY PLAN and builds the plan tree from (id, parent, notused, detail) rows.
+func (q *Querier) explainSQLite3(query string, args []interface{}) (*Plan, error) {
+	rows, err := q.Query("EXPLAIN QUERY PLAN "+query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := new(Plan)
+	nodes := make(map[int64]*PlanNode)
+	var raw []string
+	for rows.Next() {
+		var id, parent, notUsed int64
+		var detail string
+		if err = rows.Scan(&id, &parent, &notUsed, &detail); err != nil {
+			return nil, err
+		}
+		raw = append(raw, fmt.Sprintf("%d|%d|%d|%s", id, parent, notUsed, detail))
+
+		node := parseSQLite3PlanDetail(detail)
+		nodes[id] = node
+		if p := nodes[parent]; p != nil && parent != id {
+			p.Children = append(p.Children, node)
+		} else {
+			res.Nodes = append(res.Nodes, node)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	res.Raw = strings.Join(raw, "\n")
+	return res, nil
+}
+
+// parseSQLite3PlanDetail parses a single line of EXPLAIN QUERY PLAN output,
+// like "SCAN people", "SCAN TABLE people" or "SEARCH people USING INDEX idx (name=?)".
+func parseSQLite3PlanDetail(detail string) *PlanNode {
+	node := &PlanNode{Detail: detail}
+	words := strings.Fields(detail)
+	if len(words) == 0 {
+		return node
+	}
+
+	node.Operation = words[0]
+	if node.Operation != "SCAN" && node.Operation != "SEARCH" {
+		return node
+	}
+
+	rest := words[1:]
+	if len(rest) > 0 && rest[0] == "TABLE" {
+		rest = rest[1:]
+	}
+	if len(rest) > 0 {
+		node.Table = rest[0]
+	}
+	for i, w := range rest {
+		if w == "INDEX" && i+1 < len(rest) && !strings.HasPrefix(rest[i+1], "(") {
+			node.Index = rest[i+1]
+		}
+	}
+
+	node.FullScan = node.Operation == "SCAN" && !strings.Contains(detail, " USING ")
+	node.MissingIndex = strings.Contains(detail, "AUTOMATIC")
+	return node
+}
+
+// connDBTX is DBTX using a single connection of *sql.DB.
+type connDBTX struct {
+	*sql.Conn
+}
+
+func (c connDBTX) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return c.ExecContext(context.Background(), query, args...)
+}
+
+func (c connDBTX) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return c.QueryContext(context.Background(), query, args...)
+}
+
+func (c connDBTX) QueryRow(query string, args ...interface{}) *sql.Row {
+	return c.QueryRowContext(context.Background(), query, args...)
+}
+
+// check interfaces
+var (
+	_ DBTX        = connDBTX{}
+	_ dbtxContext = connDBTX{}
+)
+
+// explainSQLServer receives XML plan with SET SHOWPLAN_XML. It is a session option, so for *sql.DB
+// all statements are executed on a single connection; if it can't be turned off, the connection is discarded.
+func (q *Querier) explainSQLServer(query string, args []interface{}) (plan *Plan, err error) {
+	var conn *sql.Conn
+	if db, ok := q.dbtx.(*sql.DB); ok {
+		if conn, err = db.Conn(q.Context()); err != nil {
+			return nil, err
+		}
+		defer conn.Close()
+		q = q.clone()
+		q.dbtx = connDBTX{conn}
+	}
+
+	if _, err = q.Exec("SET SHOWPLAN_XML ON"); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if _, offErr := q.Exec("SET SHOWPLAN_XML OFF"); offErr != nil {
+			if conn != nil {
+				// don't return connection with SHOWPLAN_XML to the pool
+				conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+			}
+			if err == nil {
+				plan, err = nil, offErr
+			}
+		}
+	}()
+
+	var raw string
+	if err = q.QueryRow(query, args...).Scan(&raw); err != nil {
+		return nil, err
+	}
+	return parseSQLServerPlan(raw)
+}
+
+// parseSQLServerPlan parses SHOWPLAN_XML output: each RelOp element becomes a node.
+func parseSQLServerPlan(raw string) (*Plan, error) {
+	res := &Plan{Raw: raw}
+	var stack []*PlanNode
+	missingIndexTables := make(map[string]bool)
+
+	unquote := func(s string) string {
+		return strings.Trim(s, "[]")
+	}
+
+	d := xml.NewDecoder(strings.NewReader(raw))
+	// nvarchar plans are already decoded by the driver, but still declare utf-16
+	d.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) { return input, nil }
+	for {
+		token, err := d.Token()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("reform: failed to parse SQL Server plan: %s", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			attrs := make(map[string]string, len(t.Attr))
+			for _, a := range t.Attr {
+				attrs[a.Name.Local] = a.Value
+			}
+
+			switch t.Name.Local {
+			case "RelOp":
+				node := &PlanNode{Operation: attrs["PhysicalOp"]}
+				node.EstimatedRows, _ = strconv.ParseFloat(attrs["EstimateRows"], 64)
+				node.Cost, _ = strconv.ParseFloat(attrs["EstimatedTotalSubtreeCost"], 64)
+				node.FullScan = node.Operation == "Table Scan" || node.Operation == "Clustered Index Scan"
+				if attrs["LogicalOp"] != node.Operation {
+					node.Detail = attrs["LogicalOp"]
+				}
+				if len(stack) > 0 {
+					parent := stack[len(stack)-1]
+					parent.Children = append(parent.Children, node)
+				} else {
+					res.Nodes = append(res.Nodes, node)
+				}
+				stack = append(stack, node)
+
+			case "Object":
+				if len(stack) > 0 {
+					node := stack[len(stack)-1]
+					if node.Table == "" {
+						node.Table = unquote(attrs["Table"])
+						node.Index = unquote(attrs["Index"])
+					}
+				}
+
+			case "MissingIndex":
+				missingIndexTables[unquote(attrs["Table"])] = true
+			}
+
+		case xml.EndElement:
+			if t.Name.Local == "RelOp" && len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+
+	res.Walk(func(node *PlanNode, _ int) {
+		if missingIndexTables[node.Table] && strings.Contains(node.Operation, "Scan") {
+			node.MissingIndex = true
+		}
+	})
+	return res, nil
+}
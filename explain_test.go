@@ -0,0 +1,319 @@
+package reform_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xaionaro/reform"
+	"github.com/xaionaro/reform/dialects/sqlite3"
+	"github.com/xaionaro/reform/dialects/sqlserver"
+)
+
+// captured EXPLAIN outputs of "SELECT * FROM people JOIN projects ON projects.id = people.project_id WHERE people.name = 'Denis' ORDER BY people.id"
+const (
+	postgreSQLPlan = `[
+  {
+    "Plan": {
+      "Node Type": "Sort",
+      "Parallel Aware": false,
+      "Startup Cost": 37.97,
+      "Total Cost": 37.98,
+      "Plan Rows": 6,
+      "Plan Width": 72,
+      "Sort Key": ["people.id"],
+      "Plans": [
+        {
+          "Node Type": "Nested Loop",
+          "Parent Relationship": "Outer",
+          "Parallel Aware": false,
+          "Join Type": "Inner",
+          "Startup Cost": 0.15,
+          "Total Cost": 37.92,
+          "Plan Rows": 6,
+          "Plan Width": 72,
+          "Inner Unique": true,
+          "Plans": [
+            {
+              "Node Type": "Seq Scan",
+              "Parent Relationship": "Outer",
+              "Parallel Aware": false,
+              "Relation Name": "people",
+              "Alias": "people",
+              "Startup Cost": 0.00,
+              "Total Cost": 18.50,
+              "Plan Rows": 6,
+              "Plan Width": 36,
+              "Filter": "(name = 'Denis'::text)"
+            },
+            {
+              "Node Type": "Index Scan",
+              "Parent Relationship": "Inner",
+              "Parallel Aware": false,
+              "Scan Direction": "Forward",
+              "Index Name": "projects_pkey",
+              "Relation Name": "projects",
+              "Alias": "projects",
+              "Startup Cost": 0.15,
+              "Total Cost": 3.23,
+              "Plan Rows": 1,
+              "Plan Width": 36,
+              "Index Cond": "(id = people.project_id)"
+            }
+          ]
+        }
+      ]
+    }
+  }
+]`
+
+	mySQLPlan = `{
+  "query_block": {
+    "select_id": 1,
+    "cost_info": {
+      "query_cost": "1.10"
+    },
+    "ordering_operation": {
+      "using_temporary_table": false,
+      "using_filesort": true,
+      "nested_loop": [
+        {
+          "table": {
+            "table_name": "people",
+            "access_type": "ALL",
+            "rows_examined_per_scan": 5,
+            "rows_produced_per_join": 1,
+            "filtered": "20.00",
+            "cost_info": {
+              "read_cost": "0.65",
+              "eval_cost": "0.10",
+              "prefix_cost": "0.75",
+              "data_read_per_join": "1K"
+            },
+            "used_columns": ["id", "name", "project_id"],
+            "attached_condition": "(` + "`reform`.`people`.`name`" + ` = 'Denis')"
+          }
+        },
+        {
+          "table": {
+            "table_name": "projects",
+            "access_type": "eq_ref",
+            "possible_keys": ["PRIMARY"],
+            "key": "PRIMARY",
+            "used_key_parts": ["id"],
+            "key_length": "767",
+            "ref": ["reform.people.project_id"],
+            "rows_examined_per_scan": 1,
+            "rows_produced_per_join": 1,
+            "filtered": "100.00",
+            "cost_info": {
+              "read_cost": "0.25",
+              "eval_cost": "0.10",
+              "prefix_cost": "1.10",
+              "data_read_per_join": "1K"
+            },
+            "used_columns": ["id", "name"]
+          }
+        }
+      ]
+    }
+  }
+}`
+
+	sqlServerPlan = `<?xml version="1.0" encoding="utf-16"?>
+<ShowPlanXML xmlns="http://schemas.microsoft.com/sqlserver/2004/07/showplan" Version="1.539" Build="15.0.2000.5">
+  <BatchSequence>
+    <Batch>
+      <Statements>
+        <StmtSimple StatementText="SELECT * FROM people JOIN projects ON projects.id = people.project_id WHERE people.name = 'Denis' ORDER BY people.id" StatementId="1" StatementCompId="1" StatementType="SELECT" StatementSubTreeCost="0.0065704" StatementEstRows="1">
+          <QueryPlan CachedPlanSize="24" CompileTime="1" CompileCPU="1" CompileMemory="200">
+            <MissingIndexes>
+              <MissingIndexGroup Impact="62.4">
+                <MissingIndex Database="[reform]" Schema="[dbo]" Table="[people]">
+                  <ColumnGroup Usage="EQUALITY">
+                    <Column Name="[name]" ColumnId="3" />
+                  </ColumnGroup>
+                </MissingIndex>
+              </MissingIndexGroup>
+            </MissingIndexes>
+            <RelOp NodeId="0" PhysicalOp="Nested Loops" LogicalOp="Inner Join" EstimateRows="1" EstimatedTotalSubtreeCost="0.0065704">
+              <NestedLoops Optimized="0">
+                <RelOp NodeId="1" PhysicalOp="Clustered Index Scan" LogicalOp="Clustered Index Scan" EstimateRows="1" EstimatedTotalSubtreeCost="0.0032831">
+                  <IndexScan Ordered="1" ScanDirection="FORWARD">
+                    <Object Database="[reform]" Schema="[dbo]" Table="[people]" Index="[PK_people]" IndexKind="Clustered" Storage="RowStore" />
+                  </IndexScan>
+                </RelOp>
+                <RelOp NodeId="2" PhysicalOp="Clustered Index Seek" LogicalOp="Clustered Index Seek" EstimateRows="1" EstimatedTotalSubtreeCost="0.0032831">
+                  <IndexScan Ordered="1" ScanDirection="FORWARD">
+                    <Object Database="[reform]" Schema="[dbo]" Table="[projects]" Index="[PK_projects]" IndexKind="Clustered" Storage="RowStore" />
+                  </IndexScan>
+                </RelOp>
+              </NestedLoops>
+            </RelOp>
+          </QueryPlan>
+        </StmtSimple>
+      </Statements>
+    </Batch>
+  </BatchSequence>
+</ShowPlanXML>`
+)
+
+func TestParsePlan(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		parse          func(string) (*reform.Plan, error)
+		raw            string
+		tree           string
+		fullScans      []string
+		missingIndexes []string
+	}{{
+		name:  "PostgreSQL",
+		parse: reform.ParsePostgreSQLPlan,
+		raw:   postgreSQLPlan,
+		tree: "Sort (rows=6 cost=37.98)\n" +
+			"  Nested Loop (rows=6 cost=37.92)\n" +
+			"    Seq Scan on people (rows=6 cost=18.5) [FULL SCAN] [MISSING INDEX]: (name = 'Denis'::text)\n" +
+			"    Index Scan on projects using projects_pkey (rows=1 cost=3.23): (id = people.project_id)\n",
+		fullScans:      []string{"people"},
+		missingIndexes: []string{"people"},
+	}, {
+		name:  "MySQL",
+		parse: reform.ParseMySQLPlan,
+		raw:   mySQLPlan,
+		tree: "ordering_operation: using filesort\n" +
+			"  ALL on people (rows=5 cost=0.75) [FULL SCAN] [MISSING INDEX]: (`reform`.`people`.`name` = 'Denis')\n" +
+			"  eq_ref on projects using PRIMARY (rows=1 cost=1.1)\n",
+		fullScans:      []string{"people"},
+		missingIndexes: []string{"people"},
+	}, {
+		name:  "SQLServer",
+		parse: reform.ParseSQLServerPlan,
+		raw:   sqlServerPlan,
+		tree: "Nested Loops (rows=1 cost=0.0065704): Inner Join\n" +
+			"  Clustered Index Scan on people using PK_people (rows=1 cost=0.0032831) [FULL SCAN] [MISSING INDEX]\n" +
+			"  Clustered Index Seek on projects using PK_projects (rows=1 cost=0.0032831)\n",
+		fullScans:      []string{"people"},
+		missingIndexes: []string{"people"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			plan, err := tc.parse(tc.raw)
+			require.NoError(t, err)
+			assert.Equal(t, tc.raw, plan.Raw)
+			assert.Equal(t, tc.tree, plan.String())
+
+			tables := func(nodes []*reform.PlanNode) (res []string) {
+				for _, n := range nodes {
+					res = append(res, n.Table)
+				}
+				return
+			}
+			assert.Equal(t, tc.fullScans, tables(plan.FullScans()))
+			assert.Equal(t, tc.missingIndexes, tables(plan.MissingIndexes()))
+
+			_, err = tc.parse("not a plan <")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseSQLite3PlanDetail(t *testing.T) {
+	for _, tc := range []struct {
+		detail string
+		node   reform.PlanNode
+	}{
+		{"SCAN people", reform.PlanNode{Operation: "SCAN", Table: "people", FullScan: true}},
+		{"SCAN TABLE people", reform.PlanNode{Operation: "SCAN", Table: "people", FullScan: true}},
+		{"SCAN people USING COVERING INDEX people_name", reform.PlanNode{Operation: "SCAN", Table: "people", Index: "people_name"}},
+		{"SEARCH people USING INDEX people_name (name=?)", reform.PlanNode{Operation: "SEARCH", Table: "people", Index: "people_name"}},
+		{"SEARCH TABLE people USING INTEGER PRIMARY KEY (rowid=?)", reform.PlanNode{Operation: "SEARCH", Table: "people"}},
+		{"SEARCH projects USING AUTOMATIC COVERING INDEX (id=?)", reform.PlanNode{Operation: "SEARCH", Table: "projects", MissingIndex: true}},
+		{"USE TEMP B-TREE FOR ORDER BY", reform.PlanNode{Operation: "USE"}},
+	} {
+		t.Run(tc.detail, func(t *testing.T) {
+			tc.node.Detail = tc.detail
+			assert.Equal(t, &tc.node, reform.ParseSQLite3PlanDetail(tc.detail))
+		})
+	}
+}
+
+func TestExplainSQLite3(t *testing.T) {
+	db := newSQLite3DB(t,
+		`CREATE TABLE people (id integer PRIMARY KEY, name text NOT NULL, project_id integer)`,
+		`CREATE INDEX people_name ON people (name)`,
+	)
+
+	plan, err := db.Explain("SELECT * FROM people WHERE name = ?", "Denis")
+	require.NoError(t, err)
+	require.Len(t, plan.Nodes, 1)
+	assert.Equal(t, "SEARCH", plan.Nodes[0].Operation)
+	assert.Equal(t, "people", plan.Nodes[0].Table)
+	assert.Equal(t, "people_name", plan.Nodes[0].Index)
+	assert.Empty(t, plan.FullScans())
+	assert.Contains(t, plan.Raw, "people_name")
+
+	// subquery nodes are children of their parents
+	plan, err = db.Explain("SELECT * FROM people WHERE project_id IN (SELECT project_id FROM people WHERE id > 1)")
+	require.NoError(t, err)
+	var scans []*reform.PlanNode
+	plan.Walk(func(node *reform.PlanNode, depth int) {
+		if node.Operation == "SCAN" || node.Operation == "SEARCH" {
+			scans = append(scans, node)
+		}
+	})
+	assert.Len(t, scans, 2)
+	assert.Len(t, plan.FullScans(), 1)
+
+	// dialects may not implement ExplainDialect
+	_, err = reform.NewDB(openSQLite3(t), struct{ reform.Dialect }{sqlite3.Dialect}, nil).Explain("SELECT * FROM people")
+	assert.EqualError(t, err, "reform: dialect sqlite3 does not support EXPLAIN")
+}
+
+// TestExplainSQLServerSession emulates SHOWPLAN_XML session option with a table in SQLite ":memory:" database,
+// which is private to a connection.
+func TestExplainSQLServerSession(t *testing.T) {
+	sqlDB := openSQLite3(t)
+
+	failOff := false
+	db := reform.NewDB(sqlDB, sqlserver.Dialect, reform.NewPrintfLogger(t.Logf))
+	db.Use(reform.InterceptorFuncs{
+		OnExec: func(query string, args []interface{}, next reform.ExecFunc) (sql.Result, error) {
+			switch query {
+			case "SET SHOWPLAN_XML ON":
+				query = "CREATE TABLE showplan AS SELECT ? AS x"
+				args = []interface{}{sqlServerPlan}
+			case "SET SHOWPLAN_XML OFF":
+				query = "DROP TABLE showplan"
+				if failOff {
+					query = "DROP TABLE no_such_table"
+				}
+			}
+			return next(query, args)
+		},
+		OnQueryRow: func(query string, args []interface{}, next reform.QueryRowFunc) *sql.Row {
+			if query == "SELECT * FROM people" {
+				query, args = "SELECT x FROM showplan", nil
+			}
+			return next(query, args)
+		},
+	})
+
+	// without a pinned connection statements would be executed on new databases
+	sqlDB.SetMaxIdleConns(0)
+	plan, err := db.Explain("SELECT * FROM people")
+	require.NoError(t, err)
+	assert.Len(t, plan.FullScans(), 1)
+
+	// connection with the option still on is not returned to the pool
+	sqlDB.SetMaxIdleConns(1)
+	failOff = true
+	_, err = db.Explain("SELECT * FROM people")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no_such_table")
+
+	var n int
+	err = db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE name = 'showplan'").Scan(&n)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+}
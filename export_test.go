@@ -0,0 +1,12 @@
+package reform
+
+// Parsers of plans are exported for tests with captured EXPLAIN output.
+var (
+	ParsePostgreSQLPlan    = parsePostgreSQLPlan
+	ParseMySQLPlan         = parseMySQLPlan
+	ParseSQLite3PlanDetail = parseSQLite3PlanDetail
+	ParseSQLServerPlan     = parseSQLServerPlan
+)
+
+// ShardLess is exported for tests of merge order.
+var ShardLess = shardLess
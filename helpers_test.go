@@ -0,0 +1,33 @@
+package reform_test
+
+import (
+	"database/sql"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xaionaro/reform"
+	"github.com/xaionaro/reform/dialects/sqlite3"
+)
+
+// openSQLite3 opens a new in-memory SQLite3 database closed at the end of the test.
+// It has a single connection, as every connection would have its own database.
+func openSQLite3(t *testing.T) *sql.DB {
+	sqlDB, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+	return sqlDB
+}
+
+// newSQLite3DB returns DB for a new in-memory SQLite3 database (see openSQLite3) logging to t,
+// after executing given statements.
+func newSQLite3DB(t *testing.T, queries ...string) *reform.DB {
+	db := reform.NewDB(openSQLite3(t), sqlite3.Dialect, reform.NewPrintfLogger(t.Logf))
+	for _, q := range queries {
+		_, err := db.Exec(q)
+		require.NoError(t, err)
+	}
+	return db
+}
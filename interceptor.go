@@ -0,0 +1,121 @@
+package reform
+
+import (
+	"database/sql"
+)
+
+// ExecFunc executes a query without returning any rows. It is the "next" function passed to Interceptor.Exec.
+type ExecFunc func(query string, args []interface{}) (sql.Result, error)
+
+// QueryFunc executes a query that returns rows. It is the "next" function passed to Interceptor.Query.
+type QueryFunc func(query string, args []interface{}) (*sql.Rows, error)
+
+// QueryRowFunc executes a query that is expected to return at most one row.
+// It is the "next" function passed to Interceptor.QueryRow.
+type QueryRowFunc func(query string, args []interface{}) *sql.Row
+
+// Interceptor wraps queries executed by Querier's Exec, Query and QueryRow methods
+// (and, therefore, by all other Querier methods).
+//
+// Each method receives the query, its arguments and the next function in the chain.
+// An interceptor may change the query or arguments, call next several times (retries),
+// not call it at all (circuit breaking, caching), or just observe the call (recording, metrics).
+type Interceptor interface {
+	// Exec intercepts a query without returning any rows.
+	Exec(query string, args []interface{}, next ExecFunc) (sql.Result, error)
+
+	// Query intercepts a query that returns rows.
+	Query(query string, args []interface{}, next QueryFunc) (*sql.Rows, error)
+
+	// QueryRow intercepts a query that is expected to return at most one row.
+	QueryRow(query string, args []interface{}, next QueryRowFunc) *sql.Row
+}
+
+// InterceptorFuncs is an Interceptor built from optional functions.
+// Nil functions pass the call to the next function in the chain unchanged.
+type InterceptorFuncs struct {
+	OnExec     func(query string, args []interface{}, next ExecFunc) (sql.Result, error)
+	OnQuery    func(query string, args []interface{}, next QueryFunc) (*sql.Rows, error)
+	OnQueryRow func(query string, args []interface{}, next QueryRowFunc) *sql.Row
+}
+
+// Exec calls OnExec if it is set, next otherwise.
+func (i InterceptorFuncs) Exec(query string, args []interface{}, next ExecFunc) (sql.Result, error) {
+	if i.OnExec == nil {
+		return next(query, args)
+	}
+	return i.OnExec(query, args, next)
+}
+
+// Query calls OnQuery if it is set, next otherwise.
+func (i InterceptorFuncs) Query(query string, args []interface{}, next QueryFunc) (*sql.Rows, error) {
+	if i.OnQuery == nil {
+		return next(query, args)
+	}
+	return i.OnQuery(query, args, next)
+}
+
+// QueryRow calls OnQueryRow if it is set, next otherwise.
+func (i InterceptorFuncs) QueryRow(query string, args []interface{}, next QueryRowFunc) *sql.Row {
+	if i.OnQueryRow == nil {
+		return next(query, args)
+	}
+	return i.OnQueryRow(query, args, next)
+}
+
+// execChain returns f wrapped by all interceptors, the first interceptor being the outermost one.
+func (q *Querier) execChain(f ExecFunc) ExecFunc {
+	for i := len(q.interceptors) - 1; i >= 0; i-- {
+		interceptor, next := q.interceptors[i], f
+		f = func(query string, args []interface{}) (sql.Result, error) {
+			return interceptor.Exec(query, args, next)
+		}
+	}
+	return f
+}
+
+// queryChain returns f wrapped by all interceptors, the first interceptor being the outermost one.
+func (q *Querier) queryChain(f QueryFunc) QueryFunc {
+	for i := len(q.interceptors) - 1; i >= 0; i-- {
+		interceptor, next := q.interceptors[i], f
+		f = func(query string, args []interface{}) (*sql.Rows, error) {
+			return interceptor.Query(query, args, next)
+		}
+	}
+	return f
+}
+
+// queryRowChain returns f wrapped by all interceptors, the first interceptor being the outermost one.
+func (q *Querier) queryRowChain(f QueryRowFunc) QueryRowFunc {
+	for i := len(q.interceptors) - 1; i >= 0; i-- {
+		interceptor, next := q.interceptors[i], f
+		f = func(query string, args []interface{}) *sql.Row {
+			return interceptor.QueryRow(query, args, next)
+		}
+	}
+	return f
+}
+
+// Interceptors returns a copy of the interceptor chain of this Querier.
+func (q *Querier) Interceptors() []Interceptor {
+	return append([]Interceptor(nil), q.interceptors...)
+}
+
+// WithInterceptors returns a copy of Querier with given interceptors appended to the chain.
+// Returned Querier is tied to the same DB or TX.
+func (q *Querier) WithInterceptors(interceptors ...Interceptor) *Querier {
+	newQ := q.clone()
+	newQ.interceptors = append(newQ.Interceptors(), interceptors...)
+	return newQ
+}
+
+// Use appends given interceptors to the chain of this DB.
+// The first added interceptor is the outermost one: it sees the call first and the result last.
+// Interceptors see queries with tags (see WithTags) already appended.
+// Transactions started with Begin and copies made with WithTag after that inherit the chain.
+func (db *DB) Use(interceptors ...Interceptor) {
+	db.interceptors = append(db.Interceptors(), interceptors...)
+}
+
+// check interface
+var _ Interceptor = InterceptorFuncs{}
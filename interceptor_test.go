@@ -0,0 +1,76 @@
+package reform_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xaionaro/reform"
+)
+
+func TestInterceptors(t *testing.T) {
+	db := newSQLite3DB(t,
+		`CREATE TABLE docs (id integer PRIMARY KEY, title text NOT NULL)`,
+		`INSERT INTO docs (id, title) VALUES (1, 'a')`,
+	)
+
+	var calls []string
+	record := func(name string) reform.Interceptor {
+		return reform.InterceptorFuncs{
+			OnExec: func(query string, args []interface{}, next reform.ExecFunc) (sql.Result, error) {
+				calls = append(calls, name+" exec")
+				return next(query, args)
+			},
+			OnQuery: func(query string, args []interface{}, next reform.QueryFunc) (*sql.Rows, error) {
+				calls = append(calls, name+" before")
+				rows, err := next(query, args)
+				calls = append(calls, name+" after")
+				return rows, err
+			},
+			OnQueryRow: func(query string, args []interface{}, next reform.QueryRowFunc) *sql.Row {
+				calls = append(calls, name+" row")
+				return next(query, args)
+			},
+		}
+	}
+
+	q := db.WithInterceptors(record("outer"), record("inner"))
+	rows, err := q.Query("SELECT id, title FROM docs")
+	require.NoError(t, err)
+	assert.NoError(t, rows.Close())
+	assert.Equal(t, []string{"outer before", "inner before", "inner after", "outer after"}, calls)
+
+	calls = nil
+	var title string
+	require.NoError(t, q.QueryRow("SELECT title FROM docs WHERE id = ?", 1).Scan(&title))
+	assert.Equal(t, []string{"outer row", "inner row"}, calls)
+
+	// chain of the copy does not change DB
+	calls = nil
+	require.NoError(t, db.QueryRow("SELECT title FROM docs WHERE id = ?", 1).Scan(&title))
+	assert.Empty(t, calls)
+
+	// interceptors may change the query
+	rewrite := reform.InterceptorFuncs{
+		OnExec: func(query string, args []interface{}, next reform.ExecFunc) (sql.Result, error) {
+			return next("UPDATE docs SET title = ? WHERE id = ?", []interface{}{"rewritten", 1})
+		},
+	}
+	_, err = q.WithInterceptors(rewrite).Exec("DELETE FROM docs")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"outer exec", "inner exec"}, calls)
+	require.NoError(t, db.QueryRow("SELECT title FROM docs WHERE id = 1").Scan(&title))
+	assert.Equal(t, "rewritten", title)
+
+	// transactions inherit the chain of DB
+	calls = nil
+	db.Use(record("db"))
+	tx, err := db.Begin()
+	require.NoError(t, err)
+	_, err = tx.Exec("UPDATE docs SET title = ?", "tx")
+	assert.NoError(t, err)
+	assert.NoError(t, tx.Commit())
+	assert.Equal(t, []string{"db exec"}, calls)
+}
@@ -0,0 +1,11 @@
+// Package scopes contains models for tests of generated scopes.
+package scopes
+
+//go:generate reform
+
+//reform:docs
+type Doc struct {
+	ID       int64  `reform:"id,pk"`
+	TenantID int64  `reform:"tenant_id,tenant"`
+	Title    string `reform:"title"`
+}
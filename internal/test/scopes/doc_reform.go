@@ -0,0 +1,1164 @@
+package scopes
+
+// Generated with gopkg.in/reform.v1. Do not edit by hand.
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/xaionaro/reform"
+)
+
+type DocScope struct {
+	item *Doc
+
+	db           reform.ReformDBTX
+	sharded      *reform.ShardedDB
+	where        [][]interface{}
+	order        []string
+	groupBy      []string
+	limit        int
+	tableQuery   *string
+	fieldsFilter []string
+	appendTail   string
+	cacheTTL     *time.Duration
+
+	loggingEnabled bool
+	loggingAuthor  *string
+	loggingComment string
+}
+type DocFilter Doc
+
+type DocLogRow struct {
+	Doc
+	LogAuthor  *string
+	LogAction  string
+	LogDate    time.Time
+	LogComment string
+}
+
+// Schema returns a schema name in SQL database ("").
+type docTableTypeType struct {
+	s reform.StructInfo
+	z []interface{}
+}
+
+func (v docTableTypeType) Schema() string {
+	return v.s.SQLSchema
+}
+
+// Name returns a view or table name in SQL database ("docs").
+func (v docTableTypeType) Name() string {
+	return v.s.SQLName
+}
+
+// Columns returns a new slice of column names for that view or table in SQL database.
+func (v docTableTypeType) Columns() []string {
+	return []string{"id", "tenant_id", "title"}
+}
+
+// NewStruct makes a new struct for that view or table.
+func (v docTableTypeType) NewStruct() reform.Struct {
+	return new(Doc)
+}
+
+// NewRecord makes a new record for that table.
+func (v *docTableTypeType) NewRecord() reform.Record {
+	return new(Doc)
+}
+
+func (v *docTableTypeType) NewScope() *DocScope {
+	return &DocScope{item: &Doc{}}
+}
+
+// PKColumnIndex returns an index of primary key column for that table in SQL database.
+func (v *docTableTypeType) PKColumnIndex() uint {
+	return uint(v.s.PKFieldIndex)
+}
+
+func (v docTableTypeType) CreateTableIfNotExists(db *reform.DB) (bool, error) {
+	if db == nil {
+		db = defaultDB_Doc
+	}
+	return db.CreateTableIfNotExists(v.s)
+}
+
+func (v docTableTypeType) StructInfo() reform.StructInfo {
+	return v.s
+}
+
+// DocTable represents docs view or table in SQL database.
+var DocTable = &docTableTypeType{
+	s: reform.StructInfo{Type: "Doc", SQLSchema: "", SQLName: "docs", Fields: []reform.FieldInfo{{Name: "ID", IsPK: true, IsUnique: false, HasIndex: false, Type: "int64", Column: "id", FieldsPath: []reform.FieldInfo{}, SQLSize: 0, SQLPrecision: 0, SQLScale: 0, Kind: "int64", Embedded: "", IsJSON: false, IsArray: false, NullZero: 0, Encryption: 0, PKGenerator: "", IsTenant: false, StructFile: "", Indexes: []reform.FieldIndex(nil), SQLType: "", Default: "", Check: "", ReferencesTable: "", ReferencesColumn: "", OnDelete: "", EnumValues: []string(nil)}, {Name: "TenantID", IsPK: false, IsUnique: false, HasIndex: false, Type: "int64", Column: "tenant_id", FieldsPath: []reform.FieldInfo{}, SQLSize: 0, SQLPrecision: 0, SQLScale: 0, Kind: "int64", Embedded: "", IsJSON: false, IsArray: false, NullZero: 0, Encryption: 0, PKGenerator: "", IsTenant: true, StructFile: "", Indexes: []reform.FieldIndex(nil), SQLType: "", Default: "", Check: "", ReferencesTable: "", ReferencesColumn: "", OnDelete: "", EnumValues: []string(nil)}, {Name: "Title", IsPK: false, IsUnique: false, HasIndex: false, Type: "string", Column: "title", FieldsPath: []reform.FieldInfo{}, SQLSize: 0, SQLPrecision: 0, SQLScale: 0, Kind: "string", Embedded: "", IsJSON: false, IsArray: false, NullZero: 0, Encryption: 0, PKGenerator: "", IsTenant: false, StructFile: "", Indexes: []reform.FieldIndex(nil), SQLType: "", Default: "", Check: "", ReferencesTable: "", ReferencesColumn: "", OnDelete: "", EnumValues: []string(nil)}}, PKFieldIndex: 0, ImitateGorm: false, SkipMethodOrder: false},
+	z: new(Doc).Values(),
+}
+
+type docTableTypeType_log struct {
+	s reform.StructInfo
+	z []interface{}
+}
+
+func (v *docTableTypeType_log) Schema() string {
+	return v.s.SQLSchema
+}
+
+func (v *docTableTypeType_log) Name() string {
+	return v.s.SQLName
+}
+
+func (v *docTableTypeType_log) Columns() []string {
+	return []string{"id", "tenant_id", "title", "log_author", "log_action", "log_date", "log_comment"}
+}
+
+func (v *docTableTypeType_log) NewStruct() reform.Struct {
+	return new(Doc)
+}
+
+func (v *docTableTypeType_log) NewRecord() reform.Record {
+	return new(Doc)
+}
+
+func (v *docTableTypeType_log) NewScope() *DocScope {
+	return &DocScope{item: &Doc{}}
+}
+
+func (v *docTableTypeType_log) PKColumnIndex() uint {
+	return uint(v.s.PKFieldIndex)
+}
+
+// CreateTableIfNotExists creates "docs_log" table if it does not exist, see Log().
+func (v docTableTypeType_log) CreateTableIfNotExists(db *reform.DB) (bool, error) {
+	if db == nil {
+		db = defaultDB_Doc
+	}
+	return db.CreateLogTableIfNotExists(v.s)
+}
+
+var DocTableLogRow = &docTableTypeType_log{
+	s: reform.StructInfo{Type: "Doc", SQLSchema: "", SQLName: "docs_log", Fields: []reform.FieldInfo{{Name: "ID", IsPK: true, IsUnique: false, HasIndex: false, Type: "int64", Column: "id", FieldsPath: []reform.FieldInfo{}, SQLSize: 0, SQLPrecision: 0, SQLScale: 0, Kind: "int64", Embedded: "", IsJSON: false, IsArray: false, NullZero: 0, Encryption: 0, PKGenerator: "", IsTenant: false, StructFile: "", Indexes: []reform.FieldIndex(nil), SQLType: "", Default: "", Check: "", ReferencesTable: "", ReferencesColumn: "", OnDelete: "", EnumValues: []string(nil)}, {Name: "TenantID", IsPK: false, IsUnique: false, HasIndex: false, Type: "int64", Column: "tenant_id", FieldsPath: []reform.FieldInfo{}, SQLSize: 0, SQLPrecision: 0, SQLScale: 0, Kind: "int64", Embedded: "", IsJSON: false, IsArray: false, NullZero: 0, Encryption: 0, PKGenerator: "", IsTenant: true, StructFile: "", Indexes: []reform.FieldIndex(nil), SQLType: "", Default: "", Check: "", ReferencesTable: "", ReferencesColumn: "", OnDelete: "", EnumValues: []string(nil)}, {Name: "Title", IsPK: false, IsUnique: false, HasIndex: false, Type: "string", Column: "title", FieldsPath: []reform.FieldInfo{}, SQLSize: 0, SQLPrecision: 0, SQLScale: 0, Kind: "string", Embedded: "", IsJSON: false, IsArray: false, NullZero: 0, Encryption: 0, PKGenerator: "", IsTenant: false, StructFile: "", Indexes: []reform.FieldIndex(nil), SQLType: "", Default: "", Check: "", ReferencesTable: "", ReferencesColumn: "", OnDelete: "", EnumValues: []string(nil)}, {Name: "LogAuthor", IsPK: false, IsUnique: false, HasIndex: false, Type: "*string", Column: "log_author", FieldsPath: []reform.FieldInfo(nil), SQLSize: 0, SQLPrecision: 0, SQLScale: 0, Kind: "", Embedded: "", IsJSON: false, IsArray: false, NullZero: 0, Encryption: 0, PKGenerator: "", IsTenant: false, StructFile: "", Indexes: []reform.FieldIndex(nil), SQLType: "", Default: "", Check: "", ReferencesTable: "", ReferencesColumn: "", OnDelete: "", EnumValues: []string(nil)}, {Name: "LogAction", IsPK: false, IsUnique: false, HasIndex: false, Type: "string", Column: "log_action", FieldsPath: []reform.FieldInfo(nil), SQLSize: 0, SQLPrecision: 0, SQLScale: 0, Kind: "", Embedded: "", IsJSON: false, IsArray: false, NullZero: 0, Encryption: 0, PKGenerator: "", IsTenant: false, StructFile: "", Indexes: []reform.FieldIndex(nil), SQLType: "", Default: "", Check: "", ReferencesTable: "", ReferencesColumn: "", OnDelete: "", EnumValues: []string(nil)}, {Name: "LogDate", IsPK: false, IsUnique: false, HasIndex: false, Type: "time.Time", Column: "log_date", FieldsPath: []reform.FieldInfo(nil), SQLSize: 0, SQLPrecision: 0, SQLScale: 0, Kind: "", Embedded: "", IsJSON: false, IsArray: false, NullZero: 0, Encryption: 0, PKGenerator: "", IsTenant: false, StructFile: "", Indexes: []reform.FieldIndex(nil), SQLType: "", Default: "", Check: "", ReferencesTable: "", ReferencesColumn: "", OnDelete: "", EnumValues: []string(nil)}, {Name: "LogComment", IsPK: false, IsUnique: false, HasIndex: false, Type: "string", Column: "log_comment", FieldsPath: []reform.FieldInfo(nil), SQLSize: 0, SQLPrecision: 0, SQLScale: 0, Kind: "", Embedded: "", IsJSON: false, IsArray: false, NullZero: 0, Encryption: 0, PKGenerator: "", IsTenant: false, StructFile: "", Indexes: []reform.FieldIndex(nil), SQLType: "", Default: "", Check: "", ReferencesTable: "", ReferencesColumn: "", OnDelete: "", EnumValues: []string(nil)}}, PKFieldIndex: 0, ImitateGorm: false, SkipMethodOrder: false},
+	z: new(DocLogRow).Values(),
+}
+
+func (s docTableTypeType) ColumnNameByFieldName(fieldName string) string {
+	switch fieldName {
+	case "ID":
+		return "id"
+	case "TenantID":
+		return "tenant_id"
+	case "Title":
+		return "title"
+	}
+	return ""
+}
+
+func (s docTableTypeType_log) ColumnNameByFieldName(fieldName string) string {
+	switch fieldName {
+	case "ID":
+		return "id"
+	case "TenantID":
+		return "tenant_id"
+	case "Title":
+		return "title"
+	case "LogAuthor":
+		return "log_author"
+	case "LogAction":
+		return "log_action"
+	case "LogDate":
+		return "log_date"
+	case "LogComment":
+		return "log_comment"
+	}
+	return ""
+}
+
+func (s *Doc) FieldPointersByNames(fieldNames []string) (fieldPointers []interface{}) {
+	if len(fieldNames) == 0 {
+		return s.Pointers()
+	}
+
+	for _, fieldName := range fieldNames {
+		fieldPointer := s.FieldPointerByName(fieldName)
+		if fieldPointer == nil {
+			panic("Invalid field name:" + fieldName)
+		}
+		fieldPointers = append(fieldPointers, fieldPointer)
+	}
+
+	return
+}
+
+func (s *DocLogRow) FieldPointersByNames(fieldNames []string) (fieldPointers []interface{}) {
+	if len(fieldNames) == 0 {
+		return s.Pointers()
+	}
+
+	for _, fieldName := range fieldNames {
+		fieldPointer := s.FieldPointerByName(fieldName)
+		if fieldPointer == nil {
+			panic("Invalid field name:" + fieldName)
+		}
+		fieldPointers = append(fieldPointers, fieldPointer)
+	}
+
+	return
+}
+
+func (s *Doc) FieldPointerByName(fieldName string) interface{} {
+	switch fieldName {
+	case "ID":
+		return &s.ID
+	case "TenantID":
+		return &s.TenantID
+	case "Title":
+		return &s.Title
+	}
+
+	return nil
+}
+
+func (s *DocLogRow) FieldPointerByName(fieldName string) interface{} {
+	switch fieldName {
+	case "ID":
+		return &s.ID
+	case "TenantID":
+		return &s.TenantID
+	case "Title":
+		return &s.Title
+	case "LogAuthor":
+		return &s.LogAuthor
+	case "LogAction":
+		return &s.LogAction
+	case "LogDate":
+		return &s.LogDate
+	case "LogComment":
+		return &s.LogComment
+	}
+
+	return nil
+}
+
+// String returns a string representation of this struct or record.
+func (s Doc) String() string {
+	res := make([]string, 3)
+	res[0] = "ID: " + reform.Inspect(s.ID, true)
+	res[1] = "TenantID: " + reform.Inspect(s.TenantID, true)
+	res[2] = "Title: " + reform.Inspect(s.Title, true)
+	return strings.Join(res, ", ")
+}
+func (s DocLogRow) String() string {
+	res := make([]string, 7)
+	res[0] = "ID: " + reform.Inspect(s.ID, true)
+	res[1] = "TenantID: " + reform.Inspect(s.TenantID, true)
+	res[2] = "Title: " + reform.Inspect(s.Title, true)
+	res[3] = "LogAuthor: " + reform.Inspect(s.LogAuthor, true)
+	res[4] = "LogAction: " + reform.Inspect(s.LogAction, true)
+	res[5] = "LogDate: " + reform.Inspect(s.LogDate, true)
+	res[6] = "LogComment: " + reform.Inspect(s.LogComment, true)
+	return strings.Join(res, ", ")
+}
+
+// Values returns a slice of struct or record field values.
+// Returned interface{} values are never untyped nils.
+func (s *Doc) Values() []interface{} {
+	return []interface{}{
+		s.ID,
+		s.TenantID,
+		s.Title,
+	}
+}
+func (s *DocLogRow) Values() []interface{} {
+	return append(s.Doc.Values(), []interface{}{
+		s.LogAuthor,
+		s.LogAction,
+		s.LogDate,
+		s.LogComment,
+	}...)
+}
+
+// Pointers returns a slice of pointers to struct or record fields.
+// Returned interface{} values are never untyped nils.
+func (s *Doc) Pointers() []interface{} {
+	return []interface{}{
+		&s.ID,
+		&s.TenantID,
+		&s.Title,
+	}
+}
+func (s *DocLogRow) Pointers() []interface{} {
+	return append(s.Doc.Pointers(), []interface{}{
+		&s.LogAuthor,
+		&s.LogAction,
+		&s.LogDate,
+		&s.LogComment,
+	}...)
+}
+
+// View returns View object for that struct.
+func (s Doc) View() reform.View {
+	return DocTable
+}
+func (s DocScope) View() reform.View {
+	return s.item.View()
+}
+func (s DocLogRow) View() reform.View {
+	return DocTableLogRow
+}
+
+// Generate a scope for object
+func (s Doc) Scope() *DocScope {
+	return &DocScope{item: &s, db: defaultDB_Doc}
+}
+func (s *Doc) PtrScope() *DocScope {
+	return &DocScope{item: s, db: defaultDB_Doc}
+}
+
+// Sets DB to do queries
+func (s Doc) DB(db reform.ReformDBTX) (scope *DocScope) { return s.Scope().DB(db) }
+func (s *DocScope) DB(db reform.ReformDBTX) *DocScope {
+	if db != nil {
+		s.db = db
+	}
+	afterDBer, ok := interface{}(s).(reform.AfterDBer)
+	if ok {
+		afterDBer.AfterDB()
+	}
+	return s
+}
+
+// Tags sets sqlcommenter tags which are appended to every statement of the scope
+func (s Doc) Tags(tags reform.Tags) (scope *DocScope) { return s.Scope().Tags(tags) }
+func (s DocScope) Tags(tags reform.Tags) *DocScope {
+	s.db = reform.WithQuerier(s.db, func(q *reform.Querier) *reform.Querier { return q.WithTags(tags) })
+	return &s
+}
+
+// WithContext sets a context for queries of the scope (tags stored by reform.ContextWithTags are appended to every statement)
+func (s Doc) WithContext(ctx context.Context) (scope *DocScope) { return s.Scope().WithContext(ctx) }
+func (s DocScope) WithContext(ctx context.Context) *DocScope {
+	s.db = reform.WithQuerier(s.db, func(q *reform.Querier) *reform.Querier { return q.WithContext(ctx) })
+	return &s
+}
+
+// InSchema sets a schema used instead of the model's one by every statement of the scope (see reform.Querier.WithSchema)
+func (s Doc) InSchema(schema string) (scope *DocScope) { return s.Scope().InSchema(schema) }
+func (s DocScope) InSchema(schema string) *DocScope {
+	s.db = reform.WithQuerier(s.db, func(q *reform.Querier) *reform.Querier { return q.WithSchema(schema) })
+	return &s
+}
+
+// WithTableResolver sets a resolver of schema and table names for every statement of the scope (see reform.Querier.WithTableResolver)
+func (s Doc) WithTableResolver(resolver reform.TableResolver) (scope *DocScope) {
+	return s.Scope().WithTableResolver(resolver)
+}
+func (s DocScope) WithTableResolver(resolver reform.TableResolver) *DocScope {
+	s.db = reform.WithQuerier(s.db, func(q *reform.Querier) *reform.Querier { return q.WithTableResolver(resolver) })
+	return &s
+}
+
+// Sharded sets a sharded database for the scope: Select, First, Count and Each fan out across all shards,
+// and Insert, Replace, Save, Update and Delete are routed to the shard of the record
+func (s Doc) Sharded(db *reform.ShardedDB) (scope *DocScope) { return s.Scope().Sharded(db) }
+func (s DocScope) Sharded(db *reform.ShardedDB) *DocScope {
+	s.sharded = db
+	s.db = db.Shards()[0]
+	return &s
+}
+
+// ForTenant limits every statement of the scope to rows of given tenant (see reform.Querier.WithTenant)
+func (s Doc) ForTenant(tenant interface{}) (scope *DocScope) { return s.Scope().ForTenant(tenant) }
+func (s DocScope) ForTenant(tenant interface{}) *DocScope {
+	s.db = reform.WithQuerier(s.db, func(q *reform.Querier) *reform.Querier { return q.WithTenant(tenant) })
+	return &s
+}
+
+// WithoutTenant allows cross-tenant access for statements of the scope (see reform.Querier.WithoutTenant)
+func (s Doc) WithoutTenant() (scope *DocScope) { return s.Scope().WithoutTenant() }
+func (s DocScope) WithoutTenant() *DocScope {
+	s.db = reform.WithQuerier(s.db, func(q *reform.Querier) *reform.Querier { return q.WithoutTenant() })
+	return &s
+}
+
+// Gets DB
+func (s Doc) GetDB() (db *reform.DB) { return s.Scope().GetDB() }
+func (s DocScope) GetDB() *reform.DB {
+	return s.db.(*reform.DB)
+}
+
+func (s Doc) StartTransaction() (*reform.TX, error) { return s.Scope().StartTransaction() }
+func (s DocScope) StartTransaction() (*reform.TX, error) {
+	return s.db.(*reform.DB).Begin()
+}
+
+// Sets default DB (to do not call the scope.DB() method every time)
+func (s *Doc) SetDefaultDB(db *reform.DB) (err error) {
+	defaultDB_Doc = db
+	return nil
+}
+
+// Compiles SQL tail for defined limit scope
+// TODO: should be compiled via dialects
+func (s *DocScope) getLimitTail() (tail string, args []interface{}, err error) {
+	if s.limit <= 0 {
+		return
+	}
+
+	tail = fmt.Sprintf("%v", s.limit)
+	return
+}
+
+// Compiles SQL tail for defined group scope
+// TODO: should be compiled via dialects
+func (s *DocScope) getGroupTail() (tail string, args []interface{}, err error) {
+	tail = strings.Join(s.groupBy, ", ")
+
+	return
+}
+
+// Compiles SQL tail for defined order scope
+// TODO: should be compiled via dialects
+func (s *DocScope) getOrderTail() (tail string, args []interface{}, err error) {
+	var fieldName string
+	var orderStringParts []string
+
+	for idx, orderStr := range s.order {
+		switch idx % 2 {
+		case 0:
+			fieldName = orderStr
+		case 1:
+			orderDirection := orderStr
+
+			orderStringParts = append(orderStringParts, s.db.EscapeTableName(fieldName)+" "+orderDirection)
+		}
+	}
+
+	tail = strings.Join(orderStringParts, ", ")
+
+	return
+}
+
+// Compiles SQL tail for defined filter
+// TODO: should be compiled via dialects
+func (s *DocScope) getWhereTailForFilter(filter DocFilter) (tail string, whereTailArgs []interface{}, err error) {
+	return s.db.GetWhereTailForFilter(Doc(filter), nil, "", false)
+}
+
+// parseQuerierArgs considers different ways of defning the tail (using scope properties or/and in_args)
+func (s DocScope) parseWhereTailComponent(in_args []interface{}, placeholderCounter *int) (tail string, args []interface{}, err error) {
+	if len(in_args) > 0 {
+		switch arg := in_args[0].(type) {
+		case int:
+			*placeholderCounter++
+			tail = "id " + s.db.OperatorAndPlaceholderOfValueForSQL(in_args[0], *placeholderCounter)
+			args = s.db.ValueForSQL(in_args[0])
+		case string:
+			tailWords := s.db.SplitConditionByPlaceholders(arg)
+
+			if len(tailWords)-1 != len(in_args[1:]) {
+				panic(fmt.Errorf("The pattern doesn't fit for passed arguments (wrong number of question marks?): len(tailWords)-1 != len(in_args[1:]): <%v> <%v>", arg, in_args[1:]))
+			}
+
+			for idx, rawNewArgs := range in_args[1:] {
+				newArgs := s.db.ValueForSQL(rawNewArgs)
+				newTailWords := []string{}
+				for range newArgs {
+					*placeholderCounter++
+					newTailWords = append(newTailWords, s.db.GetDialect().Placeholder(*placeholderCounter))
+				}
+				tail += tailWords[idx] + strings.Join(newTailWords, ",")
+				args = append(args, newArgs...)
+			}
+			tail += tailWords[len(in_args[1:])]
+
+			return
+		case *Doc:
+			in_args[0] = *arg
+			return s.parseWhereTailComponent(in_args, placeholderCounter)
+		case *DocFilter:
+			in_args[0] = *arg
+			return s.parseWhereTailComponent(in_args, placeholderCounter)
+		case Doc:
+			if len(in_args) > 1 {
+				s = *s.Where(in_args[1], in_args[2:]...)
+			}
+			tail, args, err = s.getWhereTailForFilter(DocFilter(arg))
+		case DocFilter:
+			if len(in_args) > 1 {
+				s = *s.Where(in_args[1], in_args[2:]...)
+			}
+			tail, args, err = s.getWhereTailForFilter(arg)
+		default:
+			err = fmt.Errorf("Invalid first element of \"in_args\" (%T). It should be a string or DocFilter.", arg)
+			return
+		}
+	}
+
+	return
+}
+
+// Compiles SQL tail for defined filter
+// TODO: should be compiled via dialects
+func (s *DocScope) getWhereTail() (tail string, whereTailArgs []interface{}, err error) {
+	var whereTailStringParts []string
+
+	if len(s.where) == 0 {
+		return
+	}
+
+	placeholderCounter := 0
+
+	for _, whereComponent := range s.where {
+		var whereTailStringPart string
+		var whereTailArgsPart []interface{}
+
+		whereTailStringPart, whereTailArgsPart, err = s.parseWhereTailComponent(whereComponent, &placeholderCounter)
+		if err != nil {
+			return
+		}
+
+		if len(whereTailStringPart) > 0 {
+			whereTailStringParts = append(whereTailStringParts, whereTailStringPart)
+		}
+		whereTailArgs = append(whereTailArgs, whereTailArgsPart...)
+	}
+
+	if len(whereTailStringParts) == 0 {
+		return
+	}
+
+	tail = "(" + strings.Join(whereTailStringParts, ") AND (") + ")"
+
+	return
+}
+
+func (s Doc) Where(requiredArg interface{}, args ...interface{}) (scope *DocScope) {
+	return s.Scope().Where(requiredArg, args...)
+}
+func (s DocScope) Where(requiredArg interface{}, in_args ...interface{}) *DocScope {
+	s.where = append(s.where, append([]interface{}{requiredArg}, in_args...))
+	return &s
+}
+func (s DocScope) SetWhere(where [][]interface{}) *DocScope {
+	s.where = where
+	return &s
+}
+func (s DocScope) GetWhere() [][]interface{} {
+	return s.where
+}
+
+// Sets all scope-related parameters to be equal as in passed scope (as an argument)
+func (s DocScope) SetScope(anotherScope reform.Scope) *DocScope {
+	s.where = anotherScope.GetWhere()
+	s.order = anotherScope.GetOrder()
+	s.groupBy = anotherScope.GetGroup()
+	s.limit = anotherScope.GetLimit()
+	s.db = anotherScope.GetDB()
+
+	return &s
+}
+func (s DocScope) ISetScope(anotherScope reform.Scope) reform.Scope {
+	return s.ISetScope(anotherScope)
+}
+
+// Compiles SQL tail for defined db/where/order/limit scope
+// TODO: should be compiled via dialects
+func (s *DocScope) getTail() (tail string, args []interface{}, err error) {
+	whereTailString, whereTailArgs, err := s.getWhereTail()
+
+	if err != nil {
+		return
+	}
+	groupTailString, groupTailArgs, err := s.getGroupTail()
+	if err != nil {
+		return
+	}
+	orderTailString, orderTailArgs, err := s.getOrderTail()
+	if err != nil {
+		return
+	}
+	limitTailString, _, err := s.getLimitTail()
+	if err != nil {
+		return
+	}
+
+	args = append(whereTailArgs, append(groupTailArgs, orderTailArgs...)...)
+
+	if len(whereTailString) > 0 {
+		whereTailString = " WHERE " + whereTailString + " "
+	}
+
+	if len(groupTailString) > 0 {
+		groupTailString = " GROUP BY " + groupTailString + " "
+	}
+
+	if len(orderTailString) > 0 {
+		orderTailString = " ORDER BY " + orderTailString + " "
+	}
+
+	if len(limitTailString) > 0 {
+		limitTailString = " LIMIT " + limitTailString + " "
+	}
+
+	tail = whereTailString + groupTailString + orderTailString + limitTailString
+
+	if len(s.appendTail) > 0 {
+		tail += " " + s.appendTail
+	}
+
+	return
+
+}
+
+// SelectRows is a simple wrapper to get raw "sql.Rows"
+func (s Doc) SelectRows(query string, args ...interface{}) (rows *sql.Rows, err error) {
+	return s.Scope().SelectRows(query, args...)
+}
+func (s *DocScope) SelectRows(query string, queryArgs ...interface{}) (rows *sql.Rows, err error) {
+	tail, args, err := s.getTail()
+	if err != nil {
+		return
+	}
+
+	return s.db.FlexSelectColumnsRows(DocTable, s.tableQuery, query, queryArgs, tail, args...)
+}
+
+func (s *DocScope) callStructMethod(str *Doc, methodName string) error {
+	if method := reflect.ValueOf(str).MethodByName(methodName); method.IsValid() {
+		switch f := method.Interface().(type) {
+		case func():
+			f()
+
+		case func(reform.ReformDBTX):
+			f(s.db)
+
+		case func(*DocScope):
+			f(s)
+
+		case func(interface{}): // For compatibility with other ORMs
+			f(s.db)
+
+		case func() error:
+			return f()
+
+		case func(reform.ReformDBTX) error:
+			return f(s.db)
+
+		case func(*DocScope) error:
+			return f(s)
+
+		case func(interface{}) error: // For compatibility with other ORMS
+			return f(s.db)
+
+		default:
+			panic("Unknown type of method: \"" + methodName + "\"")
+		}
+	}
+	return nil
+}
+
+func (s DocScope) checkDb() {
+	if s.db == nil {
+		panic("s.db == nil")
+	}
+}
+
+// Select is a handy wrapper for SelectRows() and NextRow(): it makes a query and collects the result into a slice
+func (s Doc) Select(args ...interface{}) (result []Doc, err error) { return s.Scope().Select(args...) }
+func (s DocScope) Select(args ...interface{}) (result []Doc, err error) {
+	if s.cacheTTL != nil {
+		var cached interface{}
+		cached, err = s.cached("select", func() (interface{}, error) {
+			s.cacheTTL = nil
+			return s.Select(args...)
+		}, args...)
+		if err != nil {
+			return nil, err
+		}
+		return append([]Doc(nil), cached.([]Doc)...), nil
+	}
+
+	err = s.Each(func(item Doc) error {
+		result = append(result, item)
+		return nil
+	}, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// Each calls f for every record which Select() would return, without collecting them into a slice; it stops on the first error of f
+func (s DocScope) Each(f func(Doc) error, args ...interface{}) (err error) {
+	s.checkDb()
+
+	if len(args) > 0 {
+		s = *s.Where(args[0], args[1:]...)
+	}
+	tail, args, err := s.getTail()
+	if err != nil {
+		return
+	}
+
+	if s.sharded != nil {
+		query := reform.ShardedQuery{
+			View:              DocTable,
+			Tail:              tail,
+			Args:              args,
+			Order:             s.getShardedOrder(),
+			Limit:             s.limit,
+			ForceAnotherTable: s.tableQuery,
+			ForceFields:       s.fieldsFilter,
+		}
+		return s.sharded.Each(query, func(str reform.Struct) error { return f(*str.(*Doc)) })
+	}
+
+	rows, err := s.db.FlexSelectRows(DocTable, s.tableQuery, s.fieldsFilter, tail, args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := Doc{}
+		err = s.db.ScanRow(rows, &item, s.fieldsFilter)
+		if err != nil {
+			return
+		}
+
+		s.callStructMethod(&item, "AfterFind")
+
+		if err = f(item); err != nil {
+			return
+		}
+	}
+
+	return rows.Err()
+}
+
+// Count returns the number of records which Select() would return, ignoring Order() and Limit()
+func (s DocScope) Count(args ...interface{}) (count int, err error) {
+	s.checkDb()
+
+	if s.cacheTTL != nil {
+		var cached interface{}
+		cached, err = s.cached("count", func() (interface{}, error) {
+			s.cacheTTL = nil
+			return s.Count(args...)
+		}, args...)
+		if err != nil {
+			return 0, err
+		}
+		return cached.(int), nil
+	}
+
+	if len(args) > 0 {
+		s = *s.Where(args[0], args[1:]...)
+	}
+	s.order, s.limit = nil, 0
+	tail, args, err := s.getTail()
+	if err != nil {
+		return
+	}
+
+	if s.sharded != nil {
+		return s.sharded.Count(DocTable, tail, args...)
+	}
+	return s.db.Count(DocTable, tail, args...)
+}
+
+// Cache makes Select(), First() and Count() return results cached for ttl (zero means no expiration) in the query cache of DB
+func (s DocScope) Cache(ttl time.Duration) *DocScope {
+	s.cacheTTL = &ttl
+	return &s
+}
+
+// cached returns the result of load for given kind of query, cached if Cache() was used
+func (s DocScope) cached(kind string, load func() (interface{}, error), args ...interface{}) (interface{}, error) {
+	if s.sharded != nil {
+		return load()
+	}
+
+	if len(args) > 0 {
+		s = *s.Where(args[0], args[1:]...)
+	}
+	switch kind {
+	case "count":
+		s.order, s.limit = nil, 0
+	case "first":
+		s.limit = 1
+	}
+	tail, args, err := s.getTail()
+	if err != nil {
+		return nil, err
+	}
+	return s.db.CachedQuery(DocTable, kind, s.tableQuery, s.fieldsFilter, tail, args, *s.cacheTTL, load)
+}
+
+// getShardedOrder returns columns of Order() to merge sorted results of shards
+func (s *DocScope) getShardedOrder() (order []reform.ShardedOrder) {
+	for i := 0; i+1 < len(s.order); i += 2 {
+		column := s.order[i]
+		if idx := strings.LastIndex(column, "."); idx >= 0 {
+			column = column[idx+1:]
+		}
+		order = append(order, reform.ShardedOrder{
+			Column: strings.Trim(column, "\x60\"[] "),
+			Desc:   strings.EqualFold(strings.TrimSpace(s.order[i+1]), "DESC"),
+		})
+	}
+	return
+}
+func (s Doc) SelectI(args ...interface{}) (result interface{}, err error) {
+	return s.Scope().Select(args...)
+}
+func (s DocScope) SelectI(args ...interface{}) (result interface{}, err error) {
+	return s.Select(args...)
+}
+
+// "First" a method to select and return only one record.
+func (s Doc) First(args ...interface{}) (result Doc, err error) { return s.Scope().First(args...) }
+func (s DocScope) First(args ...interface{}) (result Doc, err error) {
+	s.checkDb()
+
+	if s.cacheTTL != nil {
+		var cached interface{}
+		cached, err = s.cached("first", func() (interface{}, error) {
+			s.cacheTTL = nil
+			return s.First(args...)
+		}, args...)
+		if err != nil {
+			return
+		}
+		return cached.(Doc), nil
+	}
+
+	if s.sharded != nil {
+		var found bool
+		err = s.Limit(1).Each(func(item Doc) error {
+			result, found = item, true
+			return nil
+		}, args...)
+		if err == nil && !found {
+			err = reform.ErrNoRows
+		}
+		return
+	}
+
+	// primary key lookups may use record cache (see reform.DB.UseRecordCache)
+	if pk, ok := s.primaryKeyLookup(args); ok {
+		err = s.db.FindByPrimaryKeyTo(&result, pk)
+		return
+	}
+
+	if len(args) > 0 {
+		s = *s.Where(args[0], args[1:]...)
+	}
+	tail, args, err := s.Limit(1).getTail()
+	if err != nil {
+		return
+	}
+
+	err = s.db.FlexSelectOneTo(&result, s.tableQuery, s.fieldsFilter, tail, args...)
+
+	return
+}
+
+// primaryKeyLookup returns the primary key if First(args...) selects the row only by it.
+func (s DocScope) primaryKeyLookup(args []interface{}) (pk int, ok bool) {
+	if len(args) != 1 || len(s.where) > 0 || len(s.groupBy) > 0 || s.tableQuery != nil || len(s.fieldsFilter) > 0 || s.appendTail != "" {
+		return
+	}
+	pk, ok = args[0].(int)
+	return
+}
+func (s Doc) FirstI(args ...interface{}) (result interface{}, err error) {
+	return s.Scope().First(args...)
+}
+func (s DocScope) FirstI(args ...interface{}) (result interface{}, err error) {
+	return s.First(args...)
+}
+
+// Explain returns the execution plan of the query which Select() would run with the same arguments
+func (s Doc) Explain(args ...interface{}) (plan *reform.Plan, err error) {
+	return s.Scope().Explain(args...)
+}
+func (s DocScope) Explain(args ...interface{}) (plan *reform.Plan, err error) {
+	s.checkDb()
+
+	if len(args) > 0 {
+		s = *s.Where(args[0], args[1:]...)
+	}
+	tail, args, err := s.getTail()
+	if err != nil {
+		return
+	}
+
+	return s.db.FlexExplain(DocTable, s.tableQuery, s.fieldsFilter, tail, args...)
+}
+
+// Sets "GROUP BY".
+func (s Doc) Group(args ...interface{}) (scope *DocScope) { return s.Scope().Group(args...) }
+func (s DocScope) Group(argsI ...interface{}) *DocScope {
+	for _, argI := range argsI {
+		s.groupBy = append(s.groupBy, argI.(string))
+	}
+
+	return &s
+}
+func (s DocScope) SetGroup(groupBy []string) *DocScope {
+	s.groupBy = groupBy
+	return &s
+}
+func (s DocScope) GetGroup() []string {
+	return s.groupBy
+}
+
+// Sets a table query. For example SetTableQuery("table1 JOIN table2 USING(key)")
+func (s Doc) SetTableQuery(query string) (scope *DocScope) { return s.Scope().SetTableQuery(query) }
+func (s DocScope) SetTableQuery(query string) *DocScope {
+	if query == "" {
+		s.tableQuery = nil
+	} else {
+		s.tableQuery = &query
+	}
+	return &s
+}
+func (s DocScope) GetTableQuery() string {
+	if s.tableQuery != nil {
+		return *s.tableQuery
+	}
+	return s.db.QualifiedView(s.View())
+}
+
+// Sets which structure fields should be queried while Select()/First(). For example SetFields("StructField1", "StructIdField", "StructCommentsField"). Could be used just to speed up a query.
+// It's not recommended to use this function!
+func (s Doc) SetQueryFieldsByNames(fields ...string) (scope *DocScope) {
+	return s.Scope().SetQueryFieldsByNames(fields...)
+}
+func (s DocScope) SetQueryFieldsByNames(fields ...string) *DocScope {
+	s.fieldsFilter = fields
+	return &s
+}
+func (s DocScope) GetQueryFields() []string {
+	return s.fieldsFilter
+}
+
+// Sets order. Arguments should be passed by pairs column-{ASC,DESC}. For example Order("id", "ASC", "value" "DESC")
+func (s Doc) Order(args ...interface{}) (scope *DocScope) { return s.Scope().Order(args...) }
+func (s DocScope) Order(argsI ...interface{}) *DocScope {
+	switch len(argsI) {
+	case 0:
+	case 1:
+		arg := argsI[0].(string)
+		args0 := strings.Split(arg, ",")
+		var args []string
+		for _, arg0 := range args0 {
+			args = append(args, strings.Split(arg0, ":")...)
+		}
+		s.order = args
+	default:
+		var args []string
+		for _, argI := range argsI {
+			args = append(args, argI.(string))
+		}
+		s.order = args
+	}
+
+	return &s
+}
+func (s DocScope) SetOrder(order []string) *DocScope {
+	s.order = order
+	return &s
+}
+func (s DocScope) GetOrder() []string {
+	return s.order
+}
+
+func (s Doc) SetSQLAppend(appendTail string) (scope *DocScope) {
+	return s.Scope().SetSQLAppend(appendTail)
+}
+func (s DocScope) SetSQLAppend(appendTail string) *DocScope {
+	s.appendTail = appendTail
+	return &s
+}
+
+// Sets limit.
+func (s Doc) Limit(limit int) (scope *DocScope) { return s.Scope().Limit(limit) }
+func (s *DocScope) Limit(limit int) *DocScope {
+	s.limit = limit
+	return s
+}
+
+// Gets limit
+func (s DocScope) GetLimit() int {
+	return s.limit
+}
+
+// "Reload" reloads record using Primary Key
+func (s *DocFilter) Reload(db *reform.DB) error { return (*Doc)(s).Reload(db) }
+func (s *Doc) Reload(db *reform.DB) (err error) {
+	return db.FindByPrimaryKeyTo(s, s.PKValue())
+}
+
+// Create and Insert inserts new record to DB
+func (s *Doc) Create() (err error) { return s.PtrScope().Create() }
+func (s *DocScope) Create() (err error) {
+	return s.Insert()
+}
+func (s *Doc) Insert() (err error) { return s.PtrScope().Insert() }
+func (s *DocScope) Insert() (err error) {
+	s.checkDb()
+	if s.sharded != nil {
+		if s.db, err = s.sharded.ShardOf(s.item); err != nil {
+			return
+		}
+	}
+	err = s.db.Insert(s.item)
+	if err == nil {
+		s.doLog("INSERT")
+	}
+	return err
+}
+
+// Replace "REPLACE INTO" new record to DB
+func (s *Doc) Replace() (err error) { return s.PtrScope().Replace() }
+func (s *DocScope) Replace() (err error) {
+	s.checkDb()
+	if s.sharded != nil {
+		if s.db, err = s.sharded.ShardOf(s.item); err != nil {
+			return
+		}
+	}
+	err = s.db.Replace(s.item)
+	if err == nil {
+		s.doLog("REPLACE")
+	}
+	return err
+}
+
+// Save inserts new record to DB is PK is zero and updates existing record if PK is not zero
+func (s *Doc) Save() (err error) { return s.PtrScope().Save() }
+func (s *DocScope) Save() (err error) {
+	s.checkDb()
+	if s.sharded != nil {
+		if s.db, err = s.sharded.ShardOf(s.item); err != nil {
+			return
+		}
+	}
+	err = s.db.Save(s.item)
+	if err == nil {
+		s.doLog("INSERT")
+	}
+	return err
+}
+
+// Update updates existing record in DB
+func (s Doc) Update() (err error) { return s.Scope().Update() }
+func (s *DocScope) Update() (err error) {
+	s.checkDb()
+	if s.sharded != nil {
+		if s.db, err = s.sharded.ShardOf(s.item); err != nil {
+			return
+		}
+	}
+	err = s.db.Update(s.item)
+	if err == nil {
+		s.doLog("UPDATE")
+	}
+	return err
+}
+
+// Delete deletes existing record in DB
+func (s Doc) Delete() (err error) { return s.Scope().Delete() }
+func (s *DocScope) Delete() (err error) {
+	s.checkDb()
+	if s.sharded != nil {
+		if s.db, err = s.sharded.ShardOf(s.item); err != nil {
+			return
+		}
+	}
+	err = s.db.Delete(s.item)
+	if err == nil {
+		s.doLog("DELETE")
+	}
+	return err
+}
+
+func (s *DocScope) doLog(requestType string) {
+	if !s.loggingEnabled {
+		return
+	}
+
+	var logRow DocLogRow
+	logRow.Doc = *s.item
+	logRow.LogAuthor = s.loggingAuthor
+	logRow.LogAction = requestType
+	logRow.LogDate = time.Now()
+	logRow.LogComment = s.loggingComment
+
+	s.db.Insert(&logRow)
+}
+
+// Enables logging to table "docs_log". This table should has the same schema, except:
+// - Unique/Primary keys should be removed
+// - Should be added next fields: "log_author" (nullable string), "log_date" (timestamp), "log_action" (enum("INSERT", "UPDATE", "DELETE")), "log_comment" (string)
+// Such table can be created with DocTableLogRow.CreateTableIfNotExists().
+func (s *Doc) Log(enableLogging bool, author *string, commentFormat string, commentArgs ...interface{}) (scope *DocScope) {
+	return s.Scope().Log(enableLogging, author, commentFormat, commentArgs...)
+}
+func (s *DocScope) Log(enableLogging bool, author *string, commentFormat string, commentArgs ...interface{}) (scope *DocScope) {
+	s.loggingEnabled = enableLogging
+	s.loggingAuthor = author
+	s.loggingComment = fmt.Sprintf(commentFormat, commentArgs...)
+
+	return s
+}
+
+// Table returns Table object for that record.
+func (s Doc) Table() reform.Table {
+	return DocTable
+}
+
+// PKValue returns a value of primary key for that record.
+// Returned interface{} value is never untyped nil.
+func (s Doc) PKValue() interface{} {
+	return s.ID
+}
+
+// PKPointer returns a pointer to primary key field for that record.
+// Returned interface{} value is never untyped nil.
+func (s *Doc) PKPointer() interface{} {
+	return &s.ID
+}
+
+// HasPK returns true if record has non-zero primary key set, false otherwise.
+func (s Doc) HasPK() bool {
+	return s.ID != DocTable.z[DocTable.s.PKFieldIndex]
+}
+
+// SetPK sets record primary key, converting it to the field type if needed (see reform.AssignPK).
+func (s *DocFilter) SetPK(pk interface{}) { (*Doc)(s).SetPK(pk) }
+func (s *Doc) SetPK(pk interface{}) {
+	reform.AssignPK(&s.ID, pk)
+}
+
+var (
+	// check interfaces
+	_ reform.View   = DocTable
+	_ reform.Struct = (*Doc)(nil)
+	_ reform.Table  = DocTable
+	_ reform.Record = (*Doc)(nil)
+	_ fmt.Stringer  = (*Doc)(nil)
+
+	// querier
+	DocSQL        = Doc{} // Should be read only
+	defaultDB_Doc *reform.DB
+)
+
+func init() {
+	//parse.AssertUpToDate(&DocTable.s, new(Doc)) // Temporary disabled (doesn't work with arbitary types like "type sliceString []string")
+}
@@ -0,0 +1,75 @@
+package scopes_test
+
+import (
+	"database/sql"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xaionaro/reform"
+	"github.com/xaionaro/reform/dialects/postgresql"
+	"github.com/xaionaro/reform/dialects/sqlite3"
+	"github.com/xaionaro/reform/internal/test/scopes"
+)
+
+func TestSelectRowsTenant(t *testing.T) {
+	sqlDB, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	defer sqlDB.Close()
+
+	db := reform.NewDB(sqlDB, sqlite3.Dialect, reform.NewPrintfLogger(t.Logf))
+	_, err = db.Exec(`CREATE TABLE docs (id integer PRIMARY KEY, tenant_id integer NOT NULL, title text NOT NULL)`)
+	require.NoError(t, err)
+	for _, d := range []scopes.Doc{{ID: 1, TenantID: 1, Title: "a"}, {ID: 2, TenantID: 1, Title: "b"}, {ID: 3, TenantID: 2, Title: "c"}} {
+		d := d
+		require.NoError(t, db.WithoutTenant().Insert(&d))
+	}
+
+	count := func(scope *scopes.DocScope) (n, sum int64) {
+		// placeholders in columns precede the tenant subquery and the tail
+		rows, err := scope.Where("title != ?", "b").SelectRows("COUNT(*), SUM(id) + ?", 100)
+		require.NoError(t, err)
+		defer rows.Close()
+		require.True(t, rows.Next())
+		require.NoError(t, rows.Scan(&n, &sum))
+		require.NoError(t, rows.Err())
+		return
+	}
+
+	n, sum := count(scopes.Doc{}.DB(db).ForTenant(int64(1)))
+	assert.Equal(t, int64(1), n)
+	assert.Equal(t, int64(101), sum)
+
+	n, sum = count(scopes.Doc{}.DB(db.WithTenant(int64(2))))
+	assert.Equal(t, int64(1), n)
+	assert.Equal(t, int64(103), sum)
+
+	n, sum = count(scopes.Doc{}.DB(db.WithTenant(int64(1))).WithoutTenant())
+	assert.Equal(t, int64(2), n)
+	assert.Equal(t, int64(104), sum)
+
+	_, err = scopes.Doc{}.DB(db).SelectRows("COUNT(*)")
+	assert.Equal(t, reform.ErrNoTenant, err)
+}
+
+func TestWherePlaceholders(t *testing.T) {
+	var queries []string
+	var args [][]interface{}
+	db := reform.NewDB(nil, postgresql.Dialect, nil)
+	db.Use(reform.InterceptorFuncs{
+		OnQuery: func(query string, a []interface{}, next reform.QueryFunc) (*sql.Rows, error) {
+			queries, args = append(queries, query), append(args, a)
+			return nil, sql.ErrConnDone
+		},
+	})
+
+	// numbered placeholders start from $1 and continue across Where calls
+	_, err := scopes.Doc{}.DB(db).WithoutTenant().Where("title = ? OR title = ?", "a", "b").Where(2).Select()
+	assert.Equal(t, sql.ErrConnDone, err)
+	require.Len(t, queries, 1)
+	assert.Contains(t, queries[0], "WHERE (title = $1 OR title = $2) AND (id  = $3)")
+	assert.Equal(t, []interface{}{"a", "b", 2}, args[0])
+}
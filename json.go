@@ -0,0 +1,125 @@
+package reform
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// JSON wraps a value of field stored as JSON document ("json" in "reform:" tag).
+// It marshals the value on write and unmarshals the column into it on scan,
+// so V should be a pointer for scanning. Generated Values() and Pointers() use it.
+type JSON struct {
+	V interface{}
+}
+
+// Value implements driver.Valuer. Nil pointers are stored as NULL.
+func (j JSON) Value() (driver.Value, error) {
+	if v := reflect.ValueOf(j.V); !v.IsValid() || (v.Kind() == reflect.Ptr && v.IsNil()) {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(j.V)
+	if err != nil {
+		return nil, fmt.Errorf("reform: failed to marshal %T to JSON: %s", j.V, err)
+	}
+	return string(b), nil
+}
+
+// Scan implements sql.Scanner. NULL sets the value to zero.
+func (j JSON) Scan(src interface{}) error {
+	v := reflect.ValueOf(j.V)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("reform: JSON.Scan: expected non-nil pointer, got %T", j.V)
+	}
+
+	var b []byte
+	switch src := src.(type) {
+	case nil:
+		v.Elem().Set(reflect.Zero(v.Elem().Type()))
+		return nil
+	case []byte:
+		b = src
+	case string:
+		b = []byte(src)
+	default:
+		return fmt.Errorf("reform: JSON.Scan: unexpected type %T", src)
+	}
+
+	// don't merge with the previous value of maps and structures
+	v.Elem().Set(reflect.Zero(v.Elem().Type()))
+	if err := json.Unmarshal(b, j.V); err != nil {
+		return fmt.Errorf("reform: failed to unmarshal JSON to %T: %s", j.V, err)
+	}
+	return nil
+}
+
+// isJSONField returns true if "reform:" (or "gorm:") tag of the field has "json" option.
+func isJSONField(tag reflect.StructTag, imitateGorm bool) bool {
+	parts := strings.Split(tag.Get("reform"), ",")[1:]
+	if imitateGorm {
+		parts = strings.Split(tag.Get("gorm"), ";")
+	}
+	for _, part := range parts {
+		if strings.TrimSpace(part) == "json" {
+			return true
+		}
+	}
+	return false
+}
+
+// JSONDialect is implemented by dialects which support querying JSON documents.
+type JSONDialect interface {
+	Dialect
+
+	// JSONPath returns SQL expression extracting a value by given path (object keys and array indexes)
+	// from JSON document expression as text.
+	JSONPath(expr string, path []string) string
+
+	// JSONPlaceholder returns SQL expression for a placeholder with JSON document
+	// comparable with JSON columns.
+	JSONPlaceholder(placeholder string) string
+}
+
+// JSONPathString returns JSON path string like $."a"[0]."b" for given object keys and array indexes.
+// It is used by dialects.
+func JSONPathString(path []string) string {
+	res := "$"
+	for _, p := range path {
+		if isArrayIndex(p) {
+			res += "[" + p + "]"
+			continue
+		}
+		res += `."` + strings.Replace(p, `"`, `\"`, -1) + `"`
+	}
+	return res
+}
+
+// isArrayIndex returns true if path element is an array index.
+func isArrayIndex(p string) bool {
+	if p == "" {
+		return false
+	}
+	for _, c := range p {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// JSONPath returns SQL expression extracting a value by given path (object keys and array indexes)
+// from JSON column as text, for conditions like:
+//
+//	Event.Where(db.JSONPath("payload", "user", "name")+" = ?", "Alice")
+//
+// Dialect should implement JSONDialect.
+func (q *Querier) JSONPath(column string, path ...string) string {
+	dialect, ok := q.Dialect.(JSONDialect)
+	if !ok {
+		panic(fmt.Errorf("reform: dialect %s does not support JSON", q.Dialect))
+	}
+	return dialect.JSONPath(q.QuoteIdentifier(column), path)
+}
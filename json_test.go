@@ -0,0 +1,66 @@
+package reform_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xaionaro/reform"
+	"github.com/xaionaro/reform/dialects/mssql"
+	"github.com/xaionaro/reform/dialects/mysql"
+	"github.com/xaionaro/reform/dialects/postgresql"
+	"github.com/xaionaro/reform/dialects/sqlite3"
+)
+
+type jsonPayload struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+}
+
+func TestJSON(t *testing.T) {
+	v, err := reform.JSON{V: jsonPayload{Name: "a", Tags: []string{"b"}}}.Value()
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"a","tags":["b"]}`, v)
+
+	v, err = reform.JSON{V: (*jsonPayload)(nil)}.Value()
+	require.NoError(t, err)
+	assert.Nil(t, v)
+
+	payload := &jsonPayload{Name: "old", Tags: []string{"old"}}
+	require.NoError(t, reform.JSON{V: &payload}.Scan([]byte(`{"name":"a"}`)))
+	assert.Equal(t, &jsonPayload{Name: "a"}, payload)
+	require.NoError(t, reform.JSON{V: &payload}.Scan(nil))
+	assert.Nil(t, payload)
+
+	m := map[string]int{"old": 1}
+	require.NoError(t, reform.JSON{V: &m}.Scan(`{"x":1}`))
+	assert.Equal(t, map[string]int{"x": 1}, m)
+
+	assert.Error(t, reform.JSON{V: m}.Scan(`{}`))
+	assert.Error(t, reform.JSON{V: &m}.Scan(`[]`))
+}
+
+func TestJSONPath(t *testing.T) {
+	path := []string{"user", "it's", "0"}
+	assert.Equal(t, `$."user"."it's"[0]`, reform.JSONPathString(path))
+	assert.Equal(t, `json_extract("payload", '$."user"."it''s"[0]')`, sqlite3.Dialect.JSONPath(`"payload"`, path))
+	assert.Equal(t, `("payload" #>> '{"user","it''s","0"}')`, postgresql.Dialect.JSONPath(`"payload"`, path))
+	assert.Equal(t, "JSON_UNQUOTE(JSON_EXTRACT(`payload`, '$.\"user\".\"it''s\"[0]'))", mysql.Dialect.JSONPath("`payload`", path))
+	assert.Equal(t, `JSON_VALUE([payload], N'$."user"."it''s"[0]')`, mssql.Dialect.JSONPath("[payload]", path))
+}
+
+func TestValueForSQL(t *testing.T) {
+	q := reform.NewDB(nil, sqlite3.Dialect, nil)
+	now := time.Now()
+	assert.Equal(t, []interface{}{now}, q.ValueForSQL(now))
+	assert.Equal(t, []interface{}{[]byte("a")}, q.ValueForSQL([]byte("a")))
+	assert.Equal(t, []interface{}{true}, q.ValueForSQL(true))
+	assert.Equal(t, []interface{}{uint32(1), uint32(2)}, q.ValueForSQL([]uint32{1, 2}))
+	type rawMessage []byte // like json.RawMessage
+	assert.Equal(t, []interface{}{rawMessage(`{}`)}, q.ValueForSQL(rawMessage(`{}`)))
+	assert.Equal(t, []interface{}{[2]byte{1, 2}}, q.ValueForSQL([2]byte{1, 2}))
+	assert.Equal(t, []interface{}{map[string]int{"a": 1}}, q.ValueForSQL(map[string]int{"a": 1}))
+	assert.Equal(t, []interface{}{struct{ A int }{1}}, q.ValueForSQL(struct{ A int }{1}))
+}
@@ -0,0 +1,119 @@
+package reform
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Loader batches concurrent primary key lookups of one table: keys requested by Load within a short
+// window are de-duplicated and loaded by a single FindAllFrom query. It is created with NewLoader
+// and is intended to be short-lived (e.g. per request), as records are not cached between batches.
+type Loader struct {
+	q        *Querier
+	table    Table
+	wait     time.Duration
+	maxBatch int
+
+	m     sync.Mutex
+	batch *loaderBatch
+}
+
+// loaderBatch is a set of keys loaded by one query.
+type loaderBatch struct {
+	keys    []interface{}
+	index   map[string]struct{}
+	timer   *time.Timer
+	done    chan struct{}
+	records map[string]Record
+	err     error
+}
+
+// NewLoader returns a new Loader of table which collects keys for wait duration after the first one,
+// or until there are maxBatch keys. Zero maxBatch means the maximum number of placeholders
+// in one query (see BulkDialect), larger values are limited by it too. Queries are made with this Querier,
+// so they use its context, tenant and other settings.
+func (q *Querier) NewLoader(table Table, wait time.Duration, maxBatch int) *Loader {
+	limit := 999
+	if dialect, ok := q.Dialect.(BulkDialect); ok {
+		if placeholders, _ := dialect.BulkInsertLimits(); placeholders > 0 {
+			limit = placeholders
+		}
+	}
+	limit-- // for tenant filter
+	if maxBatch <= 0 || maxBatch > limit {
+		maxBatch = limit
+	}
+
+	return &Loader{
+		q:        q,
+		table:    table,
+		wait:     wait,
+		maxBatch: maxBatch,
+	}
+}
+
+// Load returns a record of the table with given primary key, loaded together with keys of other
+// concurrent calls. Every caller gets its own copy of the record.
+// If there is no such record, it returns nil, ErrNoRows. It also may return FindAllFrom errors
+// shared by all keys of the batch.
+func (l *Loader) Load(pk interface{}) (Record, error) {
+	key := fmt.Sprint(pk)
+
+	l.m.Lock()
+	b := l.batch
+	if b == nil {
+		b = &loaderBatch{
+			index: make(map[string]struct{}),
+			done:  make(chan struct{}),
+		}
+		l.batch = b
+		b.timer = time.AfterFunc(l.wait, func() { l.dispatch(b) })
+	}
+	if _, ok := b.index[key]; !ok {
+		b.index[key] = struct{}{}
+		b.keys = append(b.keys, pk)
+	}
+	full := len(b.keys) >= l.maxBatch
+	l.m.Unlock()
+
+	if full {
+		l.dispatch(b)
+	}
+
+	<-b.done
+	if b.err != nil {
+		return nil, b.err
+	}
+	record := b.records[key]
+	if record == nil {
+		return nil, ErrNoRows
+	}
+	return copyStruct(record).(Record), nil
+}
+
+// dispatch loads the batch unless it is already being loaded.
+func (l *Loader) dispatch(b *loaderBatch) {
+	l.m.Lock()
+	if l.batch != b {
+		l.m.Unlock()
+		return
+	}
+	l.batch = nil
+	l.m.Unlock()
+
+	// batch may be full before the timer fires
+	b.timer.Stop()
+
+	defer close(b.done)
+	structs, err := l.q.FindAllFrom(l.table, l.table.Columns()[l.table.PKColumnIndex()], b.keys...)
+	if err != nil {
+		b.err = err
+		return
+	}
+	b.records = make(map[string]Record, len(structs))
+	for _, str := range structs {
+		record := str.(Record)
+		b.records[fmt.Sprint(record.PKValue())] = record
+	}
+}
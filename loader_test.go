@@ -0,0 +1,101 @@
+package reform_test
+
+import (
+	"database/sql"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xaionaro/reform"
+)
+
+func TestLoader(t *testing.T) {
+	var m sync.Mutex
+	var queries int
+	var args [][]interface{}
+	db := newSQLite3DB(t).WithTenant(int64(1))
+	db.Use(reform.InterceptorFuncs{
+		OnQuery: func(query string, a []interface{}, next reform.QueryFunc) (*sql.Rows, error) {
+			m.Lock()
+			queries++
+			args = append(args, a)
+			m.Unlock()
+			return next(query, a)
+		},
+	})
+	_, err := db.Exec(`CREATE TABLE docs (id integer PRIMARY KEY, tenant_id integer NOT NULL, title text NOT NULL)`)
+	require.NoError(t, err)
+	for i := 1; i <= 5; i++ {
+		require.NoError(t, db.Insert(&tenantDoc{ID: int64(i), Title: fmt.Sprint("doc ", i)}))
+	}
+
+	// batch of 5 unique keys is loaded when the last of them joins it, long before the wait ends
+	loader := db.NewLoader(tenantDocs, time.Hour, 5)
+	pks := []int64{1, 2, 3, 5, 42}
+	records := make([]reform.Record, len(pks))
+	errs := make([]error, len(pks))
+	var wg sync.WaitGroup
+	for i, pk := range pks {
+		wg.Add(1)
+		go func(i int, pk int64) {
+			defer wg.Done()
+			records[i], errs[i] = loader.Load(pk)
+		}(i, pk)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, queries)
+	for i, pk := range pks {
+		if pk == 42 {
+			assert.Equal(t, reform.ErrNoRows, errs[i])
+			assert.Nil(t, records[i])
+			continue
+		}
+		require.NoError(t, errs[i])
+		assert.Equal(t, fmt.Sprint("doc ", pk), records[i].(*tenantDoc).Title)
+	}
+
+	// duplicate keys are loaded once per batch, callers get their own copies
+	loader = db.NewLoader(tenantDocs, 10*time.Millisecond, 0)
+	pks = []int64{2, 2, 2, 3}
+	records = make([]reform.Record, len(pks))
+	errs = make([]error, len(pks))
+	for i, pk := range pks {
+		wg.Add(1)
+		go func(i int, pk int64) {
+			defer wg.Done()
+			records[i], errs[i] = loader.Load(pk)
+		}(i, pk)
+	}
+	wg.Wait()
+
+	for i, pk := range pks {
+		require.NoError(t, errs[i])
+		assert.Equal(t, fmt.Sprint("doc ", pk), records[i].(*tenantDoc).Title)
+	}
+	assert.False(t, records[0] == records[1], "callers should get their own copies")
+	for _, a := range args[1:] {
+		assert.Len(t, a, len(unique(a)), "%v", a)
+	}
+
+	// batch is loaded as soon as it is full
+	queries = 0
+	loader = db.NewLoader(tenantDocs, time.Hour, 1)
+	record, err := loader.Load(4)
+	require.NoError(t, err)
+	assert.Equal(t, "doc 4", record.(*tenantDoc).Title)
+	assert.Equal(t, 1, queries)
+}
+
+// unique returns a set of given values.
+func unique(values []interface{}) map[interface{}]struct{} {
+	res := make(map[interface{}]struct{}, len(values))
+	for _, v := range values {
+		res[v] = struct{}{}
+	}
+	return res
+}
@@ -0,0 +1,157 @@
+// Package migrate implements versioned schema migrations on top of reform.DB.
+//
+// Migrations are pairs of SQL files in one directory:
+//
+//	0001_create_people.up.sql
+//	0001_create_people.down.sql
+//	0002_add_email.up.sql
+//	...
+//
+// Number before the first underscore is a version, the rest is a name. Both files are optional;
+// missing or empty file is not executed, only bookkeeping table is updated. Each file's content
+// is executed as a single query, so if it contains multiple statements, make sure SQL driver supports them.
+//
+// Applied migrations are recorded in a bookkeeping table together with a checksum of up and down files' content,
+// so edited migrations are detected. A lock table prevents concurrent runners.
+//
+// The same engine is used by "reform-db migrate" command.
+package migrate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Migration represents a single versioned migration.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Checksum returns hex-encoded SHA-256 of migration's up and down SQL.
+func (m Migration) Checksum() string {
+	sum := sha256.Sum256([]byte(m.Up + "\x00" + m.Down))
+	return hex.EncodeToString(sum[:])
+}
+
+// String returns migration's version and name, for example "0002_add_email".
+func (m Migration) String() string {
+	return fmt.Sprintf("%04d_%s", m.Version, m.Name)
+}
+
+var fileRE = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// Load reads migrations from directory dir in fsys (for example, embed.FS).
+// Files not matching <version>_<name>.(up|down).sql pattern are ignored.
+// Returned migrations are sorted by version.
+func Load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		m := fileRE.FindStringSubmatch(e.Name())
+		if m == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("reform: invalid migration version in %s: %s", e.Name(), err)
+		}
+		b, err := fs.ReadFile(fsys, path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration := byVersion[version]
+		if migration == nil {
+			migration = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != m[2] {
+			return nil, fmt.Errorf("reform: migration %d has different names: %q and %q", version, migration.Name, m[2])
+		}
+
+		sql := strings.TrimSpace(string(b))
+		if m[3] == "up" {
+			migration.Up = sql
+		} else {
+			migration.Down = sql
+		}
+	}
+
+	res := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		res = append(res, *m)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Version < res[j].Version })
+	return res, nil
+}
+
+// LoadDir reads migrations from given directory. See Load.
+func LoadDir(dir string) ([]Migration, error) {
+	return Load(os.DirFS(dir), ".")
+}
+
+// Create creates empty up and down files for a new migration in given directory.
+// Version is the next number after the last existing migration. Created file names are returned.
+func Create(dir, name string) (up, down string, err error) {
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, strings.TrimSpace(name))
+	if name == "" {
+		return "", "", fmt.Errorf("reform: migration name is empty")
+	}
+
+	migrations, err := LoadDir(dir)
+	if err != nil {
+		return "", "", err
+	}
+	var version int64 = 1
+	if len(migrations) > 0 {
+		version = migrations[len(migrations)-1].Version + 1
+	}
+
+	m := Migration{Version: version, Name: name}
+	up = filepath.Join(dir, m.String()+".up.sql")
+	down = filepath.Join(dir, m.String()+".down.sql")
+	for _, f := range []string{up, down} {
+		if err = ioutil.WriteFile(f, nil, 0666); err != nil {
+			return "", "", err
+		}
+	}
+	return up, down, nil
+}
+
+// sortStatuses sorts statuses by version.
+func sortStatuses(statuses []Status) {
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+}
+
+// commaJoin joins strings with ", ".
+func commaJoin(s []string) string {
+	return strings.Join(s, ", ")
+}
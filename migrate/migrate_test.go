@@ -0,0 +1,155 @@
+package migrate
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xaionaro/reform"
+	"github.com/xaionaro/reform/dialects/sqlite3"
+)
+
+var testFS = fstest.MapFS{
+	"migrations/0001_create_people.up.sql":   {Data: []byte("CREATE TABLE people (id INTEGER PRIMARY KEY, name TEXT NOT NULL)\n")},
+	"migrations/0001_create_people.down.sql": {Data: []byte("DROP TABLE people")},
+	"migrations/0002_add_email.up.sql":       {Data: []byte("ALTER TABLE people ADD COLUMN email TEXT")},
+	"migrations/0002_add_email.down.sql":     {Data: []byte("ALTER TABLE people DROP COLUMN email")},
+	"migrations/0003_broken.up.sql":          {Data: []byte("INSERT INTO people (name) VALUES ('a'); SELECT no_such_column FROM people")},
+	"migrations/README.md":                   {Data: []byte("ignored")},
+}
+
+func setupDB(t *testing.T) *reform.DB {
+	sqlDB, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+	return reform.NewDB(sqlDB, sqlite3.Dialect, reform.NewPrintfLogger(t.Logf))
+}
+
+func versions(migrations []Migration) []int64 {
+	res := make([]int64, len(migrations))
+	for i, m := range migrations {
+		res[i] = m.Version
+	}
+	return res
+}
+
+func TestLoad(t *testing.T) {
+	migrations, err := Load(testFS, "migrations")
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 2, 3}, versions(migrations))
+	assert.Equal(t, "create_people", migrations[0].Name)
+	assert.Equal(t, "CREATE TABLE people (id INTEGER PRIMARY KEY, name TEXT NOT NULL)", migrations[0].Up)
+	assert.Equal(t, "DROP TABLE people", migrations[0].Down)
+	assert.Equal(t, "", migrations[2].Down)
+	assert.Equal(t, "0003_broken", migrations[2].String())
+}
+
+func TestMigrator(t *testing.T) {
+	db := setupDB(t)
+	migrations, err := Load(testFS, "migrations")
+	require.NoError(t, err)
+	m := New(db, migrations[:2])
+
+	applied, err := m.Up(0)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, versions(applied))
+	_, err = db.Exec("INSERT INTO people (name, email) VALUES ('a', 'b')")
+	require.NoError(t, err)
+
+	applied, err = m.Up(0)
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+
+	redone, err := m.Redo()
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), redone.Version)
+
+	rolledBack, err := m.Down(0)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2}, versions(rolledBack))
+
+	statuses, err := m.Status()
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.True(t, statuses[0].Applied)
+	assert.False(t, statuses[0].AppliedAt.IsZero())
+	assert.False(t, statuses[1].Applied)
+
+	// failed migration is rolled back together with its bookkeeping
+	m = New(db, migrations)
+	applied, err = m.Up(0)
+	assert.Error(t, err)
+	assert.Equal(t, []int64{2}, versions(applied))
+	var count int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM people").Scan(&count))
+	assert.Equal(t, 1, count)
+	statuses, err = m.Status()
+	require.NoError(t, err)
+	assert.False(t, statuses[2].Applied)
+}
+
+func TestMigratorChecksum(t *testing.T) {
+	db := setupDB(t)
+	migrations, err := Load(testFS, "migrations")
+	require.NoError(t, err)
+	_, err = New(db, migrations[:1]).Up(0)
+	require.NoError(t, err)
+
+	migrations[0].Up += "\n-- edited"
+	m := New(db, migrations[:2])
+	statuses, err := m.Status()
+	require.NoError(t, err)
+	assert.True(t, statuses[0].Modified)
+
+	_, err = m.Up(0)
+	assert.EqualError(t, err, "reform: migration 0001_create_people was modified after it was applied")
+
+	// down SQL is checked too, as it is used to roll back applied migration
+	edited, err := Load(testFS, "migrations")
+	require.NoError(t, err)
+	edited[0].Down = "DROP TABLE IF EXISTS people"
+	statuses, err = New(db, edited[:1]).Status()
+	require.NoError(t, err)
+	assert.True(t, statuses[0].Modified)
+
+	statuses, err = New(db, nil).Status()
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	assert.True(t, statuses[0].Missing)
+}
+
+func TestMigratorLock(t *testing.T) {
+	db := setupDB(t)
+	m := New(db, nil)
+	require.NoError(t, m.createTables())
+	require.NoError(t, m.lock())
+
+	_, err := m.Up(0)
+	assert.Equal(t, ErrLocked, err)
+
+	require.NoError(t, m.ForceUnlock())
+	_, err = m.Up(0)
+	assert.NoError(t, err)
+}
+
+func TestCreate(t *testing.T) {
+	dir := t.TempDir()
+	up, down, err := Create(dir, "create people")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "0001_create_people.up.sql"), up)
+	assert.Equal(t, filepath.Join(dir, "0001_create_people.down.sql"), down)
+
+	up, _, err = Create(dir, "add_email")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "0002_add_email.up.sql"), up)
+
+	migrations, err := LoadDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, versions(migrations))
+}
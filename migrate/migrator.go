@@ -0,0 +1,296 @@
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/xaionaro/reform"
+)
+
+// DefaultTable is a default name of migrations bookkeeping table.
+// Lock table has the same name with "_lock" suffix.
+const DefaultTable = "schema_migrations"
+
+// ErrLocked is returned when other runner holds migrations lock.
+var ErrLocked = errors.New("reform: migrations are locked by other runner")
+
+// Status describes a state of single migration.
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+
+	// Modified is true if migration was edited after it was applied.
+	Modified bool
+
+	// Missing is true if migration is applied, but it's files are not found.
+	Missing bool
+}
+
+// Migrator applies and rolls back migrations.
+type Migrator struct {
+	db         *reform.DB
+	migrations []Migration
+
+	// Table is a name of bookkeeping table, DefaultTable by default.
+	Table string
+}
+
+// New creates a new Migrator for given DB and migrations (typically returned by Load or LoadDir).
+func New(db *reform.DB, migrations []Migration) *Migrator {
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+		Table:      DefaultTable,
+	}
+}
+
+// Up applies up to n pending migrations in version order, or all of them if n <= 0.
+// Applied migrations are returned.
+func (m *Migrator) Up(n int) (applied []Migration, err error) {
+	err = m.locked(func(statuses []Status) error {
+		for _, s := range statuses {
+			if s.Applied {
+				continue
+			}
+			if n > 0 && len(applied) == n {
+				break
+			}
+			if err := m.apply(s.Migration, true); err != nil {
+				return err
+			}
+			applied = append(applied, s.Migration)
+		}
+		return nil
+	})
+	return
+}
+
+// Down rolls back up to n last applied migrations in reverse version order (n <= 0 means 1).
+// Rolled back migrations are returned.
+func (m *Migrator) Down(n int) (rolledBack []Migration, err error) {
+	if n <= 0 {
+		n = 1
+	}
+	err = m.locked(func(statuses []Status) error {
+		for i := len(statuses) - 1; i >= 0 && len(rolledBack) < n; i-- {
+			s := statuses[i]
+			if !s.Applied {
+				continue
+			}
+			if err := m.apply(s.Migration, false); err != nil {
+				return err
+			}
+			rolledBack = append(rolledBack, s.Migration)
+		}
+		return nil
+	})
+	return
+}
+
+// Redo rolls back the last applied migration and applies it again.
+// Redone migration is returned, or nil if there are no applied migrations.
+func (m *Migrator) Redo() (redone *Migration, err error) {
+	err = m.locked(func(statuses []Status) error {
+		for i := len(statuses) - 1; i >= 0; i-- {
+			s := statuses[i]
+			if !s.Applied {
+				continue
+			}
+			if err := m.apply(s.Migration, false); err != nil {
+				return err
+			}
+			if err := m.apply(s.Migration, true); err != nil {
+				return err
+			}
+			redone = &s.Migration
+			return nil
+		}
+		return nil
+	})
+	return
+}
+
+// Status returns statuses of all known and applied migrations sorted by version.
+func (m *Migrator) Status() ([]Status, error) {
+	if err := m.createTables(); err != nil {
+		return nil, err
+	}
+	return m.statuses()
+}
+
+// ForceUnlock removes migrations lock. It should be used only if runner holding lock crashed.
+func (m *Migrator) ForceUnlock() error {
+	if err := m.createTables(); err != nil {
+		return err
+	}
+	return m.unlock()
+}
+
+// locked calls f with migrations statuses while holding lock.
+// Error is returned without calling f if some applied migrations were modified or their files are missing.
+func (m *Migrator) locked(f func([]Status) error) (err error) {
+	if err = m.createTables(); err != nil {
+		return
+	}
+	if err = m.lock(); err != nil {
+		return
+	}
+	defer func() {
+		if e := m.unlock(); err == nil {
+			err = e
+		}
+	}()
+
+	statuses, err := m.statuses()
+	if err != nil {
+		return
+	}
+	for _, s := range statuses {
+		switch {
+		case s.Modified:
+			return fmt.Errorf("reform: migration %s was modified after it was applied", s.Migration)
+		case s.Missing:
+			return fmt.Errorf("reform: migration %s is applied, but it's files are not found", s.Migration)
+		}
+	}
+	return f(statuses)
+}
+
+// transactional returns true if dialect supports transactional DDL.
+func (m *Migrator) transactional() bool {
+	d, ok := m.db.Dialect.(reform.DDLTransactionDialect)
+	return !ok || d.TransactionalDDL()
+}
+
+// apply runs migration's up or down SQL and updates bookkeeping table,
+// in a single transaction if dialect allows it.
+func (m *Migrator) apply(migration Migration, up bool) error {
+	run := func(q *reform.Querier) error {
+		sql := migration.Down
+		if up {
+			sql = migration.Up
+		}
+		if sql != "" {
+			if _, err := q.Exec(sql); err != nil {
+				return fmt.Errorf("reform: migration %s failed: %s", migration, err)
+			}
+		}
+
+		var err error
+		if up {
+			query := fmt.Sprintf("INSERT INTO %s (version, name, checksum, applied_at) VALUES (%s)",
+				q.QuoteIdentifier(m.Table), commaJoin(q.Placeholders(1, 4)))
+			_, err = q.Exec(query, migration.Version, migration.Name, migration.Checksum(), time.Now().UTC())
+		} else {
+			query := fmt.Sprintf("DELETE FROM %s WHERE version = %s", q.QuoteIdentifier(m.Table), q.Placeholder(1))
+			_, err = q.Exec(query, migration.Version)
+		}
+		return err
+	}
+
+	if !m.transactional() {
+		return run(m.db.Querier)
+	}
+	return m.db.InTransaction(func(tx *reform.TX) error {
+		return run(tx.Querier)
+	})
+}
+
+// statuses merges known migrations with rows from bookkeeping table.
+func (m *Migrator) statuses() ([]Status, error) {
+	query := fmt.Sprintf("SELECT version, name, checksum, applied_at FROM %s ORDER BY version", m.db.QuoteIdentifier(m.Table))
+	rows, err := m.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]Status)
+	checksums := make(map[int64]string)
+	for rows.Next() {
+		var s Status
+		var checksum string
+		if err = rows.Scan(&s.Version, &s.Name, &checksum, &s.AppliedAt); err != nil {
+			return nil, err
+		}
+		s.Applied = true
+		s.Missing = true
+		applied[s.Version] = s
+		checksums[s.Version] = checksum
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	res := make([]Status, 0, len(m.migrations)+len(applied))
+	for _, migration := range m.migrations {
+		s, ok := applied[migration.Version]
+		if ok {
+			delete(applied, migration.Version)
+			s.Missing = false
+			s.Modified = checksums[migration.Version] != migration.Checksum()
+		}
+		s.Migration = migration
+		res = append(res, s)
+	}
+	for _, s := range applied {
+		res = append(res, s)
+	}
+	sortStatuses(res)
+	return res, nil
+}
+
+// lock acquires migrations lock by inserting a row into lock table.
+func (m *Migrator) lock() error {
+	table := m.db.QuoteIdentifier(m.Table + "_lock")
+	query := fmt.Sprintf("INSERT INTO %s (id, locked_at) VALUES (%s)", table, commaJoin(m.db.Placeholders(1, 2)))
+	if _, err := m.db.Exec(query, 1, time.Now().UTC()); err != nil {
+		var count int
+		if e := m.db.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM %s", table)).Scan(&count); e == nil && count > 0 {
+			return ErrLocked
+		}
+		return err
+	}
+	return nil
+}
+
+// unlock releases migrations lock.
+func (m *Migrator) unlock() error {
+	_, err := m.db.Exec(fmt.Sprintf("DELETE FROM %s WHERE id = 1", m.db.QuoteIdentifier(m.Table+"_lock")))
+	return err
+}
+
+// createTables creates bookkeeping and lock tables if they do not exist.
+func (m *Migrator) createTables() error {
+	timestamp := "DATETIME"
+	if d, ok := m.db.Dialect.(reform.ColumnTypeDialect); ok {
+		timestamp = d.ColumnTypeForField(reform.FieldInfo{Type: "time.Time"})
+	}
+
+	tables := map[string]string{
+		m.Table:           "version BIGINT NOT NULL PRIMARY KEY, name VARCHAR(255) NOT NULL, checksum VARCHAR(64) NOT NULL, applied_at " + timestamp + " NOT NULL",
+		m.Table + "_lock": "id INTEGER NOT NULL PRIMARY KEY, locked_at " + timestamp + " NOT NULL",
+	}
+	for _, table := range []string{m.Table, m.Table + "_lock"} {
+		query := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s)", m.db.QuoteIdentifier(table), tables[table])
+
+		// not all databases support IF NOT EXISTS, so existing table is looked up first when possible
+		if d, ok := m.db.Dialect.(reform.SchemaDialect); ok {
+			existing, err := d.InspectTable(m.db, "", table)
+			if err != nil {
+				return err
+			}
+			if existing != nil {
+				continue
+			}
+			query = fmt.Sprintf("CREATE TABLE %s (%s)", m.db.QuoteIdentifier(table), tables[table])
+		}
+
+		if _, err := m.db.Exec(query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
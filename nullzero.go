@@ -0,0 +1,240 @@
+package reform
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// NullZeroMode defines how NULL values are handled for fields of non-pointer types.
+type NullZeroMode int
+
+const (
+	// NullZeroOff means NULL can't be scanned into fields of non-pointer types, that is an error.
+	NullZeroOff NullZeroMode = iota
+
+	// NullZeroScan means NULL is scanned as zero value ("nullzero" in "reform:" tag).
+	NullZeroScan
+
+	// NullZeroScanWrite means NULL is scanned as zero value, and zero value is written as NULL
+	// ("nullzero:write" in "reform:" tag).
+	NullZeroScanWrite
+)
+
+// ScansNullAsZero returns true if NULL is scanned as zero value in this mode.
+func (m NullZeroMode) ScansNullAsZero() bool {
+	return m >= NullZeroScan
+}
+
+// WritesZeroAsNull returns true if zero value is written as NULL in this mode.
+func (m NullZeroMode) WritesZeroAsNull() bool {
+	return m >= NullZeroScanWrite
+}
+
+// nullZeroOption returns the mode from "nullzero" option of "reform:" (or "gorm:") tag of the field.
+func nullZeroOption(tag reflect.StructTag, imitateGorm bool) (NullZeroMode, error) {
+	parts := strings.Split(tag.Get("reform"), ",")[1:]
+	if imitateGorm {
+		parts = strings.Split(tag.Get("gorm"), ";")
+	}
+	for _, part := range parts {
+		switch strings.TrimSpace(part) {
+		case "nullzero":
+			return NullZeroScan, nil
+		case "nullzero:write":
+			return NullZeroScanWrite, nil
+		default:
+			if strings.HasPrefix(strings.TrimSpace(part), "nullzero:") {
+				return NullZeroOff, fmt.Errorf("invalid nullzero option %q, expected nullzero or nullzero:write", part)
+			}
+		}
+	}
+	return NullZeroOff, nil
+}
+
+// NullZero wraps a field of non-pointer type for NULL-to-zero conversion (see NullZeroMode).
+// Scan stores zero value for NULL, so V should be a pointer for scanning; Value returns NULL for zero value.
+// Generated Values() and Pointers() use it for fields with "nullzero" option.
+type NullZero struct {
+	V interface{}
+}
+
+// Value implements driver.Valuer.
+func (n NullZero) Value() (driver.Value, error) {
+	v := reflect.ValueOf(n.V)
+	if !v.IsValid() || v.IsZero() {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(n.V)
+}
+
+// Scan implements sql.Scanner. Values other than NULL are converted like database/sql does.
+func (n NullZero) Scan(src interface{}) error {
+	v := reflect.ValueOf(n.V)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("reform: NullZero.Scan: expected non-nil pointer, got %T", n.V)
+	}
+	e := v.Elem()
+	if src == nil {
+		e.Set(reflect.Zero(e.Type()))
+		return nil
+	}
+	if scanner, ok := n.V.(sql.Scanner); ok {
+		return scanner.Scan(src)
+	}
+
+	var err error
+	switch e.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i sql.NullInt64
+		if err = i.Scan(src); err == nil {
+			if e.OverflowInt(i.Int64) {
+				return fmt.Errorf("converting driver.Value type %T (%v) to a %s: value out of range", src, src, e.Kind())
+			}
+			e.SetInt(i.Int64)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var i sql.NullInt64
+		if err = i.Scan(src); err == nil {
+			if i.Int64 < 0 || e.OverflowUint(uint64(i.Int64)) {
+				return fmt.Errorf("converting driver.Value type %T (%v) to a %s: value out of range", src, src, e.Kind())
+			}
+			e.SetUint(uint64(i.Int64))
+		}
+	case reflect.Float32, reflect.Float64:
+		var f sql.NullFloat64
+		if err = f.Scan(src); err == nil {
+			e.SetFloat(f.Float64)
+		}
+	case reflect.Bool:
+		var b sql.NullBool
+		if err = b.Scan(src); err == nil {
+			e.SetBool(b.Bool)
+		}
+	case reflect.String:
+		var s sql.NullString
+		if err = s.Scan(src); err == nil {
+			e.SetString(s.String)
+		}
+	default:
+		switch {
+		case e.Kind() == reflect.Slice && e.Type().Elem().Kind() == reflect.Uint8:
+			var s sql.NullString
+			if b, ok := src.([]byte); ok {
+				e.SetBytes(append([]byte(nil), b...))
+			} else if err = s.Scan(src); err == nil {
+				e.SetBytes([]byte(s.String))
+			}
+		case reflect.TypeOf(time.Time{}).ConvertibleTo(e.Type()):
+			var t sql.NullTime
+			if err = t.Scan(src); err == nil {
+				e.Set(reflect.ValueOf(t.Time).Convert(e.Type()))
+			}
+		default:
+			err = fmt.Errorf("unsupported Scan, storing driver.Value type %T into type %s", src, e.Type())
+		}
+	}
+	return err
+}
+
+// scanErrorRE matches database/sql errors like `sql: Scan error on column index 1, name "age": ...`.
+var scanErrorRE = regexp.MustCompile(`(?s)^sql: Scan error on column index (\d+), name "([^"]*)": (.*)$`)
+
+// ScanError is returned when the column value can't be stored in the field,
+// like NULL in the field of non-pointer type without "nullzero" option.
+type ScanError struct {
+	Table  string // table or view name
+	Column string // column name
+	Err    error  // database/sql error
+}
+
+// Error returns error message naming the column.
+func (e *ScanError) Error() string {
+	msg := e.Err.Error()
+	if m := scanErrorRE.FindStringSubmatch(msg); m != nil {
+		msg = m[3]
+	}
+	if strings.Contains(msg, "converting NULL") {
+		msg += ` (use pointer type or "nullzero" option)`
+	}
+	return fmt.Sprintf("reform: failed to scan column %s.%s: %s", e.Table, e.Column, msg)
+}
+
+// Unwrap returns database/sql error.
+func (e *ScanError) Unwrap() error {
+	return e.Err
+}
+
+// scanError returns *ScanError for database/sql scan error, or err itself for other errors.
+func scanError(view View, err error) error {
+	m := scanErrorRE.FindStringSubmatch(err.Error())
+	if m == nil {
+		return err
+	}
+	return &ScanError{Table: view.Name(), Column: m[2], Err: err}
+}
+
+// WithNullZero returns a copy of Querier with given NULL-to-zero mode applied to all fields,
+// in addition to fields with "nullzero" option. Returned Querier is tied to the same DB or TX.
+func (q *Querier) WithNullZero(mode NullZeroMode) *Querier {
+	newQ := q.clone()
+	newQ.nullZero = mode
+	return newQ
+}
+
+// WithNullZero returns a copy of DB with given NULL-to-zero mode. See Querier.WithNullZero.
+func (db *DB) WithNullZero(mode NullZeroMode) *DB {
+	return db.withQuerier(db.Querier.WithNullZero(mode))
+}
+
+// WithNullZero returns a copy of TX with given NULL-to-zero mode. See Querier.WithNullZero.
+func (tx *TX) WithNullZero(mode NullZeroMode) *TX {
+	return &TX{Querier: tx.Querier.WithNullZero(mode), tx: tx.tx}
+}
+
+// scanPointers wraps pointers to fields of non-pointer types with NullZero if Querier's mode requires it,
+// and sets Querier's Cipher for encrypted fields.
+func (q *Querier) scanPointers(pointers []interface{}) []interface{} {
+	res := make([]interface{}, len(pointers))
+	for i, p := range pointers {
+		if e, ok := p.(Encrypted); ok {
+			e.cipher = q.cipher
+			p = e
+		} else if v := reflect.ValueOf(p); q.nullZero.ScansNullAsZero() && v.Kind() == reflect.Ptr && v.Elem().Kind() != reflect.Ptr {
+			p = NullZero{V: p}
+		}
+		res[i] = p
+	}
+	return res
+}
+
+// writeValues wraps values with NullZero if Querier's mode requires it.
+func (q *Querier) writeValues(values []interface{}) []interface{} {
+	if !q.nullZero.WritesZeroAsNull() {
+		return values
+	}
+	res := make([]interface{}, len(values))
+	for i, v := range values {
+		switch v.(type) {
+		case JSON, Array, NullZero, Encrypted:
+			// they handle NULL already
+		default:
+			v = NullZero{V: v}
+		}
+		res[i] = v
+	}
+	return res
+}
+
+// ScanRow scans the current row of rows into fields of str with given names (all fields if none),
+// considering NULL-to-zero mode. Conversion errors are returned as *ScanError naming the column.
+func (q *Querier) ScanRow(rows *sql.Rows, str Struct, fieldNames []string) error {
+	if err := rows.Scan(q.scanPointers(str.FieldPointersByNames(fieldNames))...); err != nil {
+		return scanError(str.View(), err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package reform_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xaionaro/reform"
+	"github.com/xaionaro/reform/dialects/sqlite3"
+)
+
+func TestNullZeroScan(t *testing.T) {
+	type Age int16
+
+	var age Age = 5
+	require.NoError(t, reform.NullZero{V: &age}.Scan(nil))
+	assert.Equal(t, Age(0), age)
+	require.NoError(t, reform.NullZero{V: &age}.Scan([]byte("42")))
+	assert.Equal(t, Age(42), age)
+	assert.Error(t, reform.NullZero{V: &age}.Scan(int64(1<<20)))
+
+	var u uint8
+	assert.Error(t, reform.NullZero{V: &u}.Scan(int64(-1)))
+
+	var s string
+	require.NoError(t, reform.NullZero{V: &s}.Scan(int64(7)))
+	assert.Equal(t, "7", s)
+
+	var f float64
+	require.NoError(t, reform.NullZero{V: &f}.Scan("1.5"))
+	assert.Equal(t, 1.5, f)
+
+	var b []byte
+	require.NoError(t, reform.NullZero{V: &b}.Scan("abc"))
+	assert.Equal(t, []byte("abc"), b)
+
+	now := time.Now()
+	tm := now
+	require.NoError(t, reform.NullZero{V: &tm}.Scan(nil))
+	assert.True(t, tm.IsZero())
+	require.NoError(t, reform.NullZero{V: &tm}.Scan(now))
+	assert.Equal(t, now, tm)
+
+	assert.Error(t, reform.NullZero{V: s}.Scan(nil))
+}
+
+func TestNullZeroValue(t *testing.T) {
+	for _, v := range []interface{}{0, "", false, time.Time{}, nil} {
+		value, err := reform.NullZero{V: v}.Value()
+		require.NoError(t, err)
+		assert.Nil(t, value, "%#v", v)
+	}
+
+	value, err := reform.NullZero{V: int32(3)}.Value()
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), value)
+	value, err = reform.NullZero{V: "a"}.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "a", value)
+}
+
+func TestNullZeroTag(t *testing.T) {
+	var f reform.FieldInfo
+	require.NoError(t, f.ConsiderTag(false, "Age", reflect.StructTag(`reform:"age,nullzero"`)))
+	assert.Equal(t, "age", f.Column)
+	assert.Equal(t, reform.NullZeroScan, f.NullZero)
+	assert.False(t, f.NullZero.WritesZeroAsNull())
+
+	f = reform.FieldInfo{Name: "Age", Type: "int", Kind: "int"}
+	require.NoError(t, f.ConsiderTag(false, "Age", reflect.StructTag(`reform:"age,nullzero:write"`)))
+	assert.Equal(t, reform.NullZeroScanWrite, f.NullZero)
+	assert.True(t, f.NullZero.WritesZeroAsNull())
+	assert.True(t, f.IsNullable())
+	assert.Equal(t, `"age" integer`, sqlite3.Dialect.ColumnDefinitionForField(f))
+
+	require.NoError(t, f.ConsiderTag(true, "Age", reflect.StructTag(`gorm:"column:age;nullzero"`)))
+	assert.Equal(t, reform.NullZeroScan, f.NullZero)
+
+	assert.Error(t, f.ConsiderTag(false, "Age", reflect.StructTag(`reform:"age,nullzero:read"`)))
+}
+
+func TestScanError(t *testing.T) {
+	sqlErr := errors.New(`sql: Scan error on column index 1, name "age": converting NULL to int is unsupported`)
+	err := &reform.ScanError{Table: "people", Column: "age", Err: sqlErr}
+	assert.Equal(t, `reform: failed to scan column people.age: converting NULL to int is unsupported (use pointer type or "nullzero" option)`, err.Error())
+	assert.True(t, errors.Is(err, sqlErr))
+}
@@ -13,9 +13,10 @@ import (
 
 // FieldInfo represents information about struct field.
 type FieldInfo struct {
-	Name   string // field name as defined in source file, e.g. Name
-	Type   string // field type as defined in source file, e.g. string; always present for primary key, may be absent otherwise
-	Column string // SQL database column name from "reform:" struct field tag, e.g. name
+	Name    string // field name as defined in source file, e.g. Name
+	Type    string // field type as defined in source file, e.g. string; always present for primary key, may be absent otherwise
+	Column  string // SQL database column name from "reform:" struct field tag, e.g. name
+	IsArray bool   // field is PostgreSQL array ("array" in "reform:" struct field tag)
 }
 
 // StructInfo represents information about struct.
@@ -0,0 +1,280 @@
+package parse
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var magicReformEnumComment = regexp.MustCompile(`(?m)^//reform:enum\s*$`)
+
+// EnumInfo represents information about type with "//reform:enum" magic comment.
+type EnumInfo struct {
+	Type   string   // enum type as defined in source file, e.g. Status
+	Kind   string   // underlying type, e.g. string or int32
+	Consts []string // names of constants with distinct values in declaration order, e.g. StatusActive
+	Values []string // values of constants as stored in the database, e.g. active
+}
+
+// IsString returns true for enums with string underlying type, false for integer ones.
+func (e EnumInfo) IsString() bool {
+	return e.Kind == "string"
+}
+
+// enumDecl is enum type declared in the file.
+type enumDecl struct {
+	EnumInfo
+	path string
+}
+
+// Enums parses given file and returns information about enum types declared in it.
+// Constants of those types may be declared in any non-test file of the same package.
+func Enums(path string) ([]EnumInfo, error) {
+	decls, err := packageEnums(filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+
+	var res []EnumInfo
+	for _, decl := range decls {
+		if filepath.Base(decl.path) == filepath.Base(path) {
+			res = append(res, decl.EnumInfo)
+		}
+	}
+	return res, nil
+}
+
+// packageEnumValues returns values of enum types declared in the package in given directory by type names.
+func packageEnumValues(dir string) (map[string][]string, error) {
+	decls, err := packageEnums(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string][]string, len(decls))
+	for _, decl := range decls {
+		res[decl.Type] = decl.Values
+	}
+	return res, nil
+}
+
+// packageEnums returns enum types declared in non-test files of the package in given directory.
+func packageEnums(dir string) ([]enumDecl, error) {
+	paths, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+	var files []*ast.File
+	var filePaths []string
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		fileNode, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.ParseComments)
+		if err != nil {
+			continue
+		}
+		files = append(files, fileNode)
+		filePaths = append(filePaths, path)
+	}
+
+	// find enum types
+	var res []enumDecl
+	indexes := make(map[string]int)
+	var types map[string]ast.Expr
+	for i, fileNode := range files {
+		for _, decl := range fileNode.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				ts := spec.(*ast.TypeSpec)
+				doc := ts.Doc
+				if doc == nil && len(gd.Specs) == 1 {
+					doc = gd.Doc
+				}
+				if doc == nil || !magicReformEnumComment.MatchString(commentText(doc)) {
+					continue
+				}
+
+				if types == nil {
+					types = packageTypes(dir)
+				}
+				kind := fileKind(ts.Type, types, 0)
+				switch kind {
+				case "string", "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+				default:
+					return nil, fmt.Errorf(`reform: %s has "//reform:enum" magic comment, but it is not a string or integer type`, ts.Name.Name)
+				}
+				indexes[ts.Name.Name] = len(res)
+				res = append(res, enumDecl{EnumInfo: EnumInfo{Type: ts.Name.Name, Kind: kind}, path: filePaths[i]})
+			}
+		}
+	}
+	if len(res) == 0 {
+		return nil, nil
+	}
+
+	// find their constants; constants may refer to ones declared later or in other files,
+	// so the last pass collects values after a few passes evaluating them
+	known := make(map[string]constant.Value)
+	knownTypes := make(map[string]string)
+	const passes = 3
+	for pass := 1; pass <= passes; pass++ {
+		for _, fileNode := range files {
+			for _, decl := range fileNode.Decls {
+				gd, ok := decl.(*ast.GenDecl)
+				if !ok || gd.Tok != token.CONST {
+					continue
+				}
+
+				// values and type are repeated for specs without them
+				var typ ast.Expr
+				var values []ast.Expr
+				for iota, spec := range gd.Specs {
+					vs := spec.(*ast.ValueSpec)
+					if vs.Type != nil || len(vs.Values) > 0 {
+						typ, values = vs.Type, vs.Values
+					}
+
+					for i, name := range vs.Names {
+						if i >= len(values) {
+							break
+						}
+						v := evalConst(values[i], int64(iota), known)
+						t := constType(typ, values[i], knownTypes)
+						known[name.Name], knownTypes[name.Name] = v, t
+
+						index, ok := indexes[t]
+						if !ok || name.Name == "_" || pass < passes {
+							continue
+						}
+						enum := &res[index].EnumInfo
+						var value string
+						switch {
+						case enum.IsString() && v.Kind() == constant.String:
+							value = constant.StringVal(v)
+						case !enum.IsString() && v.Kind() == constant.Int:
+							value = v.ExactString()
+						default:
+							return nil, fmt.Errorf(`reform: can't evaluate value of %s constant %s`, enum.Type, name.Name)
+						}
+
+						// aliases of the same value are not distinct enum values
+						var duplicate bool
+						for _, existing := range enum.Values {
+							duplicate = duplicate || existing == value
+						}
+						if !duplicate {
+							enum.Consts = append(enum.Consts, name.Name)
+							enum.Values = append(enum.Values, value)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	for _, decl := range res {
+		if len(decl.Consts) == 0 {
+			return nil, fmt.Errorf(`reform: %s has "//reform:enum" magic comment, but no constants`, decl.Type)
+		}
+	}
+	return res, nil
+}
+
+// constType returns the name of constant type declared explicitly, with conversion like Status("active"),
+// or inherited from typed constant operands like StatusNew or PriorityLow << 1.
+func constType(typ ast.Expr, value ast.Expr, knownTypes map[string]string) string {
+	if ident, ok := typ.(*ast.Ident); ok {
+		return ident.Name
+	}
+
+	switch x := value.(type) {
+	case *ast.Ident:
+		return knownTypes[x.Name]
+	case *ast.CallExpr:
+		if ident, ok := x.Fun.(*ast.Ident); ok && len(x.Args) == 1 {
+			return ident.Name
+		}
+	case *ast.ParenExpr:
+		return constType(nil, x.X, knownTypes)
+	case *ast.UnaryExpr:
+		return constType(nil, x.X, knownTypes)
+	case *ast.BinaryExpr:
+		if t := constType(nil, x.X, knownTypes); t != "" || x.Op == token.SHL || x.Op == token.SHR {
+			return t
+		}
+		return constType(nil, x.Y, knownTypes)
+	}
+	return ""
+}
+
+// evalConst returns the value of constant expression, or unknown value if it can't be evaluated.
+func evalConst(x ast.Expr, iota int64, known map[string]constant.Value) constant.Value {
+	unknown := constant.MakeUnknown()
+	switch x := x.(type) {
+	case *ast.BasicLit:
+		return constant.MakeFromLiteral(x.Value, x.Kind, 0)
+	case *ast.Ident:
+		switch x.Name {
+		case "iota":
+			return constant.MakeInt64(iota)
+		case "true", "false":
+			return constant.MakeBool(x.Name == "true")
+		}
+		if v, ok := known[x.Name]; ok {
+			return v
+		}
+		return unknown
+	case *ast.ParenExpr:
+		return evalConst(x.X, iota, known)
+	case *ast.CallExpr:
+		// conversion like Status(1)
+		if len(x.Args) != 1 {
+			return unknown
+		}
+		return evalConst(x.Args[0], iota, known)
+	case *ast.UnaryExpr:
+		v := evalConst(x.X, iota, known)
+		if v.Kind() == constant.Unknown {
+			return unknown
+		}
+		return constant.UnaryOp(x.Op, v, 0)
+	case *ast.BinaryExpr:
+		l, r := evalConst(x.X, iota, known), evalConst(x.Y, iota, known)
+		if l.Kind() == constant.Unknown || r.Kind() == constant.Unknown {
+			return unknown
+		}
+		if (l.Kind() == constant.String) != (r.Kind() == constant.String) {
+			// invalid expression, let the compiler report it
+			return unknown
+		}
+		switch x.Op {
+		case token.SHL, token.SHR:
+			s, ok := constant.Uint64Val(r)
+			if !ok {
+				return unknown
+			}
+			return constant.Shift(l, x.Op, uint(s))
+		case token.QUO:
+			if l.Kind() == constant.Int && r.Kind() == constant.Int {
+				if constant.Sign(r) == 0 {
+					return unknown
+				}
+				return constant.BinaryOp(l, token.QUO_ASSIGN, r) // integer division
+			}
+		case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+			return constant.MakeBool(constant.Compare(l, x.Op, r))
+		}
+		if x.Op == token.REM && constant.Sign(r) == 0 {
+			return unknown
+		}
+		return constant.BinaryOp(l, x.Op, r)
+	default:
+		return unknown
+	}
+}
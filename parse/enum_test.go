@@ -0,0 +1,90 @@
+package parse
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnums(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reform-enums")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"status.go": `package models
+
+// Status is order status.
+//reform:enum
+type Status string
+
+//reform:enum
+type Priority int16
+
+type Plain string
+
+const StatusNew Status = "new"
+
+const StatusRenamed = StatusDefault
+`,
+		"consts.go": `package models
+
+const (
+	StatusPaid    Status = "pa" + "id"
+	StatusDefault        = StatusNew // declared in other file
+	StatusOld            = Status("old")
+	PlainValue    Plain  = "plain"
+)
+
+const (
+	_ Priority = iota
+	PriorityLow
+	PriorityHigh = PriorityLow << 3
+	PriorityTop  = -(PriorityHigh / 3)
+)
+`,
+		"order.go": `package models
+
+//reform:orders
+type Order struct {
+	ID       int64     ` + "`reform:\"id,pk\"`" + `
+	Status   Status    ` + "`reform:\"status\"`" + `
+	Priority *Priority ` + "`reform:\"priority\"`" + `
+}
+`,
+	}
+	for name, content := range files {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	enums, err := Enums(filepath.Join(dir, "status.go"))
+	require.NoError(t, err)
+	expected := []EnumInfo{
+		{Type: "Status", Kind: "string", Consts: []string{"StatusPaid", "StatusDefault", "StatusOld"}, Values: []string{"paid", "new", "old"}},
+		{Type: "Priority", Kind: "int16", Consts: []string{"PriorityLow", "PriorityHigh", "PriorityTop"}, Values: []string{"1", "8", "-2"}},
+	}
+	assert.Equal(t, expected, enums)
+
+	enums, err = Enums(filepath.Join(dir, "consts.go"))
+	require.NoError(t, err)
+	assert.Empty(t, enums)
+
+	structs, err := File(filepath.Join(dir, "order.go"))
+	require.NoError(t, err)
+	require.Len(t, structs, 1)
+	assert.Nil(t, structs[0].Fields[0].EnumValues)
+	assert.Equal(t, []string{"paid", "new", "old"}, structs[0].Fields[1].EnumValues)
+	assert.Equal(t, []string{"1", "8", "-2"}, structs[0].Fields[2].EnumValues)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "bad.go"), []byte(`package models
+
+//reform:enum
+type Empty string
+`), 0644))
+	_, err = Enums(filepath.Join(dir, "status.go"))
+	assert.EqualError(t, err, `reform: Empty has "//reform:enum" magic comment, but no constants`)
+}
@@ -6,6 +6,7 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"strings"
@@ -32,6 +33,10 @@ func fileGoType(x ast.Expr, printOnError ...interface{}) string {
 		return "[" + fileGoType(t.Len, printOnError...) + "]" + fileGoType(t.Elt, printOnError...)
 	case *ast.BasicLit:
 		return t.Value
+	case *ast.MapType:
+		return "map[" + fileGoType(t.Key, printOnError...) + "]" + fileGoType(t.Value, printOnError...)
+	case *ast.InterfaceType:
+		return "interface{}"
 	case nil:
 		return ""
 	default:
@@ -39,6 +44,60 @@ func fileGoType(x ast.Expr, printOnError ...interface{}) string {
 	}
 }
 
+// fileKind returns the underlying type of given type without pointer, see FieldInfo.Kind.
+// Named types are resolved using given types declared in the package.
+func fileKind(x ast.Expr, types map[string]ast.Expr, depth int) string {
+	switch t := x.(type) {
+	case *ast.StarExpr:
+		return fileKind(t.X, types, depth)
+	case *ast.Ident:
+		if t.Name == "rune" {
+			return "int32"
+		}
+		// structures are kept as is, invalid recursive declarations are not followed
+		if underlying, ok := types[t.Name]; ok && depth < 10 {
+			if _, isStruct := underlying.(*ast.StructType); !isStruct {
+				return fileKind(underlying, types, depth+1)
+			}
+		}
+		return fileGoType(t)
+	case *ast.ArrayType:
+		return "[" + fileGoType(t.Len) + "]" + fileKind(t.Elt, types, depth)
+	case *ast.SelectorExpr:
+		return fileGoType(t)
+	default:
+		// maps, interfaces, etc.
+		return ""
+	}
+}
+
+// packageTypes returns types declared in non-test files of the package in given directory.
+// Files which can't be parsed are skipped: their types will not be resolved.
+func packageTypes(dir string) map[string]ast.Expr {
+	res := make(map[string]ast.Expr)
+	paths, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		fileNode, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		if err != nil {
+			continue
+		}
+		for _, decl := range fileNode.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				ts := spec.(*ast.TypeSpec)
+				res[ts.Name.Name] = ts.Type
+			}
+		}
+	}
+	return res
+}
+
 func getFieldTag(f *ast.Field) reflect.StructTag {
 	if f.Tag != nil {
 		tag := f.Tag.Value
@@ -50,7 +109,7 @@ func getFieldTag(f *ast.Field) reflect.StructTag {
 	return reflect.StructTag("")
 }
 
-func parseStructTypeSpec(ts *ast.TypeSpec, str *ast.StructType, imitateGorm bool, fieldsPath []r.FieldInfo, forceParse bool) (*r.StructInfo, error) {
+func parseStructTypeSpec(ts *ast.TypeSpec, str *ast.StructType, types map[string]ast.Expr, imitateGorm bool, fieldsPath []r.FieldInfo, forceParse bool) (*r.StructInfo, error) {
 	var prefix string
 	if len(fieldsPath) > 0 {
 		prefix = fieldsPath[len(fieldsPath)-1].Column + "__"
@@ -115,9 +174,12 @@ func parseStructTypeSpec(ts *ast.TypeSpec, str *ast.StructType, imitateGorm bool
 		fieldInfo := r.FieldInfo{
 			Name:       fieldName,
 			Type:       fType,
+			Kind:       fileKind(f.Type, types, 0),
 			FieldsPath: fieldsPath,
 		}
-		fieldInfo.ConsiderTag(imitateGorm, fieldName, tag)
+		if err := fieldInfo.ConsiderTag(imitateGorm, fieldName, tag); err != nil {
+			return nil, fmt.Errorf(`reform: %s has field %s (of type %s) with invalid tags: %s`, res.Type, fieldName, f.Type, err)
+		}
 
 		if fieldInfo.IsPK && (fieldInfo.Embedded != "") {
 			return nil, fmt.Errorf(`reform: %s has field %s (of type %s) that is the primary key and an embedded structure in the same time`, res.Type, fieldName, f.Type)
@@ -201,6 +263,16 @@ func parseStructTypeSpec(ts *ast.TypeSpec, str *ast.StructType, imitateGorm bool
 	return res, nil
 }
 
+// commentText returns the text of the comment group including directive-style lines
+// like "//reform:people", which are dropped by (*ast.CommentGroup).Text() since Go 1.15.
+func commentText(doc *ast.CommentGroup) string {
+	lines := make([]string, len(doc.List))
+	for i, c := range doc.List {
+		lines[i] = c.Text
+	}
+	return strings.Join(lines, "\n")
+}
+
 // File parses given file and returns found structs information.
 func File(path string) ([]r.StructInfo, error) {
 	return file(path, nil, []r.FieldInfo{}, false)
@@ -214,6 +286,12 @@ func file(path string, forceImitateGorm *bool, fieldsPath []r.FieldInfo, forcePa
 		return nil, err
 	}
 
+	types := packageTypes(filepath.Dir(path))
+	enums, err := packageEnumValues(filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+
 	// consider only top-level struct type declarations with magic comment
 	var res []r.StructInfo
 	for _, decl := range fileNode.Decls {
@@ -246,7 +324,7 @@ func file(path string, forceImitateGorm *bool, fieldsPath []r.FieldInfo, forcePa
 			}
 
 			if doc != nil {
-				optsMatches := magicReformOptionsComment.FindStringSubmatch(doc.Text())
+				optsMatches := magicReformOptionsComment.FindStringSubmatch(commentText(doc))
 				if len(optsMatches) >= 2 {
 					opts := strings.Split(optsMatches[1], ",")
 					for _, opt := range opts {
@@ -262,7 +340,7 @@ func file(path string, forceImitateGorm *bool, fieldsPath []r.FieldInfo, forcePa
 
 			var sm []string
 			if doc != nil {
-				sm = magicReformComment.FindStringSubmatch(doc.Text())
+				sm = magicReformComment.FindStringSubmatch(commentText(doc))
 			}
 			if !forceParse {
 				if len(sm) < 2 {
@@ -295,10 +373,13 @@ func file(path string, forceImitateGorm *bool, fieldsPath []r.FieldInfo, forcePa
 			}
 
 			// ast.Print(fset, ts)
-			s, err := parseStructTypeSpec(ts, str, imitateGorm, fieldsPath, forceParse)
+			s, err := parseStructTypeSpec(ts, str, types, imitateGorm, fieldsPath, forceParse)
 			if err != nil {
 				return nil, err
 			}
+			for i, f := range s.Fields {
+				s.Fields[i].EnumValues = enums[strings.TrimPrefix(f.Type, "*")]
+			}
 			s.SQLSchema = schema
 			s.SQLName = table
 			s.ImitateGorm = imitateGorm
@@ -23,6 +23,36 @@ func objectGoType(t reflect.Type, structT reflect.Type) string {
 	return s
 }
 
+// objectKind returns the underlying type of given type without pointer, see FieldInfo.Kind.
+func objectKind(t reflect.Type) string {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Slice:
+		return "[]" + objectKind(t.Elem())
+	case reflect.Array:
+		return fmt.Sprintf("[%d]%s", t.Len(), objectKind(t.Elem()))
+	case reflect.Struct, reflect.Map, reflect.Interface, reflect.Func, reflect.Chan:
+		// structures like time.Time and sql.NullString are kept as is
+		return t.String()
+	default:
+		return t.Kind().String()
+	}
+}
+
+// objectEnumValues returns values of enum type (implementing reform.Enum), or nil.
+func objectEnumValues(t reflect.Type) []string {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if e, ok := reflect.Zero(t).Interface().(r.Enum); ok {
+		return e.EnumValues()
+	}
+	return nil
+}
+
 // Object extracts struct information from given object.
 func Object(obj interface{}, schema, table string, imitateGorm bool) (res *r.StructInfo, err error) {
 	return object(reflect.ValueOf(obj).Elem().Type(), schema, table, imitateGorm, []r.FieldInfo{})
@@ -84,9 +114,13 @@ func object(t reflect.Type, schema, table string, imitateGorm bool, fieldsPath [
 		fieldInfo := r.FieldInfo{
 			Name:       fieldName,
 			Type:       fType,
+			Kind:       objectKind(f.Type),
 			FieldsPath: fieldsPath,
 		}
-		fieldInfo.ConsiderTag(imitateGorm, fieldName, tag)
+		if err := fieldInfo.ConsiderTag(imitateGorm, fieldName, tag); err != nil {
+			return nil, fmt.Errorf(`reform: %s has field %s with invalid tags: %s`, res.Type, fieldName, err)
+		}
+		fieldInfo.EnumValues = objectEnumValues(f.Type)
 
 		// check for exported name
 		if f.PkgPath != "" {
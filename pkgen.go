@@ -0,0 +1,354 @@
+package reform
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PKGenerator generates primary key values for records of tables with "pkgen:" option of primary key field,
+// like `reform:"id,pk,pkgen:uuidv7"`. Built-in generators are:
+//
+//	uuidv4, uuidv7       reform.UUID (for UUID or string fields)
+//	ulid                 26-character ULID string (for string fields)
+//	snowflake            int64 snowflake ID (requires registration of NewSnowflakeGenerator(node), see below)
+//	sequence[:name]      next value of database sequence (default name is <table>_<column>_seq of resolved table)
+//
+// Others can be registered with DB.RegisterPKGenerator. Snowflake IDs are unique only if every process
+// uses its own node ID, so "snowflake" generator returns an error until it is registered:
+//
+//	db.RegisterPKGenerator("snowflake", reform.NewSnowflakeGenerator(node))
+type PKGenerator interface {
+	// GeneratePK returns a new primary key value for a record of given table.
+	GeneratePK(q *Querier, table Table, field FieldInfo) (interface{}, error)
+}
+
+// PKGeneratorFunc is a function implementing PKGenerator.
+type PKGeneratorFunc func(q *Querier, table Table, field FieldInfo) (interface{}, error)
+
+// GeneratePK calls f.
+func (f PKGeneratorFunc) GeneratePK(q *Querier, table Table, field FieldInfo) (interface{}, error) {
+	return f(q, table, field)
+}
+
+// builtinPKGenerators are generators available by name without registration.
+var builtinPKGenerators = map[string]PKGenerator{
+	"uuidv4": PKGeneratorFunc(func(*Querier, Table, FieldInfo) (interface{}, error) {
+		return NewUUIDv4(), nil
+	}),
+	"uuidv7": PKGeneratorFunc(func(*Querier, Table, FieldInfo) (interface{}, error) {
+		return NewUUIDv7(), nil
+	}),
+	"ulid": PKGeneratorFunc(func(*Querier, Table, FieldInfo) (interface{}, error) {
+		return NewULID(), nil
+	}),
+	"snowflake": PKGeneratorFunc(func(*Querier, Table, FieldInfo) (interface{}, error) {
+		return nil, fmt.Errorf(`reform: "snowflake" primary key generator requires node ID, ` +
+			`register it with RegisterPKGenerator("snowflake", reform.NewSnowflakeGenerator(node))`)
+	}),
+	"sequence": PKGeneratorFunc(nextSequenceValue),
+}
+
+// SequenceDialect is implemented by dialects which support sequences.
+type SequenceDialect interface {
+	Dialect
+
+	// NextValQuery returns a query selecting the next value of given sequence.
+	NextValQuery(sequence string) string
+}
+
+// nextSequenceValue implements "sequence" generator.
+func nextSequenceValue(q *Querier, table Table, field FieldInfo) (interface{}, error) {
+	dialect, ok := q.Dialect.(SequenceDialect)
+	if !ok {
+		return nil, fmt.Errorf("reform: dialect %s does not support sequences", q.Dialect)
+	}
+	schema, name := q.resolveTable(table.Schema(), table.Name())
+	sequence := strings.TrimPrefix(strings.TrimPrefix(field.PKGenerator, "sequence"), ":")
+	if sequence == "" {
+		sequence = name + "_" + field.Column + "_seq"
+	}
+	if schema != "" && !strings.Contains(sequence, ".") {
+		sequence = schema + "." + sequence
+	}
+	var id int64
+	if err := q.QueryRow(dialect.NextValQuery(sequence)).Scan(&id); err != nil {
+		return nil, err
+	}
+	return id, nil
+}
+
+// pkGeneratorOption returns the generator from "pkgen:" option of "reform:" (or "gorm:") tag of the field,
+// e.g. uuidv7 or sequence:users_id_seq.
+func pkGeneratorOption(tag reflect.StructTag, imitateGorm bool) string {
+	parts := strings.Split(tag.Get("reform"), ",")[1:]
+	if imitateGorm {
+		parts = strings.Split(tag.Get("gorm"), ";")
+	}
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); strings.HasPrefix(part, "pkgen:") {
+			return strings.TrimPrefix(part, "pkgen:")
+		}
+	}
+	return ""
+}
+
+// RegisterPKGenerator registers primary key generator with given name for "pkgen:" option,
+// replacing the built-in one with the same name.
+// Transactions started with Begin and copies made with With* methods after that inherit it.
+func (db *DB) RegisterPKGenerator(name string, g PKGenerator) {
+	generators := make(map[string]PKGenerator, len(db.pkGenerators)+1)
+	for n, existing := range db.pkGenerators {
+		generators[n] = existing
+	}
+	generators[name] = g
+	db.pkGenerators = generators
+}
+
+// generatePK sets primary key of str if it is a record without primary key, and its table has
+// primary key field with "pkgen:" option. It returns true if primary key was generated.
+func (q *Querier) generatePK(str Struct) (bool, error) {
+	record, ok := str.(Record)
+	if !ok || record.HasPK() {
+		return false, nil
+	}
+	t, ok := record.Table().(interface{ StructInfo() StructInfo })
+	if !ok {
+		return false, nil
+	}
+	s := t.StructInfo()
+	if s.PKFieldIndex < 0 || s.Fields[s.PKFieldIndex].PKGenerator == "" {
+		return false, nil
+	}
+
+	field := s.Fields[s.PKFieldIndex]
+	name := strings.SplitN(field.PKGenerator, ":", 2)[0]
+	g, ok := q.pkGenerators[name]
+	if !ok {
+		if g, ok = builtinPKGenerators[name]; !ok {
+			return false, fmt.Errorf("reform: unknown primary key generator %q of %s", name, s.Type)
+		}
+	}
+	pk, err := g.GeneratePK(q, record.Table(), field)
+	if err != nil {
+		return false, err
+	}
+	// convert first, as SetPK panics on invalid values
+	converted := reflect.New(reflect.TypeOf(record.PKValue()))
+	if err = assignPK(converted.Interface(), pk); err != nil {
+		return false, err
+	}
+	record.SetPK(converted.Elem().Interface())
+	return true, nil
+}
+
+// AssignPK stores primary key value pk into the field pointed by ptr, converting it if needed:
+// integers of any type, strings, UUIDs (from strings and bytes) and types implementing sql.Scanner are supported.
+// It is used by generated SetPK methods, and panics if the value can't be converted.
+func AssignPK(ptr interface{}, pk interface{}) {
+	if err := assignPK(ptr, pk); err != nil {
+		panic(err)
+	}
+}
+
+// assignPK implements AssignPK.
+func assignPK(ptr interface{}, pk interface{}) error {
+	dest := reflect.ValueOf(ptr).Elem()
+	if pk == nil {
+		dest.Set(reflect.Zero(dest.Type()))
+		return nil
+	}
+	src := reflect.ValueOf(pk)
+	if src.Type().AssignableTo(dest.Type()) {
+		dest.Set(src)
+		return nil
+	}
+	if scanner, ok := ptr.(sql.Scanner); ok {
+		var value interface{} = pk
+		if valuer, ok := pk.(driver.Valuer); ok {
+			var err error
+			if value, err = valuer.Value(); err != nil {
+				return err
+			}
+		}
+		return scanner.Scan(value)
+	}
+
+	fail := fmt.Errorf("reform: can't set primary key of type %s to %v (%T)", dest.Type(), pk, pk)
+	switch dest.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		switch src.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			i = src.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if src.Uint() > 1<<63-1 {
+				return fail
+			}
+			i = int64(src.Uint())
+		case reflect.String:
+			var err error
+			if i, err = strconv.ParseInt(src.String(), 10, 64); err != nil {
+				return fail
+			}
+		default:
+			return fail
+		}
+		if dest.OverflowInt(i) {
+			return fail
+		}
+		dest.SetInt(i)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var u uint64
+		switch src.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if src.Int() < 0 {
+				return fail
+			}
+			u = uint64(src.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			u = src.Uint()
+		case reflect.String:
+			var err error
+			if u, err = strconv.ParseUint(src.String(), 10, 64); err != nil {
+				return fail
+			}
+		default:
+			return fail
+		}
+		if dest.OverflowUint(u) {
+			return fail
+		}
+		dest.SetUint(u)
+
+	case reflect.String:
+		if stringer, ok := pk.(fmt.Stringer); ok {
+			dest.SetString(stringer.String())
+			break
+		}
+		switch src.Kind() {
+		case reflect.String:
+			dest.SetString(src.String())
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			dest.SetString(strconv.FormatInt(src.Int(), 10))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			dest.SetString(strconv.FormatUint(src.Uint(), 10))
+		case reflect.Slice:
+			if src.Type().Elem().Kind() != reflect.Uint8 {
+				return fail
+			}
+			dest.SetString(string(src.Bytes()))
+		default:
+			return fail
+		}
+
+	case reflect.Array:
+		if dest.Len() != 16 || dest.Type().Elem().Kind() != reflect.Uint8 {
+			return fail
+		}
+		var u UUID
+		if err := u.Scan(pk); err != nil {
+			if src.Kind() != reflect.Array || src.Len() != 16 || src.Type().Elem().Kind() != reflect.Uint8 {
+				return fail
+			}
+			reflect.Copy(reflect.ValueOf(u[:]), src)
+		}
+		reflect.Copy(dest, reflect.ValueOf(u[:]))
+
+	default:
+		if !src.Type().ConvertibleTo(dest.Type()) {
+			return fail
+		}
+		dest.Set(src.Convert(dest.Type()))
+	}
+	return nil
+}
+
+// crockford is the alphabet of ULID encoding.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// NewULID returns a new ULID: 48-bit Unix time in milliseconds and 80 random bits
+// encoded as 26 characters, so strings generated later sort after earlier ones (with millisecond precision).
+func NewULID() string {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], uint64(time.Now().UnixNano()/int64(time.Millisecond))<<16)
+	if _, err := rand.Read(b[6:]); err != nil {
+		panic(err)
+	}
+
+	// 128 bits are encoded as 26 5-bit characters, the first one has 3 bits only
+	hi, lo := binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:])
+	var s [26]byte
+	for i := 25; i >= 0; i-- {
+		s[i] = crockford[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(s[:])
+}
+
+// SnowflakeEpoch is the start of time of snowflake IDs (2020-01-01 UTC).
+var SnowflakeEpoch = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// SnowflakeGenerator generates int64 snowflake IDs: 41 bits of milliseconds since SnowflakeEpoch,
+// 10 bits of node ID and 12 bits of sequence number within the millisecond.
+// Each process generating IDs for the same table should have its own node ID.
+type SnowflakeGenerator struct {
+	node int64
+	m    sync.Mutex
+	last int64
+	seq  int64
+}
+
+// NewSnowflakeGenerator creates a snowflake generator for given node ID (0-1023).
+func NewSnowflakeGenerator(node int64) *SnowflakeGenerator {
+	if node < 0 || node >= 1<<10 {
+		panic(fmt.Errorf("reform: snowflake node ID %d is out of range 0-1023", node))
+	}
+	return &SnowflakeGenerator{node: node}
+}
+
+// Next returns the next ID.
+func (g *SnowflakeGenerator) Next() int64 {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	now := int64(time.Since(SnowflakeEpoch) / time.Millisecond)
+	if now < g.last {
+		// clock moved backwards, stay in the last millisecond
+		now = g.last
+	}
+	if now == g.last {
+		g.seq = (g.seq + 1) & (1<<12 - 1)
+		if g.seq == 0 {
+			// sequence is exhausted, wait for the next millisecond
+			for now <= g.last {
+				time.Sleep(100 * time.Microsecond)
+				now = int64(time.Since(SnowflakeEpoch) / time.Millisecond)
+			}
+		}
+	} else {
+		g.seq = 0
+	}
+	g.last = now
+	return now<<22 | g.node<<12 | g.seq
+}
+
+// GeneratePK implements PKGenerator.
+func (g *SnowflakeGenerator) GeneratePK(*Querier, Table, FieldInfo) (interface{}, error) {
+	return g.Next(), nil
+}
+
+// check interfaces
+var (
+	_ PKGenerator = PKGeneratorFunc(nil)
+	_ PKGenerator = (*SnowflakeGenerator)(nil)
+)
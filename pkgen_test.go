@@ -0,0 +1,204 @@
+package reform_test
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xaionaro/reform"
+	"github.com/xaionaro/reform/dialects/mysql"
+	"github.com/xaionaro/reform/dialects/postgresql"
+	"github.com/xaionaro/reform/dialects/sqlite3"
+)
+
+func TestUUID(t *testing.T) {
+	u, err := reform.ParseUUID("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	require.NoError(t, err)
+	assert.Equal(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8", u.String())
+	for _, s := range []string{"6BA7B8109DAD11D180B400C04FD430C8", "{6ba7b810-9dad-11d1-80b4-00c04fd430c8}"} {
+		parsed, err := reform.ParseUUID(s)
+		require.NoError(t, err)
+		assert.Equal(t, u, parsed)
+	}
+	for _, s := range []string{"", "6ba7b810-9dad-11d1-80b4_00c04fd430c8", "6ba7b8109dad11d180b400c04fd430cz"} {
+		_, err = reform.ParseUUID(s)
+		assert.Error(t, err, "%q", s)
+	}
+
+	var scanned reform.UUID
+	require.NoError(t, scanned.Scan(u[:]))
+	assert.Equal(t, u, scanned)
+	require.NoError(t, scanned.Scan([]byte(u.String())))
+	assert.Equal(t, u, scanned)
+	assert.Error(t, scanned.Scan(nil))
+	value, err := u.Value()
+	require.NoError(t, err)
+	assert.Equal(t, u.String(), value)
+	assert.Equal(t, u[:], mysql.Dialect.UUIDValue(u))
+
+	v4 := reform.NewUUIDv4()
+	assert.Equal(t, byte(0x40), v4[6]&0xf0)
+	assert.Equal(t, byte(0x80), v4[8]&0xc0)
+	assert.NotEqual(t, v4, reform.NewUUIDv4())
+	v7 := reform.NewUUIDv7()
+	assert.Equal(t, byte(0x70), v7[6]&0xf0)
+	assert.Equal(t, byte(0x80), v7[8]&0xc0)
+	assert.False(t, v7.IsZero())
+
+	f := reform.FieldInfo{Name: "ID", Type: "reform.UUID", Kind: "[16]uint8", Column: "id", IsPK: true}
+	assert.False(t, f.IsAutoIncrement())
+	assert.Equal(t, "binary(16)", mysql.Dialect.ColumnTypeForField(f))
+	assert.Equal(t, "uuid", postgresql.Dialect.ColumnTypeForField(f))
+	f.Type = "uuid.UUID"
+	assert.Equal(t, "char(36)", mysql.Dialect.ColumnTypeForField(f))
+}
+
+func TestULID(t *testing.T) {
+	ids := make([]string, 100)
+	for i := range ids {
+		ids[i] = reform.NewULID()
+		assert.Regexp(t, `^[0-7][0-9A-HJKMNP-TV-Z]{25}$`, ids[i])
+	}
+	assert.NotEqual(t, ids[0], ids[1])
+
+	// the first 10 characters are the timestamp
+	prefixes := make([]string, len(ids))
+	for i, id := range ids {
+		prefixes[i] = id[:10]
+	}
+	assert.True(t, sort.StringsAreSorted(prefixes))
+}
+
+func TestSnowflake(t *testing.T) {
+	g := reform.NewSnowflakeGenerator(5)
+	seen := make(map[int64]bool)
+	var last int64
+	for i := 0; i < 10000; i++ {
+		id := g.Next()
+		require.True(t, id > last, "%d after %d", id, last)
+		require.False(t, seen[id])
+		assert.Equal(t, int64(5), id>>12&(1<<10-1))
+		seen[id], last = true, id
+	}
+	assert.Panics(t, func() { reform.NewSnowflakeGenerator(1024) })
+}
+
+func TestAssignPK(t *testing.T) {
+	type ID int32
+
+	var id ID
+	reform.AssignPK(&id, int64(42))
+	assert.Equal(t, ID(42), id)
+	reform.AssignPK(&id, uint8(7))
+	assert.Equal(t, ID(7), id)
+	reform.AssignPK(&id, "9")
+	assert.Equal(t, ID(9), id)
+	assert.Panics(t, func() { reform.AssignPK(&id, int64(1<<40)) })
+	assert.Panics(t, func() { reform.AssignPK(&id, 1.5) })
+
+	var u uint16
+	reform.AssignPK(&u, 3)
+	assert.Equal(t, uint16(3), u)
+	assert.Panics(t, func() { reform.AssignPK(&u, -1) })
+
+	uuid := reform.NewUUIDv4()
+	var s string
+	reform.AssignPK(&s, uuid)
+	assert.Equal(t, uuid.String(), s)
+	reform.AssignPK(&s, int64(5))
+	assert.Equal(t, "5", s)
+
+	var scanned reform.UUID
+	reform.AssignPK(&scanned, uuid.String())
+	assert.Equal(t, uuid, scanned)
+	var array [16]byte
+	reform.AssignPK(&array, uuid)
+	assert.Equal(t, [16]byte(uuid), array)
+	reform.AssignPK(&array, uuid.String())
+	assert.Equal(t, [16]byte(uuid), array)
+
+	reform.AssignPK(&s, nil)
+	assert.Equal(t, "", s)
+}
+
+func TestPKGenTag(t *testing.T) {
+	f := reform.FieldInfo{Name: "ID", Type: "int64", Kind: "int64"}
+	require.NoError(t, f.ConsiderTag(false, "ID", reflect.StructTag(`reform:"id,pk,pkgen:snowflake"`)))
+	assert.True(t, f.IsPK)
+	assert.Equal(t, "snowflake", f.PKGenerator)
+	assert.False(t, f.IsAutoIncrement())
+	assert.Equal(t, `"id" integer PRIMARY KEY NOT NULL`, sqlite3.Dialect.ColumnDefinitionForField(f))
+
+	require.NoError(t, f.ConsiderTag(false, "ID", reflect.StructTag(`reform:"id,pk,pkgen:sequence"`)))
+	assert.True(t, f.IsAutoIncrement())
+	require.NoError(t, f.ConsiderTag(false, "ID", reflect.StructTag(`reform:"id,pk,pkgen:sequence:ids"`)))
+	assert.Equal(t, "sequence:ids", f.PKGenerator)
+	assert.False(t, f.IsAutoIncrement())
+
+	require.NoError(t, f.ConsiderTag(true, "ID", reflect.StructTag(`gorm:"primary_key;pkgen:uuidv7"`)))
+	assert.Equal(t, "uuidv7", f.PKGenerator)
+
+	assert.Error(t, f.ConsiderTag(false, "Name", reflect.StructTag(`reform:"name,pkgen:ulid"`)))
+
+	assert.Equal(t, `SELECT nextval('public.users_id_seq')`, postgresql.Dialect.NextValQuery("public.users_id_seq"))
+}
+
+// pkgenDoc is tenantDoc stored in pkgenDocTable.
+type pkgenDoc struct {
+	tenantDoc
+	table *pkgenDocTable
+}
+
+func (d *pkgenDoc) View() reform.View   { return d.table }
+func (d *pkgenDoc) Table() reform.Table { return d.table }
+
+// pkgenDocTable is tenantDocs with primary key generator instead of the tenant field.
+type pkgenDocTable struct {
+	*tenantDocTable
+	gen string
+}
+
+func (t *pkgenDocTable) StructInfo() reform.StructInfo {
+	return reform.StructInfo{Type: "pkgenDoc", SQLName: "docs", PKFieldIndex: 0, Fields: []reform.FieldInfo{
+		{Name: "ID", Type: "int64", Column: "id", IsPK: true, PKGenerator: t.gen},
+		{Name: "TenantID", Type: "int64", Column: "tenant_id"},
+		{Name: "Title", Type: "string", Column: "title"},
+	}}
+}
+
+// sequenceDialect is SQLite dialect with sequences returning 42, it records their names.
+type sequenceDialect struct {
+	reform.Dialect
+	sequences []string
+}
+
+func (d *sequenceDialect) NextValQuery(sequence string) string {
+	d.sequences = append(d.sequences, sequence)
+	return "SELECT 42"
+}
+
+func TestGeneratePK(t *testing.T) {
+	sqlDB := openSQLite3(t)
+	dialect := &sequenceDialect{Dialect: sqlite3.Dialect}
+	db := reform.NewDB(sqlDB, dialect, reform.NewPrintfLogger(t.Logf))
+	_, err := db.Exec(`CREATE TABLE docs_2026 (id integer PRIMARY KEY, tenant_id integer NOT NULL, title text NOT NULL)`)
+	require.NoError(t, err)
+	db = db.WithTableResolver(func(schema, name string) (string, string) { return schema, name + "_2026" })
+
+	// snowflake generator requires node ID
+	snowflakeDocs := &pkgenDocTable{tenantDocTable: tenantDocs, gen: "snowflake"}
+	assert.Error(t, db.Insert(&pkgenDoc{table: snowflakeDocs}))
+	db.RegisterPKGenerator("snowflake", reform.NewSnowflakeGenerator(7))
+	doc := &pkgenDoc{table: snowflakeDocs}
+	require.NoError(t, db.Insert(doc))
+	assert.Equal(t, int64(7), doc.ID>>12&(1<<10-1))
+
+	// default sequence name uses resolved table name
+	doc = &pkgenDoc{table: &pkgenDocTable{tenantDocTable: tenantDocs, gen: "sequence"}}
+	require.NoError(t, db.Insert(doc))
+	assert.Equal(t, int64(42), doc.ID)
+	assert.Equal(t, []string{"docs_2026_id_seq"}, dialect.sequences)
+}
@@ -1,7 +1,9 @@
 package reform
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	mysqlDriver "github.com/go-sql-driver/mysql"
 	"reflect"
@@ -16,6 +18,25 @@ type Querier struct {
 	Dialect
 	Logger         Logger
 	dbForCallbacks *DB
+	interceptors   []Interceptor
+	ctx            context.Context
+	tags           Tags
+	nullZero       NullZeroMode
+	cipher         Cipher
+	pkGenerators   map[string]PKGenerator
+	tableResolver  TableResolver
+	tenant         interface{}
+	withoutTenant  bool
+	recordCache    *recordCache
+	queryCache     Cache
+	txCache        *txCache
+}
+
+// dbtxContext is implemented by DBTX implementations supporting context, like *sql.DB and *sql.Tx.
+type dbtxContext interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
 }
 
 func newQuerier(dbtx DBTX, dialect Dialect, logger Logger, dbForCallbacks *DB) *Querier {
@@ -27,6 +48,12 @@ func newQuerier(dbtx DBTX, dialect Dialect, logger Logger, dbForCallbacks *DB) *
 	}
 }
 
+// clone returns a shallow copy of Querier.
+func (q *Querier) clone() *Querier {
+	newQ := *q
+	return &newQ
+}
+
 func (q *Querier) logBefore(query string, args []interface{}) {
 	if q.Logger != nil {
 		q.Logger.Before(query, args)
@@ -83,7 +110,7 @@ func (q *Querier) startQuery(command string) string {
 // WithTag returns a copy of Querier with set tag. Returned Querier is tied to the same DB or TX.
 // See Tagging section in documentation for details.
 func (q *Querier) WithTag(format string, args ...interface{}) *Querier {
-	newQ := newQuerier(q.dbtx, q.Dialect, q.Logger, q.dbForCallbacks)
+	newQ := q.clone()
 	if len(args) == 0 {
 		newQ.tag = format
 	} else {
@@ -92,11 +119,29 @@ func (q *Querier) WithTag(format string, args ...interface{}) *Querier {
 	return newQ
 }
 
-// QualifiedView returns quoted qualified view name.
+// WithQuerier returns a copy of db (*DB, *TX or *Querier) which uses a Querier returned by f.
+// It is used by generated scopes to get tagged, context-aware, etc. copies of their database handle.
+func WithQuerier(db ReformDBTX, f func(*Querier) *Querier) ReformDBTX {
+	switch db := db.(type) {
+	case *DB:
+		return db.withQuerier(f(db.Querier))
+	case *TX:
+		return &TX{Querier: f(db.Querier), tx: db.tx}
+	case *Querier:
+		return f(db)
+	case nil:
+		return nil
+	default:
+		panic(fmt.Sprintf("reform: WithQuerier: unexpected type %T", db))
+	}
+}
+
+// QualifiedView returns quoted qualified view name, considering table resolver (see WithTableResolver).
 func (q *Querier) QualifiedView(view View) string {
-	v := q.QuoteIdentifier(view.Name())
-	if view.Schema() != "" {
-		v = q.QuoteIdentifier(view.Schema()) + "." + v
+	schema, name := q.resolveTable(view.Schema(), view.Name())
+	v := q.QuoteIdentifier(name)
+	if schema != "" {
+		v = q.QuoteIdentifier(schema) + "." + v
 	}
 	return v
 }
@@ -114,20 +159,36 @@ func (q *Querier) QualifiedColumns(view View) []string {
 // Exec executes a query without returning any rows.
 // The args are for any placeholder parameters in the query.
 func (q *Querier) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return q.execChain(q.exec)(appendTags(query, q.statementTags()), q.prepareArgs(args))
+}
+
+func (q *Querier) exec(query string, args []interface{}) (res sql.Result, err error) {
 	q.logBefore(query, args)
 	start := time.Now()
-	res, err := q.dbtx.Exec(query, args...)
+	if dbtx, ok := q.dbtx.(dbtxContext); ok && q.ctx != nil {
+		res, err = dbtx.ExecContext(q.ctx, query, args...)
+	} else {
+		res, err = q.dbtx.Exec(query, args...)
+	}
 	q.logAfter(query, args, time.Since(start), err)
 	return res, err
 }
 
 // Query executes a query that returns rows, typically a SELECT.
 // The args are for any placeholder parameters in the query.
-func (q *Querier) Query(query string, args ...interface{}) (rows *sql.Rows, err error) {
+func (q *Querier) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return q.queryChain(q.query)(appendTags(query, q.statementTags()), q.prepareArgs(args))
+}
+
+func (q *Querier) query(query string, args []interface{}) (rows *sql.Rows, err error) {
 	q.logBefore(query, args)
 	start := time.Now()
 	for {
-		rows, err = q.dbtx.Query(query, args...)
+		if dbtx, ok := q.dbtx.(dbtxContext); ok && q.ctx != nil {
+			rows, err = dbtx.QueryContext(q.ctx, query, args...)
+		} else {
+			rows, err = q.dbtx.Query(query, args...)
+		}
 		if err == mysqlDriver.ErrInvalidConn {
 			continue
 		}
@@ -140,9 +201,17 @@ func (q *Querier) Query(query string, args ...interface{}) (rows *sql.Rows, err
 // QueryRow executes a query that is expected to return at most one row.
 // QueryRow always returns a non-nil value. Errors are deferred until Row's Scan method is called.
 func (q *Querier) QueryRow(query string, args ...interface{}) *sql.Row {
+	return q.queryRowChain(q.queryRow)(appendTags(query, q.statementTags()), q.prepareArgs(args))
+}
+
+func (q *Querier) queryRow(query string, args []interface{}) (row *sql.Row) {
 	q.logBefore(query, args)
 	start := time.Now()
-	row := q.dbtx.QueryRow(query, args...)
+	if dbtx, ok := q.dbtx.(dbtxContext); ok && q.ctx != nil {
+		row = dbtx.QueryRowContext(q.ctx, query, args...)
+	} else {
+		row = q.dbtx.QueryRow(query, args...)
+	}
 	q.logAfter(query, args, time.Since(start), nil)
 	return row
 }
@@ -223,12 +292,27 @@ func (querier Querier) ValueForSQL(valueI interface{}) []interface{} {
 			return []interface{}{driverValuer}
 		}
 
+		// like time.Time and []byte
+		if driver.IsValue(value) {
+			return []interface{}{value}
+		}
+
 		stringer, ok := value.(Stringer)
 		if ok {
 			return []interface{}{stringer.String()}
 		}
 
-		return []interface{}{fmt.Sprintf("%v", value)}
+		// named []byte types like json.RawMessage are single values
+		v := reflect.ValueOf(value)
+		switch v.Kind() {
+		case reflect.Slice, reflect.Array:
+			if v.Type().Elem().Kind() != reflect.Uint8 {
+				return sliceWrapper(value)
+			}
+		}
+
+		// named types of basic kinds are converted by database/sql
+		return []interface{}{value}
 	}
 }
 
@@ -241,40 +325,89 @@ func (querier Querier) EscapeTableName(tableName string) string {
 	return querier.Dialect.QuoteIdentifier(tableName)
 }
 
+// ColumnDefinitionsOfStruct returns definitions of columns for CREATE TABLE query
+// followed by foreign key constraints.
 func (querier Querier) ColumnDefinitionsOfStruct(structInfo StructInfo) (definitions []string) {
+	var foreignKeys []string
 	for _, field := range structInfo.Fields {
 		if field.Column == "" {
 			continue
 		}
 		definitions = append(definitions, querier.Dialect.ColumnDefinitionForField(field))
+		if fk := field.ForeignKeyDefinition(querier.Dialect); fk != "" {
+			foreignKeys = append(foreignKeys, fk)
+		}
 	}
 
-	return
+	return append(definitions, foreignKeys...)
 }
 
+// CreateTableIfNotExists creates the table described by structInfo together with its indexes,
+// if the table does not exist yet. It returns true if the table was created.
+// Dialect should implement SchemaDialect.
 func (querier Querier) CreateTableIfNotExists(structInfo StructInfo) (bool, error) {
-	// TODO: correctly escape table name
-	request := "CREATE TABLE IF NOT EXISTS `" + structInfo.SQLName + "` (" +
-		strings.Join(querier.ColumnDefinitionsOfStruct(structInfo), ", ") +
-		")"
+	dialect, ok := querier.Dialect.(SchemaDialect)
+	if !ok {
+		return false, fmt.Errorf("reform: dialect %s does not support schema inspection", querier.Dialect)
+	}
 
-	var postQueries []string
-	for _, field := range structInfo.Fields {
-		if field.Column == "" {
-			continue
-		}
-		postQuery := querier.Dialect.ColumnDefinitionPostQueryForField(structInfo, field)
-		if postQuery == "" {
-			continue
+	structInfo = querier.resolveStruct(structInfo)
+	actual, err := dialect.InspectTable(&querier, structInfo.SQLSchema, structInfo.SQLName)
+	if err != nil || actual != nil {
+		return false, err
+	}
+
+	types := len(querier.createTypeQueries(structInfo))
+	for i, query := range querier.createTableQueries(structInfo) {
+		if _, err = querier.Exec(query); err != nil {
+			// the first queries create enum types, then the table, others create indexes
+			return i > types, err
 		}
-		postQueries = append(postQueries, postQuery)
 	}
+	return true, nil
+}
 
-	_, err := querier.Exec(request + "; " + strings.Join(postQueries, "; "))
-	return false, err
+// CreateLogTableIfNotExists creates the log table described by structInfo (see StructInfo.ToLog),
+// if it does not exist yet. Log table has the same columns, but primary key and unique indexes
+// are replaced with ordinary ones, and there are no foreign keys. It returns true if the table was created.
+func (querier Querier) CreateLogTableIfNotExists(structInfo StructInfo) (bool, error) {
+	fields := make([]FieldInfo, len(structInfo.Fields))
+	for i, f := range structInfo.Fields {
+		indexes := make([]FieldIndex, 0, len(f.Indexes)+1)
+		for _, index := range f.Indexes {
+			if index.Name != "" {
+				// index names are unique per schema in some databases
+				index.Name = structInfo.SQLName + "_" + index.Name
+			}
+			index.Unique, index.Where = false, ""
+			indexes = append(indexes, index)
+		}
+		if f.IsPK && len(indexes) > 0 {
+			indexes = append(indexes, FieldIndex{})
+		}
+		f.Indexes = indexes
+
+		f.HasIndex = f.HasIndex || f.IsPK || f.IsUnique
+		f.IsPK, f.IsUnique = false, false
+		f.ReferencesTable, f.ReferencesColumn, f.OnDelete = "", "", ""
+		fields[i] = f
+	}
+	structInfo.Fields = fields
+	structInfo.PKFieldIndex = -1
+	return querier.CreateTableIfNotExists(structInfo)
 }
 
 func (querier Querier) GetWhereTailForFilter(filter interface{}, columnNameByFieldName func(string) string, prefix string, imitateGorm bool) (tail string, whereTailArgs []interface{}, err error) {
+	// table name is bound to values of encrypted fields, it is known for generated models
+	var table string
+	if viewer, ok := reflect.New(reflect.TypeOf(filter)).Interface().(interface{ View() View }); ok {
+		table = viewer.View().Name()
+	}
+	return querier.getWhereTailForFilter(filter, table, columnNameByFieldName, prefix, imitateGorm)
+}
+
+// getWhereTailForFilter implements GetWhereTailForFilter for filter of given table.
+func (querier Querier) getWhereTailForFilter(filter interface{}, table string, columnNameByFieldName func(string) string, prefix string, imitateGorm bool) (tail string, whereTailArgs []interface{}, err error) {
 	var whereTailStringParts []string
 
 	v := reflect.ValueOf(filter)
@@ -316,7 +449,7 @@ func (querier Querier) GetWhereTailForFilter(filter interface{}, columnNameByFie
 				if embedded == "prefixed" {
 					nestedPrefix += columnName + "__"
 				}
-				tailPart, args, er := querier.GetWhereTailForFilter(f.Interface(), columnNameByFieldName, nestedPrefix, imitateGorm)
+				tailPart, args, er := querier.getWhereTailForFilter(f.Interface(), table, columnNameByFieldName, nestedPrefix, imitateGorm)
 				if er != nil {
 					err = er
 					return
@@ -344,8 +477,26 @@ func (querier Querier) GetWhereTailForFilter(filter interface{}, columnNameByFie
 		}
 
 		placeholderCounter++
-		whereTailStringParts = append(whereTailStringParts, querier.EscapeTableName(columnName)+" = "+querier.Dialect.Placeholder(placeholderCounter))
-		whereTailArgs = append(whereTailArgs, f.Interface())
+		placeholder := querier.Dialect.Placeholder(placeholderCounter)
+		value := f.Interface()
+		if dialect, ok := querier.Dialect.(JSONDialect); ok && isJSONField(tag, imitateGorm) {
+			placeholder = dialect.JSONPlaceholder(placeholder)
+			value = JSON{V: value}
+		}
+		if isArrayField(tag, imitateGorm) {
+			value = Array{V: value}
+		}
+		if mode, er := encryptionOption(tag, imitateGorm); er != nil || mode == EncryptionRandom {
+			if er == nil {
+				er = fmt.Errorf("reform: field %s is encrypted without deterministic mode and can't be used in filter", vTF.Name)
+			}
+			err = er
+			return
+		} else if mode == EncryptionDeterministic {
+			value = Encrypted{V: value, Deterministic: true, Table: table, Column: columnName}
+		}
+		whereTailStringParts = append(whereTailStringParts, querier.EscapeTableName(columnName)+" = "+placeholder)
+		whereTailArgs = append(whereTailArgs, value)
 	}
 
 	tail = strings.Join(whereTailStringParts, " AND ")
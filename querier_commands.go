@@ -55,6 +55,7 @@ func (q *Querier) insertOrReplace(cmdStr string, str Struct, columns []string, v
 		columns[i] = q.QuoteIdentifier(c)
 	}
 	placeholders := q.Placeholders(1, len(columns))
+	values = q.writeValues(values)
 
 	view := str.View()
 	record, _ := str.(Record)
@@ -82,6 +83,7 @@ func (q *Querier) insertOrReplace(cmdStr string, str Struct, columns []string, v
 	if record != nil && lastInsertIdMethod == Returning {
 		query += fmt.Sprintf(" RETURNING %s", q.QuoteIdentifier(view.Columns()[pk]))
 	}
+	defer q.invalidateQueries(view)
 
 	switch lastInsertIdMethod {
 	case LastInsertId:
@@ -113,7 +115,10 @@ func (q *Querier) insertOrReplace(cmdStr string, str Struct, columns []string, v
 }
 
 func (q *Querier) beforeInsert(str Struct) error {
-	return q.callStructMethod(str, "BeforeInsert")
+	if err := q.callStructMethod(str, "BeforeInsert"); err != nil {
+		return err
+	}
+	return q.stampTenant(str)
 }
 
 func (q *Querier) afterInsert(str Struct) error {
@@ -124,6 +129,9 @@ func (q *Querier) insertOrReplaceWrapper(cmdStr string, str Struct) error {
 	if err := q.beforeInsert(str); err != nil {
 		return err
 	}
+	if _, err := q.generatePK(str); err != nil {
+		return err
+	}
 
 	view := str.View()
 	values := str.Values()
@@ -141,6 +149,10 @@ func (q *Querier) insertOrReplaceWrapper(cmdStr string, str Struct) error {
 	}
 
 	err := q.insertOrReplace(cmdStr, str, columns, values)
+	if record != nil && cmdStr == "REPLACE" {
+		// replaced row may be cached
+		q.invalidateRecord(record.Table(), record.PKValue())
+	}
 
 	if err == nil {
 		return q.afterInsert(str)
@@ -178,6 +190,30 @@ func (q *Querier) InsertColumns(str Struct, columns ...string) error {
 		return err
 	}
 
+	generated, err := q.generatePK(str)
+	if err != nil {
+		return err
+	}
+	if generated {
+		pkColumn := str.View().Columns()[str.(Record).Table().PKColumnIndex()]
+		var found bool
+		for _, c := range columns {
+			found = found || c == pkColumn
+		}
+		if !found {
+			columns = append(columns, pkColumn)
+		}
+	}
+	if field, _, _ := q.tenantFilter(str.View()); field != nil {
+		var found bool
+		for _, c := range columns {
+			found = found || c == field.Column
+		}
+		if !found {
+			columns = append(columns, field.Column)
+		}
+	}
+
 	columns, values, err := filteredColumnsAndValues(str, columns, false)
 	if err != nil {
 		return err
@@ -197,7 +233,8 @@ func (q *Querier) InsertColumns(str Struct, columns ...string) error {
 //
 // All structs should belong to the same view/table.
 // All records should either have or not have primary key set.
-// It doesn't fill primary key fields.
+// It doesn't fill primary key fields generated by database; primary keys of tables
+// with "pkgen:" option are generated before insert, so they are filled.
 // Given all these limitations, most users should use Querier.Insert in a loop, not this method.
 func (q *Querier) InsertMulti(structs ...Struct) error {
 	if len(structs) == 0 {
@@ -213,10 +250,13 @@ func (q *Querier) InsertMulti(structs ...Struct) error {
 	}
 
 	for _, str := range structs {
-		err := q.callStructMethod(str, "BeforeInsert")
+		err := q.beforeInsert(str)
 		if err != nil {
 			return err
 		}
+		if _, err = q.generatePK(str); err != nil {
+			return err
+		}
 	}
 
 	// check if all PK are present or all are absent
@@ -259,10 +299,11 @@ func (q *Querier) InsertMulti(structs ...Struct) error {
 		if record != nil && !record.HasPK() {
 			v = append(v[:pk], v[pk+1:]...)
 		}
-		values = append(values, v...)
+		values = append(values, q.writeValues(v)...)
 	}
 
 	_, err := q.Exec(query, values...)
+	q.invalidateQueries(view)
 	return err
 }
 
@@ -284,9 +325,19 @@ func (q *Querier) update(record Record, columns []string, values []interface{})
 		q.QuoteIdentifier(table.Columns()[table.PKColumnIndex()]),
 		q.Placeholder(len(columns)+1),
 	)
+	args := append(q.writeValues(values), record.PKValue())
+
+	field, tenant, err := q.tenantFilter(table)
+	if err != nil {
+		return err
+	}
+	if field != nil {
+		query += fmt.Sprintf(" AND %s = %s", q.QuoteIdentifier(field.Column), q.Placeholder(len(args)+1))
+		args = append(args, tenant)
+	}
 
-	args := append(values, record.PKValue())
 	res, err := q.Exec(query, args...)
+	q.invalidateRecord(table, record.PKValue())
 	if err != nil {
 		return err
 	}
@@ -308,7 +359,10 @@ func (q *Querier) beforeUpdate(record Record) error {
 		return ErrNoPK
 	}
 
-	return q.callStructMethod(record, "BeforeUpdate")
+	if err := q.callStructMethod(record, "BeforeUpdate"); err != nil {
+		return err
+	}
+	return q.stampTenant(record)
 }
 
 func (q *Querier) afterUpdate(record Record) error {
@@ -419,8 +473,19 @@ func (q *Querier) Delete(record Record) error {
 		q.QuoteIdentifier(table.Columns()[pk]),
 		q.Placeholder(1),
 	)
+	args := []interface{}{record.PKValue()}
+
+	field, tenant, err := q.tenantFilter(table)
+	if err != nil {
+		return err
+	}
+	if field != nil {
+		query += fmt.Sprintf(" AND %s = %s", q.QuoteIdentifier(field.Column), q.Placeholder(2))
+		args = append(args, tenant)
+	}
 
-	res, err := q.Exec(query, record.PKValue())
+	res, err := q.Exec(query, args...)
+	q.invalidateRecord(table, record.PKValue())
 	if err != nil {
 		return err
 	}
@@ -447,7 +512,33 @@ func (q *Querier) DeleteFrom(view View, tail string, args ...interface{}) (uint,
 		tail,
 	)
 
+	field, tenant, err := q.tenantFilter(view)
+	if err != nil {
+		return 0, err
+	}
+	if field != nil {
+		// tail may contain ORDER BY and LIMIT, so rows are selected by the subquery from rows
+		// of the current tenant, the same way as by SELECT;
+		// it is wrapped twice because MySQL can't select from the table being deleted from
+		t, ok := view.(Table)
+		if !ok {
+			return 0, fmt.Errorf("reform: can't delete from view %s with tenant field", view.Name())
+		}
+		table, pk := q.QualifiedView(view), q.QuoteIdentifier(view.Columns()[t.PKColumnIndex()])
+		var placeholder string
+		placeholder, args = q.argBefore(tenant, args)
+		query = fmt.Sprintf("%s FROM %s WHERE %s IN (SELECT %s FROM (SELECT %s FROM (SELECT * FROM %s WHERE %s = %s) AS %s %s) AS %s)",
+			q.startQuery("DELETE"),
+			table,
+			pk, pk, pk,
+			table, q.QuoteIdentifier(field.Column), placeholder, q.QuoteIdentifier(q.viewName(view)),
+			tail,
+			q.QuoteIdentifier("reform_tenant_rows"),
+		)
+	}
+
 	res, err := q.Exec(query, args...)
+	q.InvalidateTable(view)
 	if err != nil {
 		return 0, err
 	}
@@ -25,19 +25,19 @@ func (q *Querier) NextRow(str Struct, rows *sql.Rows) error {
 		return err
 	}
 
-	if err = rows.Scan(str.Pointers()...); err != nil {
+	if err = q.ScanRow(rows, str, nil); err != nil {
 		return err
 	}
 
 	return q.callStructMethod(str, "AfterFind")
 }
 
-// selectQuery returns full SELECT query for given view and tail.
-func (q *Querier) selectQuery(view View, tail string, limit1 bool, forceAnotherTable *string, forceFields []string) string {
-	queryStart := q.startQuery("SELECT")
-
-	if limit1 && q.SelectLimitMethod() == SelectTop {
-		queryStart += " TOP 1"
+// selectQuery returns full SELECT query and its arguments for given view, tail and args.
+// For views with tenant field, rows are selected from the subquery limited to the current tenant.
+func (q *Querier) selectQuery(view View, tail string, args []interface{}, limit1 bool, forceAnotherTable *string, forceFields []string) (string, []interface{}, error) {
+	field, _, err := q.tenantFilter(view)
+	if err != nil {
+		return "", nil, err
 	}
 
 	var columnsQuoted []string
@@ -49,19 +49,58 @@ func (q *Querier) selectQuery(view View, tail string, limit1 bool, forceAnotherT
 			}
 			columnsQuoted = append(columnsQuoted, q.QuoteIdentifier(column))
 		}
+	} else if field != nil {
+		// columns of the subquery can't be qualified with schema
+		alias := q.QuoteIdentifier(q.viewName(view))
+		for _, c := range view.Columns() {
+			columnsQuoted = append(columnsQuoted, alias+"."+q.QuoteIdentifier(c))
+		}
 	} else {
 		columnsQuoted = q.QualifiedColumns(view)
 	}
-	columnsQuery := strings.Join(columnsQuoted, ", ")
+
+	return q.selectColumnsQuery(view, strings.Join(columnsQuoted, ", "), nil, tail, args, limit1, forceAnotherTable)
+}
+
+// selectColumnsQuery returns full SELECT query of columns expression and its arguments for given view,
+// columnsArgs, tail and args. For views with tenant field, rows are selected from the subquery limited
+// to the current tenant.
+func (q *Querier) selectColumnsQuery(view View, columns string, columnsArgs []interface{}, tail string, args []interface{}, limit1 bool, forceAnotherTable *string) (string, []interface{}, error) {
+	field, tenant, err := q.tenantFilter(view)
+	if err != nil {
+		return "", nil, err
+	}
+	if field != nil && forceAnotherTable != nil {
+		return "", nil, fmt.Errorf("reform: can't limit custom table query of %s to tenant, use WithoutTenant()", view.Name())
+	}
+
+	queryStart := q.startQuery("SELECT")
+
+	if limit1 && q.SelectLimitMethod() == SelectTop {
+		queryStart += " TOP 1"
+	}
 
 	var tableQuery string
-	if forceAnotherTable == nil {
-		tableQuery = q.QualifiedView(view)
-	} else {
+	switch {
+	case forceAnotherTable != nil:
 		tableQuery = *forceAnotherTable
+	case field != nil:
+		var placeholder string
+		placeholder, args = q.argBefore(tenant, args)
+		if q.Placeholder(1) != q.Placeholder(2) {
+			// numbered placeholders also count columnsArgs
+			placeholder = q.Placeholder(len(columnsArgs) + len(args))
+		}
+		tableQuery = fmt.Sprintf("(SELECT * FROM %s WHERE %s = %s) AS %s",
+			q.QualifiedView(view), q.QuoteIdentifier(field.Column), placeholder, q.QuoteIdentifier(q.viewName(view)))
+	default:
+		tableQuery = q.QualifiedView(view)
 	}
 
-	return fmt.Sprintf("%s %s FROM %s %s", queryStart, columnsQuery, tableQuery, tail)
+	if len(columnsArgs) > 0 {
+		args = append(columnsArgs[:len(columnsArgs):len(columnsArgs)], args...)
+	}
+	return fmt.Sprintf("%s %s FROM %s %s", queryStart, columns, tableQuery, tail), args, nil
 }
 
 // FlexSelectOneTo queries str's View with tail, args, forceAnotherTable and forceFields and scans first result to str.
@@ -70,14 +109,17 @@ func (q *Querier) selectQuery(view View, tail string, limit1 bool, forceAnotherT
 // If there are no rows in result, it returns ErrNoRows. It also may return QueryRow(), Scan()
 // and AfterFind() errors.
 func (q *Querier) FlexSelectOneTo(str Struct, forceAnotherTable *string, forceFields []string, tail string, args ...interface{}) error {
-	query := q.selectQuery(str.View(), tail, true, forceAnotherTable, forceFields)
+	query, args, err := q.selectQuery(str.View(), tail, args, true, forceAnotherTable, forceFields)
+	if err != nil {
+		return err
+	}
 	for {
-		err := q.QueryRow(query, args...).Scan(str.FieldPointersByNames(forceFields)...)
+		err := q.QueryRow(query, args...).Scan(q.scanPointers(str.FieldPointersByNames(forceFields))...)
 		if err == mysqlDriver.ErrInvalidConn {
 			continue
 		}
 		if err != nil {
-			return err
+			return scanError(str.View(), err)
 		}
 		break
 	}
@@ -114,7 +156,23 @@ func (q *Querier) SelectOneFrom(view View, tail string, args ...interface{}) (St
 //
 // See example for idiomatic usage.
 func (q *Querier) FlexSelectRows(view View, forceAnotherTable *string, forceFields []string, tail string, args ...interface{}) (*sql.Rows, error) {
-	query := q.selectQuery(view, tail, false, forceAnotherTable, forceFields)
+	query, args, err := q.selectQuery(view, tail, args, false, forceAnotherTable, forceFields)
+	if err != nil {
+		return nil, err
+	}
+	return q.Query(query, args...)
+}
+
+// FlexSelectColumnsRows queries columns expression (like "COUNT(*), MAX(id)") of view with tail, args
+// and forceAnotherTable and returns rows. columnsArgs are arguments for placeholders in columns.
+// Like other selects, it is limited to the current tenant. It is caller's responsibility to call rows.Close().
+//
+// In case of error rows will be nil. Error is never ErrNoRows.
+func (q *Querier) FlexSelectColumnsRows(view View, forceAnotherTable *string, columns string, columnsArgs []interface{}, tail string, args ...interface{}) (*sql.Rows, error) {
+	query, args, err := q.selectColumnsQuery(view, columns, columnsArgs, tail, args, false, forceAnotherTable)
+	if err != nil {
+		return nil, err
+	}
 	return q.Query(query, args...)
 }
 
@@ -125,10 +183,25 @@ func (q *Querier) FlexSelectRows(view View, forceAnotherTable *string, forceFiel
 //
 // See example for idiomatic usage.
 func (q *Querier) SelectRows(view View, tail string, args ...interface{}) (*sql.Rows, error) {
-	query := q.selectQuery(view, tail, false, nil, nil)
+	query, args, err := q.selectQuery(view, tail, args, false, nil, nil)
+	if err != nil {
+		return nil, err
+	}
 	return q.Query(query, args...)
 }
 
+// Count returns the number of rows of view with tail and args. Tail should not contain ORDER BY.
+func (q *Querier) Count(view View, tail string, args ...interface{}) (int, error) {
+	query, args, err := q.selectQuery(view, tail, args, false, nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	var count int
+	query = fmt.Sprintf("%s COUNT(*) FROM (%s) AS %s", q.startQuery("SELECT"), query, q.QuoteIdentifier("reform_count"))
+	err = q.QueryRow(query, args...).Scan(&count)
+	return count, err
+}
+
 // SelectAllFrom queries view with tail and args and returns a slice of new Structs.
 // If view's Struct has valid method "AfterFind", it also calls AfterFind().
 //
@@ -184,7 +257,7 @@ func (q *Querier) findTail(view string, column string, arg interface{}, limit1 b
 // If there are no rows in result, it returns ErrNoRows. It also may return QueryRow(), Scan()
 // and AfterFind() errors.
 func (q *Querier) FindOneTo(str Struct, column string, arg interface{}) error {
-	tail, needArg := q.findTail(str.View().Name(), column, arg, true)
+	tail, needArg := q.findTail(q.viewName(str.View()), column, arg, true)
 	if needArg {
 		return q.SelectOneTo(str, tail, arg)
 	}
@@ -197,7 +270,7 @@ func (q *Querier) FindOneTo(str Struct, column string, arg interface{}) error {
 // If there are no rows in result, it returns nil, ErrNoRows. It also may return QueryRow(), Scan()
 // and AfterFind() errors.
 func (q *Querier) FindOneFrom(view View, column string, arg interface{}) (Struct, error) {
-	tail, needArg := q.findTail(view.Name(), column, arg, true)
+	tail, needArg := q.findTail(q.viewName(view), column, arg, true)
 	if needArg {
 		return q.SelectOneFrom(view, tail, arg)
 	}
@@ -211,7 +284,7 @@ func (q *Querier) FindOneFrom(view View, column string, arg interface{}) (Struct
 //
 // See SelectRows example for idiomatic usage.
 func (q *Querier) FindRows(view View, column string, arg interface{}) (*sql.Rows, error) {
-	tail, needArg := q.findTail(view.Name(), column, arg, false)
+	tail, needArg := q.findTail(q.viewName(view), column, arg, false)
 	if needArg {
 		return q.SelectRows(view, tail, arg)
 	}
@@ -225,7 +298,7 @@ func (q *Querier) FindRows(view View, column string, arg interface{}) (*sql.Rows
 // partial result and error will be returned. Error is never ErrNoRows.
 func (q *Querier) FindAllFrom(view View, column string, args ...interface{}) ([]Struct, error) {
 	p := strings.Join(q.Placeholders(1, len(args)), ", ")
-	qi := q.QualifiedView(view) + "." + q.QuoteIdentifier(column)
+	qi := q.QuoteIdentifier(q.viewName(view)) + "." + q.QuoteIdentifier(column)
 	tail := fmt.Sprintf("WHERE %s IN (%s)", qi, p)
 	return q.SelectAllFrom(view, tail, args...)
 }
@@ -235,9 +308,25 @@ func (q *Querier) FindAllFrom(view View, column string, args ...interface{}) ([]
 //
 // If there are no rows in result, it returns ErrNoRows. It also may return QueryRow(), Scan()
 // and AfterFind() errors.
+//
+// If DB has record cache (see DB.UseRecordCache), record may be copied from the cache.
 func (q *Querier) FindByPrimaryKeyTo(record Record, pk interface{}) error {
+	var key string
+	if q.useRecordCache() {
+		key = q.recordCacheKey(record.Table(), pk)
+		if ok, err := q.cachedRecord(record, key); ok {
+			return err
+		}
+	}
+
 	table := record.Table()
-	return q.FindOneTo(record, table.Columns()[table.PKColumnIndex()], pk)
+	if err := q.FindOneTo(record, table.Columns()[table.PKColumnIndex()], pk); err != nil {
+		return err
+	}
+	if key != "" {
+		q.cacheRecord(record, key)
+	}
+	return nil
 }
 
 // FindByPrimaryKeyFrom queries table with primary key and scans first result to new Record.
@@ -247,7 +336,7 @@ func (q *Querier) FindByPrimaryKeyTo(record Record, pk interface{}) error {
 // and AfterFind() errors.
 func (q *Querier) FindByPrimaryKeyFrom(table Table, pk interface{}) (Record, error) {
 	record := table.NewRecord()
-	if err := q.FindOneTo(record, table.Columns()[table.PKColumnIndex()], pk); err != nil {
+	if err := q.FindByPrimaryKeyTo(record, pk); err != nil {
 		return nil, err
 	}
 	return record, nil
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/xaionaro/reform"
+	"github.com/xaionaro/reform/parse"
+)
+
+var (
+	diffFlags = flag.NewFlagSet("diff", flag.ExitOnError)
+)
+
+func init() {
+	diffFlags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "`diff` command compares Go models with existing database schema.\n\n")
+		fmt.Fprintf(os.Stderr, "Usage:\n")
+		fmt.Fprintf(os.Stderr, "  %s [global flags] diff [directories or file names]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Global flags:\n")
+		flag.PrintDefaults()
+		diffFlags.PrintDefaults()
+		fmt.Fprintf(os.Stderr, `
+It parses Go files (all non-test files in given directories, or in the current
+directory if nothing is given) for structs with magic reform comments, and
+compares tables with existing ones. Missing tables and columns, type,
+nullability, unique, index and primary key mismatches are printed as SQL
+comments followed by ALTER TABLE/CREATE INDEX statements, ready to be used
+as a new migration file. Statements dropping data are never emitted.
+`)
+	}
+}
+
+// goFiles returns Go files to parse from given arguments.
+func goFiles(args []string) []string {
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+
+	var files []string
+	for _, arg := range args {
+		fi, err := os.Stat(arg)
+		if err != nil {
+			logger.Fatalf("%s", err)
+		}
+		if !fi.IsDir() {
+			files = append(files, arg)
+			continue
+		}
+
+		matches, err := filepath.Glob(filepath.Join(arg, "*.go"))
+		if err != nil {
+			logger.Fatalf("%s", err)
+		}
+		for _, m := range matches {
+			if strings.HasSuffix(m, "_test.go") || strings.HasSuffix(m, "_reform.go") {
+				continue
+			}
+			files = append(files, m)
+		}
+	}
+	return files
+}
+
+// cmdDiff implements diff command.
+func cmdDiff(db *reform.DB, args []string) {
+	var changed bool
+	for _, f := range goFiles(args) {
+		logger.Debugf("parsing file %s", f)
+		structs, err := parse.File(f)
+		if err != nil {
+			logger.Fatalf("failed to parse %s: %s", f, err)
+		}
+
+		for _, s := range structs {
+			if !s.IsTable() {
+				continue
+			}
+
+			diff, err := db.DiffSchema(s)
+			if err != nil {
+				logger.Fatalf("failed to compare %s with table %s: %s", s.Type, s.SQLName, err)
+			}
+			if len(diff.Changes) == 0 {
+				continue
+			}
+
+			if changed {
+				fmt.Println()
+			}
+			fmt.Print(diff)
+			changed = true
+		}
+	}
+
+	if !changed {
+		fmt.Println("-- no changes")
+	}
+}
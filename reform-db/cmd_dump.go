@@ -0,0 +1,228 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/xaionaro/reform"
+)
+
+var (
+	dumpFlags   = flag.NewFlagSet("dump", flag.ExitOnError)
+	dumpTableF  = dumpFlags.String("table", "", "Table name, optionally with schema (required)")
+	dumpFormatF = dumpFlags.String("format", "csv", "Output format: csv, jsonl or sql")
+	dumpWhereF  = dumpFlags.String("where", "", "SQL condition selecting dumped rows")
+	dumpNullF   = dumpFlags.String("null", `\N`, "NULL representation in CSV")
+)
+
+func init() {
+	dumpFlags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "`dump` command writes rows of a table to stdout.\n\n")
+		fmt.Fprintf(os.Stderr, "Usage:\n")
+		fmt.Fprintf(os.Stderr, "  %s [global flags] dump [dump flags]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Global flags:\n")
+		flag.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nDump flags:\n")
+		dumpFlags.PrintDefaults()
+		fmt.Fprintf(os.Stderr, `
+Rows are ordered by primary key, if any. CSV starts with a header of column
+names; NULL is written as -null value, binary values as \x and hex digits,
+time values in RFC 3339 format. JSON Lines contain one object per row with
+binary values in base64. SQL contains one INSERT statement per row for the
+current database dialect.
+`)
+	}
+}
+
+// tableName is a table given by -table flag of dump and load commands.
+type tableName struct {
+	schema string
+	name   string
+}
+
+// parseTableName parses "table" or "schema.table".
+func parseTableName(s string) tableName {
+	if s == "" {
+		logger.Fatalf("please set -table flag.")
+	}
+	if i := strings.LastIndex(s, "."); i >= 0 {
+		return tableName{schema: s[:i], name: s[i+1:]}
+	}
+	return tableName{name: s}
+}
+
+// quoted returns quoted table name for queries.
+func (t tableName) quoted(db interface{ QuoteIdentifier(string) string }) string {
+	if t.schema == "" {
+		return db.QuoteIdentifier(t.name)
+	}
+	return db.QuoteIdentifier(t.schema) + "." + db.QuoteIdentifier(t.name)
+}
+
+// inspect returns schema of existing table.
+func (t tableName) inspect(db *reform.DB) *reform.TableSchema {
+	dialect, ok := db.Dialect.(reform.SchemaDialect)
+	if !ok {
+		logger.Fatalf("dialect %s does not support schema inspection", db.Dialect)
+	}
+	s, err := dialect.InspectTable(db, t.schema, t.name)
+	if err != nil {
+		logger.Fatalf("failed to inspect table %s: %s", t.name, err)
+	}
+	if s == nil {
+		logger.Fatalf("table %s does not exist", t.name)
+	}
+	return s
+}
+
+// rowWriter writes dumped rows.
+type rowWriter interface {
+	writeRow(values []interface{}) error
+	flush() error
+}
+
+type csvRowWriter struct {
+	w     *csv.Writer
+	kinds []valueKind
+	null  string
+}
+
+func (w *csvRowWriter) writeRow(values []interface{}) error {
+	record := make([]string, len(values))
+	for i, v := range values {
+		s, ok := textValue(v, w.kinds[i])
+		if !ok {
+			s = w.null
+		}
+		record[i] = s
+	}
+	return w.w.Write(record)
+}
+
+func (w *csvRowWriter) flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+type jsonlRowWriter struct {
+	w       *bufio.Writer
+	columns []string
+	kinds   []valueKind
+}
+
+func (w *jsonlRowWriter) writeRow(values []interface{}) error {
+	converted := make([]interface{}, len(values))
+	for i, v := range values {
+		converted[i] = jsonValue(v, w.kinds[i])
+	}
+	object, err := jsonObject(w.columns, converted)
+	if err != nil {
+		return err
+	}
+	if _, err = w.w.Write(object); err != nil {
+		return err
+	}
+	return w.w.WriteByte('\n')
+}
+
+func (w *jsonlRowWriter) flush() error {
+	return w.w.Flush()
+}
+
+type sqlRowWriter struct {
+	w       *bufio.Writer
+	dialect reform.Dialect
+	prefix  string
+	kinds   []valueKind
+}
+
+func (w *sqlRowWriter) writeRow(values []interface{}) error {
+	literals := make([]string, len(values))
+	for i, v := range values {
+		literals[i] = sqlLiteral(w.dialect, v, w.kinds[i])
+	}
+	_, err := fmt.Fprintf(w.w, "%s(%s);\n", w.prefix, strings.Join(literals, ", "))
+	return err
+}
+
+func (w *sqlRowWriter) flush() error {
+	return w.w.Flush()
+}
+
+// cmdDump implements dump command.
+func cmdDump(db *reform.DB, out io.Writer) {
+	table := parseTableName(*dumpTableF)
+	s := table.inspect(db)
+
+	columns := make([]string, len(s.Columns))
+	quoted := make([]string, len(s.Columns))
+	kinds := make([]valueKind, len(s.Columns))
+	for i, c := range s.Columns {
+		columns[i] = c.Name
+		quoted[i] = db.QuoteIdentifier(c.Name)
+		kinds[i] = kindOfType(c.Type)
+	}
+
+	var w rowWriter
+	switch *dumpFormatF {
+	case "csv":
+		cw := csv.NewWriter(out)
+		if err := cw.Write(columns); err != nil {
+			logger.Fatalf("%s", err)
+		}
+		w = &csvRowWriter{w: cw, kinds: kinds, null: *dumpNullF}
+	case "jsonl":
+		w = &jsonlRowWriter{w: bufio.NewWriter(out), columns: columns, kinds: kinds}
+	case "sql":
+		prefix := fmt.Sprintf("INSERT INTO %s (%s) VALUES ", table.quoted(db), strings.Join(quoted, ", "))
+		w = &sqlRowWriter{w: bufio.NewWriter(out), dialect: db.Dialect, prefix: prefix, kinds: kinds}
+	default:
+		logger.Fatalf("unexpected format %q, expected csv, jsonl or sql", *dumpFormatF)
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s", strings.Join(quoted, ", "), table.quoted(db))
+	if *dumpWhereF != "" {
+		query += " WHERE " + *dumpWhereF
+	}
+	if pk := s.PrimaryKey(); pk != nil {
+		order := make([]string, len(pk.Columns))
+		for i, c := range pk.Columns {
+			order[i] = db.QuoteIdentifier(c)
+		}
+		query += " ORDER BY " + strings.Join(order, ", ")
+	}
+
+	rows, err := db.Query(query)
+	if err != nil {
+		logger.Fatalf("failed to query %s: %s", query, err)
+	}
+	defer rows.Close()
+
+	values := make([]interface{}, len(columns))
+	dests := make([]interface{}, len(columns))
+	for i := range dests {
+		dests[i] = &values[i]
+	}
+	var n int
+	for rows.Next() {
+		if err = rows.Scan(dests...); err != nil {
+			logger.Fatalf("%s", err)
+		}
+		if err = w.writeRow(values); err != nil {
+			logger.Fatalf("%s", err)
+		}
+		n++
+	}
+	if err = rows.Err(); err != nil {
+		logger.Fatalf("%s", err)
+	}
+	if err = w.flush(); err != nil {
+		logger.Fatalf("%s", err)
+	}
+	logger.Debugf("dumped %d rows", n)
+}
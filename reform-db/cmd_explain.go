@@ -0,0 +1,51 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/xaionaro/reform"
+)
+
+var (
+	explainFlags = flag.NewFlagSet("explain", flag.ExitOnError)
+	explainRawF  = explainFlags.Bool("raw", false, "Print plan as returned by the database (JSON, XML or text)")
+)
+
+func init() {
+	explainFlags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "`explain` command prints execution plans of SQL queries from given files or stdin.\n\n")
+		fmt.Fprintf(os.Stderr, "Usage:\n")
+		fmt.Fprintf(os.Stderr, "  %s [global flags] explain [explain flags] [file names]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Global flags:\n")
+		flag.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nExplain flags:\n")
+		explainFlags.PrintDefaults()
+		fmt.Fprintf(os.Stderr, `
+Each file's content is explained as a single query. If file names are not given,
+a query is read from stdin until EOF, then explained. Queries are not executed.
+Full table scans and missing indexes are marked in the output.
+`)
+	}
+}
+
+// cmdExplain implements explain command.
+func cmdExplain(db *reform.DB, files []string) {
+	queries := readFiles(files)
+	for i, q := range queries {
+		plan, err := db.Explain(q)
+		if err != nil {
+			logger.Fatalf("failed to explain %s: %s", q, err)
+		}
+
+		if i > 0 {
+			fmt.Println()
+		}
+		if *explainRawF {
+			fmt.Println(plan.Raw)
+		} else {
+			fmt.Print(plan)
+		}
+	}
+}
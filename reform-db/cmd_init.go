@@ -8,6 +8,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"unicode"
 
 	"github.com/xaionaro/reform"
 	"github.com/xaionaro/reform/dialects/mssql"
@@ -78,6 +79,26 @@ func convertName(sqlName string) string {
 	return strings.Join(res, "")
 }
 
+// newEnumData returns EnumData for given database enum values with constant names derived from them.
+func newEnumData(typ, sqlName string, values []string) *EnumData {
+	res := &EnumData{Type: typ, SQLName: sqlName, Values: values}
+	seen := make(map[string]bool)
+	for i, v := range values {
+		name := typ + convertName(strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return unicode.ToLower(r)
+			}
+			return '_'
+		}, v))
+		if name == typ || seen[name] {
+			name = fmt.Sprintf("%s%d", name, i)
+		}
+		seen[name] = true
+		res.Consts = append(res.Consts, name)
+	}
+	return res
+}
+
 // getPrimaryKeyColumn returns single primary key column for given table, or nil.
 func getPrimaryKeyColumn(db *reform.DB, catalog, schema, tableName string) *keyColumnUsage {
 	using := []string{"table_catalog", "table_schema", "table_name"}
@@ -119,7 +140,9 @@ func initModelsInformationSchema(db *reform.DB, tablesTail string, typeFunc type
 		logger.Fatalf("%s", err)
 	}
 
+	enums := make(map[string]bool)
 	for _, t := range tables {
+		var data StructData
 		imports := make(map[string]struct{})
 		table := t.(*table)
 		str := parse.StructInfo{
@@ -141,15 +164,38 @@ func initModelsInformationSchema(db *reform.DB, tablesTail string, typeFunc type
 		}
 		for i, c := range columns {
 			column := c.(*column)
-			typ, pack, comment := typeFunc(column.Type, bool(column.IsNullable))
+			sqlType := column.Type
+			if sqlType == "ARRAY" && db.Dialect == postgresql.Dialect {
+				// information_schema doesn't describe element type
+				sqlType = postgresArrayType(db, column)
+			}
+			typ, pack, comment := typeFunc(sqlType, bool(column.IsNullable))
+
+			var enum *EnumData
+			switch {
+			case sqlType == "enum" && db.Dialect == mysql.Dialect:
+				enum = mysqlEnum(db, column)
+			case sqlType == "USER-DEFINED" && db.Dialect == postgresql.Dialect:
+				enum = postgresEnum(db, column)
+			}
+			if enum != nil {
+				typ, pack, comment = maybePointer(enum.Type, bool(column.IsNullable)), "", ""
+				// enum types may be shared by several tables
+				if !enums[enum.Type] {
+					enums[enum.Type] = true
+					data.Enums = append(data.Enums, *enum)
+				}
+			}
+
 			if pack != "" {
 				imports[pack] = struct{}{}
 			}
 			comments = append(comments, comment)
 			str.Fields = append(str.Fields, parse.FieldInfo{
-				Name:   convertName(column.Name),
-				Type:   typ,
-				Column: column.Name,
+				Name:    convertName(column.Name),
+				Type:    typ,
+				Column:  column.Name,
+				IsArray: column.Type == "ARRAY",
 			})
 
 			if key != nil && key.ColumnName == column.Name {
@@ -157,35 +203,49 @@ func initModelsInformationSchema(db *reform.DB, tablesTail string, typeFunc type
 			}
 		}
 
-		structs = append(structs, StructData{
-			Imports:       imports,
-			StructInfo:    str,
-			FieldComments: comments,
-		})
+		data.Imports = imports
+		data.StructInfo = str
+		data.FieldComments = comments
+		structs = append(structs, data)
 	}
 
 	return
 }
 
-// cmdInit implements init command.
-func cmdInit(db *reform.DB, dir string) {
-	var structs []StructData
-	switch db.Dialect {
+// currentSchema returns information_schema condition selecting tables of current schema,
+// or empty string for dialects without information_schema.
+func currentSchema(dialect reform.Dialect) string {
+	switch dialect {
 	case postgresql.Dialect:
 		// catalog is a currently selected database (reform-database, postgres, template0, etc.)
 		// schema is a PostgreSQL schema (public, pg_catalog, information_schema, etc.)
-		structs = initModelsInformationSchema(db, `WHERE table_schema = current_schema()`, goTypePostgres)
+		return "table_schema = current_schema()"
 	case mysql.Dialect:
 		// catalog is always "def"
 		// schema is a database name (reform-database, information_schema, performance_schema, mysql, sys, etc.)
-		structs = initModelsInformationSchema(db, `WHERE table_schema = DATABASE()`, goTypeMySQL)
+		return "table_schema = DATABASE()"
+	case mssql.Dialect, sqlserver.Dialect:
+		// catalog is a currently selected database (reform-database, master, etc.)
+		// schema is MS SQL schema (dbo, guest, sys, information_schema, etc.)
+		return "table_schema = SCHEMA_NAME()"
+	default:
+		return ""
+	}
+}
+
+// cmdInit implements init command.
+func cmdInit(db *reform.DB, dir string) {
+	var structs []StructData
+	switch db.Dialect {
+	case postgresql.Dialect:
+		structs = initModelsInformationSchema(db, "WHERE "+currentSchema(db.Dialect), goTypePostgres)
+	case mysql.Dialect:
+		structs = initModelsInformationSchema(db, "WHERE "+currentSchema(db.Dialect), goTypeMySQL)
 	case sqlite3.Dialect:
 		// SQLite is special
 		structs = initModelsSQLite3(db)
 	case mssql.Dialect, sqlserver.Dialect:
-		// catalog is a currently selected database (reform-database, master, etc.)
-		// schema is MS SQL schema (dbo, guest, sys, information_schema, etc.)
-		structs = initModelsInformationSchema(db, `WHERE table_schema = SCHEMA_NAME()`, goTypeMSSQL)
+		structs = initModelsInformationSchema(db, "WHERE "+currentSchema(db.Dialect), goTypeMSSQL)
 	default:
 		logger.Fatalf("unhandled dialect %s", db.Dialect)
 	}
@@ -2,8 +2,59 @@ package main
 
 import (
 	"fmt"
+	"strings"
+
+	"github.com/xaionaro/reform"
 )
 
+// mysqlEnum returns enum type data for ENUM column.
+func mysqlEnum(db *reform.DB, column *column) *EnumData {
+	var columnType string
+	err := db.QueryRow(`SELECT column_type FROM information_schema.columns
+		WHERE table_schema = ? AND table_name = ? AND column_name = ?`,
+		column.TableSchema, column.TableName, column.Name).Scan(&columnType)
+	if err != nil {
+		logger.Fatalf("%s", err)
+	}
+	values := parseMySQLEnum(columnType)
+	if len(values) == 0 {
+		return nil
+	}
+	return newEnumData(convertName(column.TableName)+convertName(column.Name), column.TableName+"."+column.Name, values)
+}
+
+// parseMySQLEnum returns values of column type like enum('a','it”s').
+func parseMySQLEnum(columnType string) []string {
+	if !strings.HasPrefix(strings.ToLower(columnType), "enum(") {
+		return nil
+	}
+	var res []string
+	var value strings.Builder
+	var quoted bool
+	s := columnType[len("enum(") : len(columnType)-1]
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case !quoted:
+			if c == '\'' {
+				quoted = true
+				value.Reset()
+			}
+		case c == '\\' && i+1 < len(s):
+			i++
+			value.WriteByte(s[i])
+		case c == '\'' && i+1 < len(s) && s[i+1] == '\'':
+			i++
+			value.WriteByte(c)
+		case c == '\'':
+			quoted = false
+			res = append(res, value.String())
+		default:
+			value.WriteByte(c)
+		}
+	}
+	return res
+}
+
 // goTypeMySQL converts given SQL type to Go type. https://dev.mysql.com/doc/refman/5.7/en/data-types.html
 func goTypeMySQL(sqlType string, nullable bool) (typ string, pack string, comment string) {
 	switch sqlType {
@@ -2,10 +2,66 @@ package main
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/xaionaro/reform"
 )
 
+// typeModifiers matches type modifiers like (10) in character varying(10)[].
+var typeModifiers = regexp.MustCompile(`\([^)]*\)`)
+
+// postgresArrayType returns SQL type of array column like integer[].
+func postgresArrayType(db *reform.DB, column *column) string {
+	var typ string
+	err := db.QueryRow(`SELECT format_type(a.atttypid, a.atttypmod) FROM pg_attribute a
+		WHERE a.attrelid = (quote_ident($1) || '.' || quote_ident($2))::regclass AND a.attname = $3`,
+		column.TableSchema, column.TableName, column.Name).Scan(&typ)
+	if err != nil {
+		logger.Fatalf("%s", err)
+	}
+	return typeModifiers.ReplaceAllString(typ, "")
+}
+
+// postgresEnum returns enum type data for column of enum type, or nil for other user-defined types.
+func postgresEnum(db *reform.DB, column *column) *EnumData {
+	rows, err := db.Query(`SELECT t.typname, e.enumlabel FROM information_schema.columns c
+		JOIN pg_namespace n ON n.nspname = c.udt_schema
+		JOIN pg_type t ON t.typnamespace = n.oid AND t.typname = c.udt_name
+		JOIN pg_enum e ON e.enumtypid = t.oid
+		WHERE c.table_schema = $1 AND c.table_name = $2 AND c.column_name = $3
+		ORDER BY e.enumsortorder`,
+		column.TableSchema, column.TableName, column.Name)
+	if err != nil {
+		logger.Fatalf("%s", err)
+	}
+	defer rows.Close()
+
+	var name string
+	var values []string
+	for rows.Next() {
+		var value string
+		if err = rows.Scan(&name, &value); err != nil {
+			logger.Fatalf("%s", err)
+		}
+		values = append(values, value)
+	}
+	if err = rows.Err(); err != nil {
+		logger.Fatalf("%s", err)
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return newEnumData(convertName(name), name, values)
+}
+
 // goTypePostgres converts given SQL type to Go type. https://www.postgresql.org/docs/current/static/datatype.html
 func goTypePostgres(sqlType string, nullable bool) (typ string, pack string, comment string) {
+	if strings.HasSuffix(sqlType, "[]") {
+		typ, pack, comment = goTypePostgres(strings.TrimSuffix(sqlType, "[]"), false)
+		return "[]" + typ, pack, comment // never a pointer, nil is NULL
+	}
+
 	switch sqlType {
 	case "smallint", "smallserial":
 		return maybePointer("int16", nullable), "", ""
@@ -3,13 +3,22 @@ package main
 import (
 	"text/template"
 
-	"gopkg.in/reform.v1/parse"
+	"github.com/xaionaro/reform/parse"
 )
 
 type StructData struct {
 	Imports map[string]struct{}
 	parse.StructInfo
 	FieldComments []string
+	Enums         []EnumData
+}
+
+// EnumData represents Go enum type generated for database enum type.
+type EnumData struct {
+	Type    string   // Go type name, e.g. OrderStatus
+	SQLName string   // database enum type name or column definition, e.g. order_status
+	Consts  []string // Go constant names, e.g. OrderStatusPaid
+	Values  []string // database values, e.g. paid
 }
 
 var (
@@ -28,8 +37,22 @@ import (
 //reform:{{ .SQLName }}
 type {{ .Type }} struct {
 	{{- range $i, $f := .Fields }}
-    {{ $f.Name }} {{ $f.Type }} ` + "`" + `reform:"{{ $f.Column }}{{ if eq $i $.PKFieldIndex }},pk{{ end }}"` + "`" + ` {{ index $.FieldComments $i }}
+    {{ $f.Name }} {{ $f.Type }} ` + "`" + `reform:"{{ $f.Column }}{{ if eq $i $.PKFieldIndex }},pk{{ end }}{{ if $f.IsArray }},array{{ end }}"` + "`" + ` {{ index $.FieldComments $i }}
 	{{- end }}
 }
+
+{{- range $e := .Enums }}
+
+// {{ $e.Type }} represents {{ $e.SQLName }} values.
+//reform:enum
+type {{ $e.Type }} string
+
+// {{ $e.Type }} values.
+const (
+	{{- range $i, $c := $e.Consts }}
+	{{ $c }} {{ $e.Type }} = {{ printf "%q" (index $e.Values $i) }}
+	{{- end }}
+)
+{{- end }}
 `))
 )
@@ -0,0 +1,420 @@
+package main
+
+import (
+	"bufio"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/xaionaro/reform"
+	"github.com/xaionaro/reform/dialects/mssql"
+	"github.com/xaionaro/reform/dialects/mysql"
+	"github.com/xaionaro/reform/dialects/sqlserver"
+)
+
+var (
+	loadFlags       = flag.NewFlagSet("load", flag.ExitOnError)
+	loadTableF      = loadFlags.String("table", "", "Table name, optionally with schema (required)")
+	loadFormatF     = loadFlags.String("format", "csv", "Input format: csv, jsonl or sql")
+	loadMapF        = loadFlags.String("map", "", "Column mapping: input:column pairs separated by commas, input:- skips input column")
+	loadOnConflictF = loadFlags.String("on-conflict", "error", "What to do with rows conflicting with existing ones: error, ignore or update")
+	loadBatchF      = loadFlags.Int("batch", 1000, "Maximum number of rows in one INSERT statement")
+	loadNullF       = loadFlags.String("null", `\N`, "NULL representation in CSV")
+)
+
+func init() {
+	loadFlags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "`load` command inserts rows into a table from given files or stdin.\n\n")
+		fmt.Fprintf(os.Stderr, "Usage:\n")
+		fmt.Fprintf(os.Stderr, "  %s [global flags] load [load flags] [file names]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Global flags:\n")
+		flag.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nLoad flags:\n")
+		loadFlags.PrintDefaults()
+		fmt.Fprintf(os.Stderr, `
+Input should be in the format written by dump command. All rows are inserted
+in a single transaction with multi-row INSERT statements. Conflicts are
+detected by primary key and unique indexes; "update" policy updates all
+loaded columns except primary key. It is not supported for MS SQL.
+For SQL format each statement is executed as is, so -map and -on-conflict
+can't be used. Only SQL written by dump is accepted: statements end with
+semicolon at the end of line and can't contain comments; use exec command
+for other SQL files.
+`)
+	}
+}
+
+// rowLoader inserts rows into the table in batches.
+type rowLoader struct {
+	tx      *reform.TX
+	table   tableName
+	s       *reform.TableSchema
+	mapping map[string]string
+
+	columns  []string
+	kinds    []valueKind
+	prefix   string
+	suffix   string
+	size     int
+	batch    [][]interface{}
+	n        int   // number of loaded input rows (statements for SQL), including conflicting ones
+	affected int64 // number of rows reported as affected by the database
+}
+
+// parseColumnMap parses -map flag value.
+func parseColumnMap(s string) map[string]string {
+	m := make(map[string]string)
+	if s == "" {
+		return m
+	}
+	for _, pair := range strings.Split(s, ",") {
+		parts := strings.SplitN(pair, ":", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			logger.Fatalf("unexpected column mapping %q, expected input:column", pair)
+		}
+		m[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	return m
+}
+
+// column returns table column for input column, or empty string if it is skipped.
+func (l *rowLoader) column(input string) string {
+	name, ok := l.mapping[input]
+	if !ok {
+		name = input
+	}
+	if name == "-" {
+		return ""
+	}
+	c := l.s.Column(name)
+	if c == nil {
+		logger.Fatalf("table %s has no column %s, use -map flag", l.table.name, name)
+	}
+	return c.Name
+}
+
+// setColumns sets table columns of loaded rows and prepares INSERT statement.
+func (l *rowLoader) setColumns(columns []string) {
+	if len(columns) == 0 {
+		logger.Fatalf("no columns to load")
+	}
+	db := l.tx.Querier
+	l.columns = columns
+	l.kinds = make([]valueKind, len(columns))
+	quoted := make([]string, len(columns))
+	for i, c := range columns {
+		l.kinds[i] = kindOfType(l.s.Column(c).Type)
+		quoted[i] = db.QuoteIdentifier(c)
+	}
+
+	insert := "INSERT"
+	switch *loadOnConflictF {
+	case "error":
+	case "ignore", "update":
+		switch db.Dialect {
+		case mysql.Dialect:
+			if *loadOnConflictF == "ignore" {
+				insert = "INSERT IGNORE"
+			} else {
+				updates := make([]string, len(quoted))
+				for i, c := range quoted {
+					updates[i] = fmt.Sprintf("%s = VALUES(%s)", c, c)
+				}
+				l.suffix = " ON DUPLICATE KEY UPDATE " + strings.Join(updates, ", ")
+			}
+		case mssql.Dialect, sqlserver.Dialect:
+			logger.Fatalf("-on-conflict %s is not supported for %s", *loadOnConflictF, db.Dialect)
+		default:
+			l.suffix = " ON CONFLICT DO NOTHING"
+			if *loadOnConflictF == "update" {
+				l.suffix = l.onConflictUpdate(db)
+			}
+		}
+	default:
+		logger.Fatalf("unexpected -on-conflict %q, expected error, ignore or update", *loadOnConflictF)
+	}
+	l.prefix = fmt.Sprintf("%s INTO %s (%s) VALUES ", insert, l.table.quoted(db), strings.Join(quoted, ", "))
+
+	placeholders, rows := 999, 0
+	if dialect, ok := db.Dialect.(reform.BulkDialect); ok {
+		placeholders, rows = dialect.BulkInsertLimits()
+	}
+	l.size = *loadBatchF
+	if placeholders > 0 && l.size*len(columns) > placeholders {
+		l.size = placeholders / len(columns)
+	}
+	if rows > 0 && l.size > rows {
+		l.size = rows
+	}
+	if l.size < 1 {
+		l.size = 1
+	}
+}
+
+// onConflictUpdate returns ON CONFLICT clause updating loaded columns except primary key.
+func (l *rowLoader) onConflictUpdate(db *reform.Querier) string {
+	pk := l.s.PrimaryKey()
+	if pk == nil {
+		logger.Fatalf("table %s has no primary key for -on-conflict update", l.table.name)
+	}
+	keys := make([]string, len(pk.Columns))
+	for i, c := range pk.Columns {
+		keys[i] = db.QuoteIdentifier(c)
+	}
+	var updates []string
+	for _, c := range l.columns {
+		isKey := false
+		for _, k := range pk.Columns {
+			isKey = isKey || strings.EqualFold(k, c)
+		}
+		if !isKey {
+			updates = append(updates, fmt.Sprintf("%s = EXCLUDED.%s", db.QuoteIdentifier(c), db.QuoteIdentifier(c)))
+		}
+	}
+	if len(updates) == 0 {
+		return fmt.Sprintf(" ON CONFLICT (%s) DO NOTHING", strings.Join(keys, ", "))
+	}
+	return fmt.Sprintf(" ON CONFLICT (%s) DO UPDATE SET %s", strings.Join(keys, ", "), strings.Join(updates, ", "))
+}
+
+// add adds a row with values of columns.
+func (l *rowLoader) add(values []interface{}) error {
+	l.batch = append(l.batch, values)
+	if len(l.batch) < l.size {
+		return nil
+	}
+	return l.flush()
+}
+
+// flush inserts collected rows.
+func (l *rowLoader) flush() error {
+	if len(l.batch) == 0 {
+		return nil
+	}
+	rows := make([]string, len(l.batch))
+	args := make([]interface{}, 0, len(l.batch)*len(l.columns))
+	for i, values := range l.batch {
+		rows[i] = "(" + strings.Join(l.tx.Placeholders(len(args)+1, len(values)), ", ") + ")"
+		args = append(args, values...)
+	}
+	res, err := l.tx.Exec(l.prefix+strings.Join(rows, ", ")+l.suffix, args...)
+	if err != nil {
+		return err
+	}
+	if err = l.count(res); err != nil {
+		return err
+	}
+	l.n += len(l.batch)
+	l.batch = l.batch[:0]
+	return nil
+}
+
+// count adds the number of affected rows of executed statement.
+// Note that MySQL counts rows updated by ON DUPLICATE KEY UPDATE twice.
+func (l *rowLoader) count(res sql.Result) error {
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	l.affected += ra
+	return nil
+}
+
+// loadCSV loads rows from CSV with header.
+func (l *rowLoader) loadCSV(r io.Reader) error {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err != nil {
+		return err
+	}
+	var indexes []int
+	var columns []string
+	for i, input := range header {
+		if c := l.column(input); c != "" {
+			indexes = append(indexes, i)
+			columns = append(columns, c)
+		}
+	}
+	l.setColumns(columns)
+
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		values := make([]interface{}, len(indexes))
+		for i, index := range indexes {
+			if s := record[index]; s != *loadNullF {
+				values[i] = parseText(s, l.kinds[i])
+			}
+		}
+		if err = l.add(values); err != nil {
+			return err
+		}
+	}
+}
+
+// loadJSONL loads rows from JSON Lines. Columns are taken from the first object,
+// missing keys of other objects are loaded as NULL.
+func (l *rowLoader) loadJSONL(r io.Reader) error {
+	d := json.NewDecoder(r)
+	d.UseNumber()
+	var index map[string]int
+	for line := 1; ; line++ {
+		var object map[string]interface{}
+		err := d.Decode(&object)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		row := make(map[string]interface{}, len(object))
+		for input, v := range object {
+			if c := l.column(input); c != "" {
+				row[c] = v
+			}
+		}
+
+		if index == nil {
+			// keep the order of table columns
+			var columns []string
+			for _, c := range l.s.Columns {
+				if _, ok := row[c.Name]; ok {
+					columns = append(columns, c.Name)
+				}
+			}
+			l.setColumns(columns)
+			index = make(map[string]int, len(columns))
+			for i, c := range columns {
+				index[c] = i
+			}
+		}
+
+		values := make([]interface{}, len(l.columns))
+		for c, v := range row {
+			i, ok := index[c]
+			if !ok {
+				return fmt.Errorf("line %d: column %s is absent in the first line", line, c)
+			}
+			if values[i], err = parseJSON(v, l.kinds[i]); err != nil {
+				return fmt.Errorf("line %d: column %s: %s", line, c, err)
+			}
+		}
+		if err = l.add(values); err != nil {
+			return err
+		}
+	}
+}
+
+// statementEnds returns true if SQL statement ends with semicolon outside of string literal.
+// It handles only the output of dump: standard string literals with doubled quotes,
+// no comments and no escape string constants (E'...').
+func statementEnds(q string) bool {
+	return strings.HasSuffix(strings.TrimRightFunc(q, unicode.IsSpace), ";") && strings.Count(q, "'")%2 == 0
+}
+
+// loadSQL executes statements written by dump, one or more lines each.
+// Other SQL files should be executed by exec command.
+func (l *rowLoader) loadSQL(r io.Reader) error {
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 64*1024*1024)
+	var statement strings.Builder
+	for s.Scan() {
+		statement.WriteString(s.Text())
+		q := statement.String()
+		if !statementEnds(q) {
+			statement.WriteString("\n")
+			continue
+		}
+		res, err := l.tx.Exec(q)
+		if err != nil {
+			return err
+		}
+		if err = l.count(res); err != nil {
+			return err
+		}
+		l.n++
+		statement.Reset()
+	}
+	if err := s.Err(); err != nil {
+		return err
+	}
+	if strings.TrimSpace(statement.String()) != "" {
+		return fmt.Errorf("unterminated statement %q", statement.String())
+	}
+	return nil
+}
+
+// cmdLoad implements load command.
+func cmdLoad(db *reform.DB, files []string) {
+	table := parseTableName(*loadTableF)
+	l := &rowLoader{
+		table:   table,
+		s:       table.inspect(db),
+		mapping: parseColumnMap(*loadMapF),
+	}
+
+	var load func(io.Reader) error
+	switch *loadFormatF {
+	case "csv":
+		load = l.loadCSV
+	case "jsonl":
+		load = l.loadJSONL
+	case "sql":
+		if *loadMapF != "" || *loadOnConflictF != "error" {
+			logger.Fatalf("-map and -on-conflict can't be used with sql format")
+		}
+		load = l.loadSQL
+	default:
+		logger.Fatalf("unexpected format %q, expected csv, jsonl or sql", *loadFormatF)
+	}
+
+	err := db.InTransaction(func(tx *reform.TX) error {
+		l.tx = tx
+		if len(files) == 0 {
+			if err := load(os.Stdin); err != nil {
+				return fmt.Errorf("stdin: %s", err)
+			}
+			return l.flush()
+		}
+		for _, name := range files {
+			f, err := os.Open(name)
+			if err != nil {
+				return err
+			}
+			err = load(f)
+			f.Close()
+			if err == nil {
+				err = l.flush()
+			}
+			if err != nil {
+				return fmt.Errorf("%s: %s", name, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Fatalf("failed to load: %s", err)
+	}
+	switch {
+	case *loadFormatF == "sql":
+		logger.Printf("executed %d statements, %d rows affected in %s", l.n, l.affected, table.name)
+	case *loadOnConflictF == "ignore":
+		logger.Printf("loaded %d rows into %s: %d inserted, %d ignored", l.n, table.name, l.affected, int64(l.n)-l.affected)
+	case *loadOnConflictF == "update":
+		logger.Printf("loaded %d rows into %s: %d rows affected (MySQL counts updated rows twice)", l.n, table.name, l.affected)
+	default:
+		logger.Printf("loaded %d rows into %s", l.n, table.name)
+	}
+}
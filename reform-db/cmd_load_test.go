@@ -0,0 +1,73 @@
+package main
+
+import (
+	"database/sql"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xaionaro/reform"
+	"github.com/xaionaro/reform/dialects/sqlite3"
+	"github.com/xaionaro/reform/internal"
+)
+
+func TestParseColumnMap(t *testing.T) {
+	assert.Equal(t, map[string]string{}, parseColumnMap(""))
+	assert.Equal(t, map[string]string{
+		"Name":   "name",
+		"legacy": "-",
+	}, parseColumnMap("Name:name, legacy : - "))
+}
+
+func TestStatementEnds(t *testing.T) {
+	for q, ends := range map[string]bool{
+		"INSERT INTO t VALUES (1);":                    true,
+		"INSERT INTO t VALUES (1);  \t":                true,
+		"INSERT INTO t VALUES (1)":                     false,
+		"INSERT INTO t VALUES ('a;":                    false,
+		"INSERT INTO t VALUES ('a;\nb');":              true,
+		"INSERT INTO t VALUES ('it''s;');":             true,
+		"INSERT INTO t VALUES ('it''s;\n":              false,
+		"INSERT INTO t VALUES ('it''s', 'x;'), ('y');": true,
+		"": false,
+	} {
+		assert.Equal(t, ends, statementEnds(q), "%q", q)
+	}
+}
+
+func TestRowLoaderCounts(t *testing.T) {
+	logger = internal.NewLogger("reform-db-test: ", false)
+
+	sqlDB, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	defer sqlDB.Close()
+	db := reform.NewDB(sqlDB, sqlite3.Dialect, reform.NewPrintfLogger(t.Logf))
+	_, err = db.Exec(`CREATE TABLE t (id integer PRIMARY KEY, name text)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO t (id, name) VALUES (1, 'a')`)
+	require.NoError(t, err)
+
+	defer func(v string) { *loadOnConflictF = v }(*loadOnConflictF)
+	for policy, expected := range map[string]int64{"ignore": 1, "update": 2} {
+		t.Run(policy, func(t *testing.T) {
+			*loadOnConflictF = policy
+			table := parseTableName("t")
+			l := &rowLoader{table: table, s: table.inspect(db), mapping: map[string]string{}}
+			tx, err := db.Begin()
+			require.NoError(t, err)
+			defer tx.Rollback()
+			l.tx = tx
+
+			// ignored rows are loaded, but not affected
+			l.setColumns([]string{"id", "name"})
+			require.NoError(t, l.add([]interface{}{1, "b"}))
+			require.NoError(t, l.add([]interface{}{2, "c"}))
+			require.NoError(t, l.flush())
+			assert.Equal(t, 2, l.n)
+			assert.Equal(t, expected, l.affected)
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/xaionaro/reform/migrate"
+)
+
+var (
+	migrateFlags  = flag.NewFlagSet("migrate", flag.ExitOnError)
+	migrateDirF   = migrateFlags.String("dir", "migrations", "Directory with migration files")
+	migrateTableF = migrateFlags.String("table", migrate.DefaultTable, "Migrations bookkeeping table name")
+)
+
+func init() {
+	migrateFlags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "`migrate` command applies and rolls back versioned schema migrations.\n\n")
+		fmt.Fprintf(os.Stderr, "Usage:\n")
+		fmt.Fprintf(os.Stderr, "  %s [global flags] migrate [migrate flags] [subcommand] [arguments]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Global flags:\n")
+		flag.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nMigrate flags:\n")
+		migrateFlags.PrintDefaults()
+		fmt.Fprintf(os.Stderr, `
+Subcommands:
+  up [N]      - applies N (all by default) pending migrations
+  down [N]    - rolls back N (1 by default) last applied migrations
+  status      - prints statuses of all migrations
+  redo        - rolls back the last applied migration and applies it again
+  create NAME - creates empty up and down files for a new migration
+  unlock      - removes migrations lock left by crashed runner
+
+Migration files are named <version>_<name>.up.sql and <version>_<name>.down.sql.
+Each migration is applied in its own transaction, except for MySQL which
+does not support transactional DDL. Edited applied migrations are detected
+by checksums.
+`)
+	}
+}
+
+// cmdMigrate implements migrate command.
+func cmdMigrate(args []string) {
+	if len(args) == 0 {
+		migrateFlags.Usage()
+		os.Exit(1)
+	}
+
+	if args[0] == "create" {
+		if len(args) != 2 {
+			logger.Fatalf("Expected migration name for %q", "migrate create")
+		}
+		up, down, err := migrate.Create(*migrateDirF, args[1])
+		if err != nil {
+			logger.Fatalf("%s", err)
+		}
+		fmt.Printf("Created %s\nCreated %s\n", up, down)
+		return
+	}
+
+	n := 0
+	switch args[0] {
+	case "up", "down":
+		if len(args) > 2 {
+			logger.Fatalf("Expected zero or one argument for %q, got %d", "migrate "+args[0], len(args)-1)
+		}
+		if len(args) == 2 {
+			var err error
+			if n, err = strconv.Atoi(args[1]); err != nil || n <= 0 {
+				logger.Fatalf("Expected positive number of migrations, got %q", args[1])
+			}
+		}
+	case "status", "redo", "unlock":
+		if len(args) > 1 {
+			logger.Fatalf("Expected zero arguments for %q, got %d", "migrate "+args[0], len(args)-1)
+		}
+	default:
+		migrateFlags.Usage()
+		logger.Fatalf("Unexpected migrate subcommand %q", args[0])
+	}
+
+	migrations, err := migrate.LoadDir(*migrateDirF)
+	if err != nil {
+		logger.Fatalf("failed to load migrations: %s", err)
+	}
+	m := migrate.New(getDB(), migrations)
+	m.Table = *migrateTableF
+
+	switch args[0] {
+	case "up":
+		applied, err := m.Up(n)
+		printMigrations("Applied", applied)
+		if err != nil {
+			logger.Fatalf("%s", err)
+		}
+
+	case "down":
+		rolledBack, err := m.Down(n)
+		printMigrations("Rolled back", rolledBack)
+		if err != nil {
+			logger.Fatalf("%s", err)
+		}
+
+	case "redo":
+		redone, err := m.Redo()
+		if err != nil {
+			logger.Fatalf("%s", err)
+		}
+		if redone != nil {
+			printMigrations("Redone", []migrate.Migration{*redone})
+		}
+
+	case "unlock":
+		if err = m.ForceUnlock(); err != nil {
+			logger.Fatalf("%s", err)
+		}
+
+	case "status":
+		statuses, err := m.Status()
+		if err != nil {
+			logger.Fatalf("%s", err)
+		}
+		printStatuses(statuses)
+	}
+}
+
+// printMigrations prints migrations with a given verb.
+func printMigrations(verb string, migrations []migrate.Migration) {
+	for _, m := range migrations {
+		fmt.Printf("%s %s\n", verb, m)
+	}
+}
+
+// printStatuses prints migrations statuses as a table.
+func printStatuses(statuses []migrate.Status) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "MIGRATION\tSTATUS\tAPPLIED AT")
+	for _, s := range statuses {
+		status := "pending"
+		appliedAt := ""
+		if s.Applied {
+			status = "applied"
+			appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		switch {
+		case s.Modified:
+			status += ", modified"
+		case s.Missing:
+			status += ", missing"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", s.Migration, status, appliedAt)
+	}
+	if err := w.Flush(); err != nil {
+		logger.Fatalf("%s", err)
+	}
+}
@@ -1,82 +1,461 @@
 package main
 
 import (
-	"bytes"
+	"bufio"
+	"database/sql"
+	"encoding/csv"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"text/tabwriter"
+	"unicode/utf8"
 
-	"gopkg.in/reform.v1"
+	"github.com/xaionaro/reform"
 )
 
+// argsFlag is a flag which can be given several times.
+type argsFlag []string
+
+func (a *argsFlag) String() string {
+	return strings.Join(*a, ", ")
+}
+
+func (a *argsFlag) Set(s string) error {
+	*a = append(*a, s)
+	return nil
+}
+
 var (
-	queryFlags = flag.NewFlagSet("query", flag.ExitOnError)
+	queryFlags   = flag.NewFlagSet("query", flag.ExitOnError)
+	queryFormatF = queryFlags.String("format", "table", "Output format: table, csv, tsv, json, jsonl, markdown or vertical")
+	queryNullF   = queryFlags.String("null", "", "NULL representation in text formats (default \"\\N\" for csv and tsv, \"NULL\" for others)")
+	queryArgsF   argsFlag
 )
 
 func init() {
+	queryFlags.Var(&queryArgsF, "arg", "Query argument, may be given several times")
+
 	queryFlags.Usage = func() {
 		fmt.Fprintf(os.Stderr, "`query` command executes SQL queries from given files or stdin, and returns results.\n\n")
 		fmt.Fprintf(os.Stderr, "Usage:\n")
-		fmt.Fprintf(os.Stderr, "  %s [global flags] query [file names]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s [global flags] query [query flags] [file names]\n\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "Global flags:\n")
 		flag.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nQuery flags:\n")
 		queryFlags.PrintDefaults()
 		fmt.Fprintf(os.Stderr, `
 Each file's content is executed as a single query. If it contains multiple
-statements, make sure SQL driver supports them. If file names are not given,
-a query is read from stdin until EOF, then executed.
+statements, make sure SQL driver supports them; every returned result set is
+written. If file names are not given, a query is read from stdin until EOF,
+then executed.
+
+Arguments given with -arg flags are bound to placeholders of the database
+dialect ($1 for PostgreSQL, ? for MySQL and SQLite3, @p1 for MS SQL) in order.
+Binary values are written as \x and hex digits, time values in RFC 3339 format.
 `)
 	}
 }
 
-// cmdQuery implements query command.
-func cmdQuery(db *reform.DB, files []string) {
-	queries := readFiles(files)
-	for _, q := range queries {
-		rows, err := db.Query(q)
-		if err != nil {
-			logger.Fatalf("failed to query %s: %s", q, err)
+// resultWriter writes result sets of queries.
+type resultWriter interface {
+	// start starts a new result set with given columns and kinds of their values.
+	start(columns []string, kinds []valueKind) error
+
+	// writeRow writes a row of the current result set.
+	writeRow(values []interface{}) error
+
+	// end ends the current result set.
+	end() error
+}
+
+// newResultWriter returns resultWriter for given format. Empty null selects the default NULL representation.
+func newResultWriter(format string, out io.Writer, null string) (resultWriter, error) {
+	if null == "" {
+		null = "NULL"
+		if format == "csv" || format == "tsv" {
+			null = `\N`
 		}
-		columns, err := rows.Columns()
-		if err != nil {
-			logger.Fatalf("failed to get columns for %s: %s", q, err)
+	}
+	switch format {
+	case "table":
+		return &tableResultWriter{out: out, null: null}, nil
+	case "csv":
+		return &csvResultWriter{out: out, null: null}, nil
+	case "tsv":
+		return &tsvResultWriter{w: bufio.NewWriter(out), null: null}, nil
+	case "json":
+		return &jsonResultWriter{w: bufio.NewWriter(out)}, nil
+	case "jsonl":
+		return &jsonResultWriter{w: bufio.NewWriter(out), lines: true}, nil
+	case "markdown":
+		return &markdownResultWriter{w: bufio.NewWriter(out), null: null}, nil
+	case "vertical":
+		return &verticalResultWriter{w: bufio.NewWriter(out), null: null}, nil
+	default:
+		return nil, fmt.Errorf("unexpected format %q, expected table, csv, tsv, json, jsonl, markdown or vertical", format)
+	}
+}
+
+// displayValue returns a text representation of value scanned from the column of given kind, or null for NULL.
+// Values of columns of unknown types which are not valid UTF-8 are treated as binary.
+func displayValue(v interface{}, kind valueKind, null string) string {
+	if b, ok := v.([]byte); ok && !utf8.Valid(b) {
+		kind = binaryKind
+	}
+	s, ok := textValue(v, kind)
+	if !ok {
+		return null
+	}
+	return s
+}
+
+// displayValues returns text representations of values of a row.
+func displayValues(values []interface{}, kinds []valueKind, null string, escape func(string) string) []string {
+	res := make([]string, len(values))
+	for i, v := range values {
+		res[i] = escape(displayValue(v, kinds[i], null))
+	}
+	return res
+}
+
+// escapeControl replaces tabs and line breaks with escape sequences.
+var escapeControl = strings.NewReplacer("\t", `\t`, "\n", `\n`, "\r", `\r`).Replace
+
+type tableResultWriter struct {
+	out   io.Writer
+	w     *tabwriter.Writer
+	kinds []valueKind
+	null  string
+	sets  int
+}
+
+func (w *tableResultWriter) start(columns []string, kinds []valueKind) error {
+	if w.sets > 0 {
+		if _, err := fmt.Fprintln(w.out); err != nil {
+			return err
 		}
-		logger.Debugf("result columns: %v", columns)
+	}
+	w.sets++
+	w.w = tabwriter.NewWriter(w.out, 0, 0, 1, ' ', tabwriter.Debug)
+	w.kinds = kinds
 
-		// write table header
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', tabwriter.Debug)
-		if _, err = fmt.Fprintln(w, strings.Join(columns, "\t")); err != nil {
-			logger.Fatalf("%s", err)
+	dashes := make([]string, len(columns))
+	for i, c := range columns {
+		columns[i] = escapeControl(c)
+		dashes[i] = strings.Repeat("-", utf8.RuneCountInString(columns[i]))
+	}
+	if _, err := fmt.Fprintln(w.w, strings.Join(columns, "\t")); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(w.w, strings.Join(dashes, "\t"))
+	return err
+}
+
+func (w *tableResultWriter) writeRow(values []interface{}) error {
+	_, err := fmt.Fprintln(w.w, strings.Join(displayValues(values, w.kinds, w.null, escapeControl), "\t"))
+	return err
+}
+
+func (w *tableResultWriter) end() error {
+	return w.w.Flush()
+}
+
+type csvResultWriter struct {
+	out   io.Writer
+	w     *csv.Writer
+	kinds []valueKind
+	null  string
+	sets  int
+}
+
+func (w *csvResultWriter) start(columns []string, kinds []valueKind) error {
+	if w.sets > 0 {
+		if _, err := fmt.Fprintln(w.out); err != nil {
+			return err
+		}
+	}
+	w.sets++
+	w.w = csv.NewWriter(w.out)
+	w.kinds = kinds
+	return w.w.Write(columns)
+}
+
+func (w *csvResultWriter) writeRow(values []interface{}) error {
+	return w.w.Write(displayValues(values, w.kinds, w.null, func(s string) string { return s }))
+}
+
+func (w *csvResultWriter) end() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+// escapeTSV escapes values like PostgreSQL text format does.
+var escapeTSV = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`).Replace
+
+type tsvResultWriter struct {
+	w     *bufio.Writer
+	kinds []valueKind
+	null  string
+	sets  int
+}
+
+func (w *tsvResultWriter) start(columns []string, kinds []valueKind) error {
+	if w.sets > 0 {
+		if err := w.w.WriteByte('\n'); err != nil {
+			return err
 		}
-		for i, c := range columns {
-			columns[i] = strings.Repeat("-", len(c))
+	}
+	w.sets++
+	w.kinds = kinds
+	for i, c := range columns {
+		columns[i] = escapeTSV(c)
+	}
+	_, err := fmt.Fprintln(w.w, strings.Join(columns, "\t"))
+	return err
+}
+
+func (w *tsvResultWriter) writeRow(values []interface{}) error {
+	// NULL representation is not escaped
+	row := make([]string, len(values))
+	for i, v := range values {
+		if v == nil {
+			row[i] = w.null
+			continue
 		}
-		if _, err = fmt.Fprintln(w, strings.Join(columns, "\t")); err != nil {
-			logger.Fatalf("%s", err)
+		row[i] = escapeTSV(displayValue(v, w.kinds[i], w.null))
+	}
+	_, err := fmt.Fprintln(w.w, strings.Join(row, "\t"))
+	return err
+}
+
+func (w *tsvResultWriter) end() error {
+	return w.w.Flush()
+}
+
+// jsonResultWriter writes every result set as JSON array of objects, or, for JSON Lines,
+// every row as JSON object.
+type jsonResultWriter struct {
+	w       *bufio.Writer
+	lines   bool
+	columns []string
+	kinds   []valueKind
+	rows    int
+}
+
+func (w *jsonResultWriter) start(columns []string, kinds []valueKind) error {
+	w.columns = columns
+	w.kinds = kinds
+	w.rows = 0
+	if w.lines {
+		return nil
+	}
+	return w.w.WriteByte('[')
+}
+
+func (w *jsonResultWriter) writeRow(values []interface{}) error {
+	converted := make([]interface{}, len(values))
+	for i, v := range values {
+		if b, ok := v.([]byte); ok && (w.kinds[i] == binaryKind || !utf8.Valid(b)) {
+			converted[i], _ = textValue(v, binaryKind)
+			continue
+		}
+		converted[i] = jsonValue(v, w.kinds[i])
+	}
+	object, err := jsonObject(w.columns, converted)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case w.lines:
+	case w.rows == 0:
+		err = w.w.WriteByte('\n')
+	default:
+		_, err = w.w.WriteString(",\n")
+	}
+	if err != nil {
+		return err
+	}
+	w.rows++
+	if _, err = w.w.Write(object); err != nil {
+		return err
+	}
+	if w.lines {
+		return w.w.WriteByte('\n')
+	}
+	return nil
+}
+
+func (w *jsonResultWriter) end() error {
+	if !w.lines {
+		s := "]\n"
+		if w.rows > 0 {
+			s = "\n]\n"
 		}
+		if _, err := w.w.WriteString(s); err != nil {
+			return err
+		}
+	}
+	return w.w.Flush()
+}
+
+// escapeMarkdown escapes values for Markdown table cells.
+var escapeMarkdown = strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>", "\r", "<br>").Replace
+
+type markdownResultWriter struct {
+	w     *bufio.Writer
+	kinds []valueKind
+	null  string
+	sets  int
+}
 
-		// read all rows, scan each field to []byte
+func (w *markdownResultWriter) start(columns []string, kinds []valueKind) error {
+	if w.sets > 0 {
+		if err := w.w.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+	w.sets++
+	w.kinds = kinds
+	dashes := make([]string, len(columns))
+	for i, c := range columns {
+		columns[i] = escapeMarkdown(c)
+		dashes[i] = "---"
+	}
+	_, err := fmt.Fprintf(w.w, "| %s |\n| %s |\n", strings.Join(columns, " | "), strings.Join(dashes, " | "))
+	return err
+}
+
+func (w *markdownResultWriter) writeRow(values []interface{}) error {
+	_, err := fmt.Fprintf(w.w, "| %s |\n", strings.Join(displayValues(values, w.kinds, w.null, escapeMarkdown), " | "))
+	return err
+}
+
+func (w *markdownResultWriter) end() error {
+	return w.w.Flush()
+}
+
+// verticalResultWriter writes every column of a row on its own line, like MySQL's \G.
+type verticalResultWriter struct {
+	w       *bufio.Writer
+	columns []string
+	kinds   []valueKind
+	null    string
+	rows    int
+}
+
+func (w *verticalResultWriter) start(columns []string, kinds []valueKind) error {
+	var width int
+	for _, c := range columns {
+		if n := utf8.RuneCountInString(c); n > width {
+			width = n
+		}
+	}
+	w.columns = make([]string, len(columns))
+	for i, c := range columns {
+		w.columns[i] = strings.Repeat(" ", width-utf8.RuneCountInString(c)) + c
+	}
+	w.kinds = kinds
+	w.rows = 0
+	return nil
+}
+
+func (w *verticalResultWriter) writeRow(values []interface{}) error {
+	w.rows++
+	if _, err := fmt.Fprintf(w.w, "*************************** %d. row ***************************\n", w.rows); err != nil {
+		return err
+	}
+	for i, v := range displayValues(values, w.kinds, w.null, escapeControl) {
+		if _, err := fmt.Fprintf(w.w, "%s: %s\n", w.columns[i], v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *verticalResultWriter) end() error {
+	return w.w.Flush()
+}
+
+// writeResults writes all result sets of rows with rw and closes rows.
+// Result sets without columns (for example, of INSERT statements) are skipped.
+func writeResults(rw resultWriter, rows *sql.Rows) error {
+	defer rows.Close()
+
+	for {
+		types, err := rows.ColumnTypes()
+		if err != nil {
+			return err
+		}
+		columns := make([]string, len(types))
+		kinds := make([]valueKind, len(types))
+		for i, t := range types {
+			columns[i] = t.Name()
+			kinds[i] = kindOfType(t.DatabaseTypeName())
+		}
+		logger.Debugf("result columns: %v", columns)
+
+		// rows of statements without columns are still iterated, some drivers execute them lazily
+		if len(columns) > 0 {
+			if err = rw.start(columns, kinds); err != nil {
+				return err
+			}
+		}
+		values := make([]interface{}, len(columns))
+		dests := make([]interface{}, len(columns))
+		for i := range dests {
+			dests[i] = &values[i]
+		}
 		for rows.Next() {
-			line := make([][]byte, len(columns))
-			dests := make([]interface{}, len(line))
-			for i := range dests {
-				dests[i] = &line[i]
+			if len(columns) == 0 {
+				continue
 			}
 			if err = rows.Scan(dests...); err != nil {
-				logger.Fatalf("%s", err)
+				return err
+			}
+			if err = rw.writeRow(values); err != nil {
+				return err
 			}
-			fmt.Fprintf(w, "%s\n", bytes.Join(line, []byte("\t")))
 		}
 		if err = rows.Err(); err != nil {
-			logger.Fatalf("%s", err)
+			return err
+		}
+		if len(columns) > 0 {
+			if err = rw.end(); err != nil {
+				return err
+			}
 		}
 
-		if err = w.Flush(); err != nil {
-			logger.Fatalf("%s", err)
+		if !rows.NextResultSet() {
+			break
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	return rows.Close()
+}
+
+// cmdQuery implements query command.
+func cmdQuery(db *reform.DB, files []string) {
+	rw, err := newResultWriter(*queryFormatF, os.Stdout, *queryNullF)
+	if err != nil {
+		logger.Fatalf("%s", err)
+	}
+	args := make([]interface{}, len(queryArgsF))
+	for i, a := range queryArgsF {
+		args[i] = a
+	}
+
+	queries := readFiles(files)
+	for _, q := range queries {
+		rows, err := db.Query(q, args...)
+		if err != nil {
+			logger.Fatalf("failed to query %s: %s", q, err)
 		}
-		if err = rows.Close(); err != nil {
+		if err = writeResults(rw, rows); err != nil {
 			logger.Fatalf("%s", err)
 		}
 	}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xaionaro/reform/internal"
+)
+
+// writeTestRows writes a result set with all kinds of values, NULLs and text which needs escaping.
+func writeTestRows(t *testing.T, rw resultWriter) {
+	columns := []string{"id", "name", "data", "created", "ok"}
+	kinds := []valueKind{otherKind, otherKind, binaryKind, timeKind, boolKind}
+	require.NoError(t, rw.start(columns, kinds))
+	require.NoError(t, rw.writeRow([]interface{}{
+		int64(1), []byte("it's \"a\"|\tb\nc"), []byte{0xde, 0xad}, time.Date(2026, 10, 18, 12, 30, 0, 0, time.UTC), true,
+	}))
+	// text which is not valid UTF-8 is shown as binary
+	require.NoError(t, rw.writeRow([]interface{}{int64(2), []byte{0xff, 0xfe}, nil, nil, false}))
+	require.NoError(t, rw.end())
+}
+
+func TestResultWriters(t *testing.T) {
+	for format, expected := range map[string]string{
+		"table": "id |name            |data   |created              |ok\n" +
+			"-- |----            |----   |-------              |--\n" +
+			"1  |it's \"a\"|\\tb\\nc |\\xdead |2026-10-18T12:30:00Z |true\n" +
+			"2  |\\xfffe          |NULL   |NULL                 |false\n",
+		"csv": "id,name,data,created,ok\n" +
+			"1,\"it's \"\"a\"\"|\tb\nc\",\\xdead,2026-10-18T12:30:00Z,true\n" +
+			"2,\\xfffe,\\N,\\N,false\n",
+		"tsv": "id\tname\tdata\tcreated\tok\n" +
+			"1\tit's \"a\"|\\tb\\nc\t\\\\xdead\t2026-10-18T12:30:00Z\ttrue\n" +
+			"2\t\\\\xfffe\t\\N\t\\N\tfalse\n",
+		"json": "[\n" +
+			`{"id":1,"name":"it's \"a\"|\tb\nc","data":"\\xdead","created":"2026-10-18T12:30:00Z","ok":true},` + "\n" +
+			`{"id":2,"name":"\\xfffe","data":null,"created":null,"ok":false}` + "\n" +
+			"]\n",
+		"jsonl": `{"id":1,"name":"it's \"a\"|\tb\nc","data":"\\xdead","created":"2026-10-18T12:30:00Z","ok":true}` + "\n" +
+			`{"id":2,"name":"\\xfffe","data":null,"created":null,"ok":false}` + "\n",
+		"markdown": "| id | name | data | created | ok |\n" +
+			"| --- | --- | --- | --- | --- |\n" +
+			"| 1 | it's \"a\"\\|\tb<br>c | \\xdead | 2026-10-18T12:30:00Z | true |\n" +
+			"| 2 | \\xfffe | NULL | NULL | false |\n",
+		"vertical": "*************************** 1. row ***************************\n" +
+			"     id: 1\n" +
+			"   name: it's \"a\"|\\tb\\nc\n" +
+			"   data: \\xdead\n" +
+			"created: 2026-10-18T12:30:00Z\n" +
+			"     ok: true\n" +
+			"*************************** 2. row ***************************\n" +
+			"     id: 2\n" +
+			"   name: \\xfffe\n" +
+			"   data: NULL\n" +
+			"created: NULL\n" +
+			"     ok: false\n",
+	} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			rw, err := newResultWriter(format, &buf, "")
+			require.NoError(t, err)
+			writeTestRows(t, rw)
+			assert.Equal(t, expected, buf.String())
+		})
+	}
+
+	_, err := newResultWriter("xml", new(bytes.Buffer), "")
+	assert.Error(t, err)
+}
+
+func TestResultWritersNull(t *testing.T) {
+	for format, expected := range map[string]string{
+		"csv":      "n\n-\n",
+		"tsv":      "n\n-\n",
+		"markdown": "| n |\n| --- |\n| - |\n",
+		"vertical": "*************************** 1. row ***************************\nn: -\n",
+	} {
+		var buf bytes.Buffer
+		rw, err := newResultWriter(format, &buf, "-")
+		require.NoError(t, err)
+		require.NoError(t, rw.start([]string{"n"}, []valueKind{otherKind}))
+		require.NoError(t, rw.writeRow([]interface{}{nil}))
+		require.NoError(t, rw.end())
+		assert.Equal(t, expected, buf.String(), format)
+	}
+}
+
+func TestWriteResults(t *testing.T) {
+	logger = internal.NewLogger("reform-db-test: ", false)
+
+	sqlDB, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer sqlDB.Close()
+	_, err = sqlDB.Exec(`CREATE TABLE files (id integer PRIMARY KEY, name text, data blob)`)
+	require.NoError(t, err)
+	_, err = sqlDB.Exec(`INSERT INTO files (id, name, data) VALUES (1, 'a.txt', X'74657874'), (2, NULL, X'00FF')`)
+	require.NoError(t, err)
+
+	// blob column is binary by type, expressions of unknown type are binary if they are not valid UTF-8
+	rows, err := sqlDB.Query(`SELECT id, name, data, X'FFFE' AS raw, 'text' AS s FROM files ORDER BY id`)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	rw, err := newResultWriter("csv", &buf, "")
+	require.NoError(t, err)
+	require.NoError(t, writeResults(rw, rows))
+	assert.Equal(t, "id,name,data,raw,s\n"+
+		`1,a.txt,\x74657874,\xfffe,text`+"\n"+
+		`2,\N,\x00ff,\xfffe,text`+"\n", buf.String())
+}
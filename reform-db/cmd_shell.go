@@ -0,0 +1,513 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/xaionaro/reform"
+	"github.com/xaionaro/reform/dialects/postgresql"
+	"github.com/xaionaro/reform/dialects/sqlite3"
+)
+
+var (
+	shellFlags    = flag.NewFlagSet("shell", flag.ExitOnError)
+	shellFormatF  = shellFlags.String("format", "table", "Initial output format: table, csv, tsv, json, jsonl, markdown or vertical")
+	shellNullF    = shellFlags.String("null", "", "NULL representation in text formats (default \"\\N\" for csv and tsv, \"NULL\" for others)")
+	shellHistoryF = shellFlags.String("history", defaultHistoryFile(), "History file, empty to disable history saving")
+)
+
+func init() {
+	shellFlags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "`shell` command starts interactive SQL shell.\n\n")
+		fmt.Fprintf(os.Stderr, "Usage:\n")
+		fmt.Fprintf(os.Stderr, "  %s [global flags] shell [shell flags]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Global flags:\n")
+		flag.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nShell flags:\n")
+		shellFlags.PrintDefaults()
+		fmt.Fprintf(os.Stderr, `
+Statements may span several lines and are executed when terminated with
+semicolon. BEGIN, COMMIT and ROLLBACK statements start and end a transaction;
+the prompt shows * inside of it, and ! if it is aborted by an error.
+Type \? for meta-commands. If stdin is not a terminal, statements and
+meta-commands are read from it without prompts.
+There is no line editing or recall of previous lines: typed statements and
+meta-commands are only appended to the history file and listed with \s.
+`)
+	}
+}
+
+const shellHelp = `Meta-commands:
+  \dt            list tables
+  \d TABLE       describe table columns
+  \format [NAME] show or set output format: table, csv, tsv, json, jsonl, markdown or vertical
+  \timing [on|off] toggle or set printing of statements execution time
+  \i FILE        execute statements and meta-commands from file
+  \s             show history (it is not recalled with arrow keys)
+  \?             show this help
+  \q             quit
+`
+
+// errQuit is returned by shell.run when \q meta-command is executed.
+var errQuit = errors.New("quit")
+
+// defaultHistoryFile returns default shell history file in home directory.
+func defaultHistoryFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".reform-db_history")
+}
+
+// shell is an interactive SQL shell.
+type shell struct {
+	db     *reform.DB
+	out    io.Writer
+	format string
+	null   string
+	timing bool
+
+	tx     *reform.TX
+	failed bool // transaction is aborted by error and should be rolled back
+
+	statement   strings.Builder
+	history     []string
+	historyFile *os.File
+	depth       int // nesting of \i meta-commands
+}
+
+// querier returns Querier of the current transaction, if any.
+func (s *shell) querier() *reform.Querier {
+	if s.tx != nil {
+		return s.tx.Querier
+	}
+	return s.db.Querier
+}
+
+// prompt returns the prompt for the next line.
+func (s *shell) prompt() string {
+	var state string
+	switch {
+	case s.failed:
+		state = "!"
+	case s.tx != nil:
+		state = "*"
+	}
+	if s.statement.Len() == 0 {
+		return fmt.Sprintf("%s=%s> ", s.db.Dialect, state)
+	}
+	return fmt.Sprintf("%s-%s> ", s.db.Dialect, state)
+}
+
+// openHistory loads history from file and opens it for appending.
+func (s *shell) openHistory(name string) error {
+	b, err := ioutil.ReadFile(name)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for _, line := range strings.Split(string(b), "\n") {
+		if line != "" {
+			s.history = append(s.history, line)
+		}
+	}
+	s.historyFile, err = os.OpenFile(name, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	return err
+}
+
+// addHistory adds statement or meta-command to history.
+func (s *shell) addHistory(entry string) {
+	// keep one entry per line
+	entry = strings.Join(strings.Fields(entry), " ")
+	s.history = append(s.history, entry)
+	if s.historyFile != nil {
+		if _, err := fmt.Fprintln(s.historyFile, entry); err != nil {
+			logger.Printf("failed to save history: %s", err)
+			s.historyFile = nil
+		}
+	}
+}
+
+// run reads and executes statements and meta-commands from r until EOF or \q.
+// Unterminated statement at EOF is executed too.
+func (s *shell) run(r io.Reader, interactive bool) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 64*1024*1024)
+	for {
+		if interactive {
+			fmt.Fprint(s.out, s.prompt())
+		}
+		if !sc.Scan() {
+			break
+		}
+		if s.line(sc.Text(), interactive) {
+			return errQuit
+		}
+	}
+	if interactive {
+		fmt.Fprintln(s.out)
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+
+	if q := strings.TrimSpace(s.statement.String()); q != "" {
+		s.statement.Reset()
+		s.report(s.execute(q))
+	}
+	return nil
+}
+
+// line handles a single input line. It returns true on \q.
+func (s *shell) line(text string, interactive bool) bool {
+	trimmed := strings.TrimSpace(text)
+	if s.statement.Len() == 0 {
+		if trimmed == "" {
+			return false
+		}
+		if strings.HasPrefix(trimmed, `\`) {
+			if interactive {
+				s.addHistory(trimmed)
+			}
+			quit, err := s.meta(trimmed)
+			s.report(err)
+			return quit
+		}
+	}
+
+	s.statement.WriteString(text)
+	q := s.statement.String()
+	if !statementEnds(q) {
+		s.statement.WriteString("\n")
+		return false
+	}
+	s.statement.Reset()
+
+	q = strings.TrimSpace(q)
+	if interactive {
+		s.addHistory(q)
+	}
+	s.report(s.execute(q))
+	return false
+}
+
+// report prints error, if any.
+func (s *shell) report(err error) {
+	if err != nil {
+		logger.Printf("%s", err)
+	}
+}
+
+// transactionStatement returns "begin", "commit" or "rollback" for statements
+// starting and ending transactions, and empty string for other statements.
+func transactionStatement(q string) string {
+	words := strings.Fields(strings.ToUpper(strings.TrimRight(q, "; \t\r\n")))
+	if len(words) == 0 {
+		return ""
+	}
+	rest := words[1:]
+	plain := len(rest) == 0 || (len(rest) == 1 && (rest[0] == "TRANSACTION" || rest[0] == "TRAN" || rest[0] == "WORK"))
+	switch {
+	case words[0] == "BEGIN" && plain, words[0] == "START" && len(rest) == 1 && rest[0] == "TRANSACTION":
+		return "begin"
+	case (words[0] == "COMMIT" || words[0] == "END") && plain:
+		return "commit"
+	case (words[0] == "ROLLBACK" || words[0] == "ABORT") && plain:
+		return "rollback"
+	default:
+		return ""
+	}
+}
+
+// execute executes statement and writes its results, printing execution time if \timing is on.
+func (s *shell) execute(q string) error {
+	start := time.Now()
+	err := s.exec(q)
+	if s.timing {
+		fmt.Fprintf(s.out, "Time: %.3f ms\n", float64(time.Since(start))/float64(time.Millisecond))
+	}
+	return err
+}
+
+func (s *shell) exec(q string) error {
+	statement := transactionStatement(q)
+	switch statement {
+	case "begin":
+		if s.tx != nil {
+			return errors.New("transaction is already started")
+		}
+		tx, err := s.db.Begin()
+		if err != nil {
+			return err
+		}
+		s.tx = tx
+		return nil
+
+	case "commit", "rollback":
+		if s.tx == nil {
+			return errors.New("no transaction is started")
+		}
+		var err error
+		if statement == "commit" && !s.failed {
+			err = s.tx.Commit()
+		} else {
+			err = s.tx.Rollback()
+		}
+		s.tx, s.failed = nil, false
+		return err
+	}
+
+	rw, err := newResultWriter(s.format, s.out, s.null)
+	if err != nil {
+		return err
+	}
+	rows, err := s.querier().Query(q)
+	if err == nil {
+		err = writeResults(rw, rows)
+	}
+	if err != nil && s.tx != nil && s.db.Dialect == postgresql.Dialect {
+		// PostgreSQL ignores all statements until the end of the transaction
+		s.failed = true
+	}
+	return err
+}
+
+// meta executes meta-command. It returns true on \q.
+func (s *shell) meta(cmd string) (bool, error) {
+	fields := strings.Fields(cmd)
+	name, args := fields[0], fields[1:]
+	switch name {
+	case `\q`:
+		return true, nil
+
+	case `\?`:
+		_, err := fmt.Fprint(s.out, shellHelp)
+		return false, err
+
+	case `\dt`:
+		return false, s.listTables()
+
+	case `\d`:
+		if len(args) != 1 {
+			return false, errors.New(`usage: \d TABLE`)
+		}
+		return false, s.describeTable(args[0])
+
+	case `\timing`:
+		switch {
+		case len(args) == 0:
+			s.timing = !s.timing
+		case len(args) == 1 && (args[0] == "on" || args[0] == "off"):
+			s.timing = args[0] == "on"
+		default:
+			return false, errors.New(`usage: \timing [on|off]`)
+		}
+		state := "off"
+		if s.timing {
+			state = "on"
+		}
+		_, err := fmt.Fprintf(s.out, "Timing is %s.\n", state)
+		return false, err
+
+	case `\format`:
+		switch len(args) {
+		case 0:
+			_, err := fmt.Fprintf(s.out, "Output format is %s.\n", s.format)
+			return false, err
+		case 1:
+			if _, err := newResultWriter(args[0], ioutil.Discard, s.null); err != nil {
+				return false, err
+			}
+			s.format = args[0]
+			return false, nil
+		default:
+			return false, errors.New(`usage: \format [NAME]`)
+		}
+
+	case `\i`:
+		if len(args) != 1 {
+			return false, errors.New(`usage: \i FILE`)
+		}
+		return s.include(args[0])
+
+	case `\s`:
+		for i, entry := range s.history {
+			if _, err := fmt.Fprintf(s.out, "%5d  %s\n", i+1, entry); err != nil {
+				return false, err
+			}
+		}
+		return false, nil
+
+	default:
+		return false, fmt.Errorf(`unknown meta-command %s, type \? for help`, name)
+	}
+}
+
+// include executes statements and meta-commands from file. It returns true on \q.
+func (s *shell) include(name string) (bool, error) {
+	if s.depth >= 16 {
+		return false, fmt.Errorf(`too many nested \i meta-commands for %s`, name)
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	s.depth++
+	err = s.run(f, false)
+	s.depth--
+	if err == errQuit {
+		return true, nil
+	}
+	return false, err
+}
+
+// writeTable writes rows of meta-command with the current format.
+func (s *shell) writeTable(columns []string, rows [][]interface{}) error {
+	rw, err := newResultWriter(s.format, s.out, s.null)
+	if err != nil {
+		return err
+	}
+	if err = rw.start(columns, make([]valueKind, len(columns))); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err = rw.writeRow(row); err != nil {
+			return err
+		}
+	}
+	return rw.end()
+}
+
+// listTables implements \dt meta-command.
+func (s *shell) listTables() error {
+	q := s.querier()
+	var rows [][]interface{}
+	if s.db.Dialect == sqlite3.Dialect {
+		tables, err := q.SelectAllFrom(sqliteMasterView, "WHERE type = ? ORDER BY name", "table")
+		if err != nil {
+			return err
+		}
+		for _, t := range tables {
+			if name := t.(*sqliteMaster).Name; name != "sqlite_sequence" {
+				rows = append(rows, []interface{}{name, "table"})
+			}
+		}
+		return s.writeTable([]string{"name", "type"}, rows)
+	}
+
+	cond := currentSchema(s.db.Dialect)
+	if cond == "" {
+		return fmt.Errorf("unhandled dialect %s", s.db.Dialect)
+	}
+	tables, err := q.SelectAllFrom(tableView, "WHERE "+cond+" ORDER BY table_name")
+	if err != nil {
+		return err
+	}
+	for _, t := range tables {
+		table := t.(*table)
+		rows = append(rows, []interface{}{table.TableName, strings.ToLower(table.TableType)})
+	}
+	return s.writeTable([]string{"name", "type"}, rows)
+}
+
+// describeTable implements \d meta-command.
+func (s *shell) describeTable(name string) error {
+	q := s.querier()
+	table := parseTableName(name)
+	var rows [][]interface{}
+	if s.db.Dialect == sqlite3.Dialect {
+		pragma := "PRAGMA table_info(" + q.QuoteIdentifier(table.name) + ")" // no placeholders for PRAGMA
+		if table.schema != "" {
+			pragma = "PRAGMA " + q.QuoteIdentifier(table.schema) + ".table_info(" + q.QuoteIdentifier(table.name) + ")"
+		}
+		res, err := q.Query(pragma)
+		if err != nil {
+			return err
+		}
+		defer res.Close()
+		for {
+			var column sqliteTableInfo
+			if err = q.NextRow(&column, res); err != nil {
+				break
+			}
+			rows = append(rows, []interface{}{column.Name, column.Type, !column.NotNull})
+		}
+		if err != reform.ErrNoRows {
+			return err
+		}
+	} else {
+		cond := currentSchema(s.db.Dialect)
+		if cond == "" {
+			return fmt.Errorf("unhandled dialect %s", s.db.Dialect)
+		}
+		var args []interface{}
+		if table.schema != "" {
+			cond = "table_schema = " + q.Placeholder(1)
+			args = append(args, table.schema)
+		}
+		args = append(args, table.name)
+		tail := fmt.Sprintf("WHERE %s AND table_name = %s ORDER BY ordinal_position", cond, q.Placeholder(len(args)))
+		columns, err := q.SelectAllFrom(columnView, tail, args...)
+		if err != nil {
+			return err
+		}
+		for _, c := range columns {
+			column := c.(*column)
+			rows = append(rows, []interface{}{column.Name, column.Type, bool(column.IsNullable)})
+		}
+	}
+
+	if len(rows) == 0 {
+		return fmt.Errorf("table %s does not exist", name)
+	}
+	return s.writeTable([]string{"column", "type", "nullable"}, rows)
+}
+
+// cmdShell implements shell command.
+func cmdShell(db *reform.DB) {
+	if _, err := newResultWriter(*shellFormatF, ioutil.Discard, *shellNullF); err != nil {
+		logger.Fatalf("%s", err)
+	}
+	s := &shell{
+		db:     db,
+		out:    os.Stdout,
+		format: *shellFormatF,
+		null:   *shellNullF,
+	}
+
+	fi, err := os.Stdin.Stat()
+	interactive := err == nil && fi.Mode()&os.ModeCharDevice != 0
+	if interactive {
+		if *shellHistoryF != "" {
+			if err = s.openHistory(*shellHistoryF); err != nil {
+				logger.Printf("failed to open history: %s", err)
+			}
+		}
+		fmt.Fprintf(s.out, "reform-db %s shell. Type \\? for help.\n", db.Dialect)
+	}
+
+	err = s.run(os.Stdin, interactive)
+	if s.tx != nil {
+		if rbErr := s.tx.Rollback(); rbErr != nil {
+			logger.Printf("failed to roll back transaction: %s", rbErr)
+		} else {
+			logger.Printf("uncommitted transaction is rolled back")
+		}
+	}
+	if s.historyFile != nil {
+		s.historyFile.Close()
+	}
+	if err != nil && err != errQuit {
+		logger.Fatalf("%s", err)
+	}
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xaionaro/reform"
+	"github.com/xaionaro/reform/dialects/postgresql"
+	"github.com/xaionaro/reform/dialects/sqlite3"
+	"github.com/xaionaro/reform/internal"
+)
+
+// newTestShell returns shell for SQLite database in memory with table t, and its output.
+// Dialect may differ from SQLite for dialect-specific behavior of shell itself.
+func newTestShell(t *testing.T, dialect reform.Dialect) (*shell, *bytes.Buffer) {
+	logger = internal.NewLogger("reform-db-test: ", false)
+
+	sqlDB, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db := reform.NewDB(sqlDB, dialect, reform.NewPrintfLogger(t.Logf))
+	_, err = db.Exec(`CREATE TABLE t (id integer PRIMARY KEY, name text)`)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	return &shell{db: db, out: &out, format: "csv"}, &out
+}
+
+func TestTransactionStatement(t *testing.T) {
+	for q, expected := range map[string]string{
+		"BEGIN;":                       "begin",
+		"begin transaction ;":          "begin",
+		"BEGIN TRAN":                   "begin",
+		"START TRANSACTION;":           "begin",
+		"BEGIN IMMEDIATE;":             "",
+		"begin\nwork;":                 "begin",
+		"COMMIT;":                      "commit",
+		"end;":                         "commit",
+		"ROLLBACK WORK;":               "rollback",
+		"abort":                        "rollback",
+		"ROLLBACK TO SAVEPOINT a;":     "",
+		"START TRANSACTION READ ONLY;": "",
+		"SELECT 1;":                    "",
+		";":                            "",
+	} {
+		assert.Equal(t, expected, transactionStatement(q), "%q", q)
+	}
+}
+
+func TestShellStatements(t *testing.T) {
+	s, out := newTestShell(t, sqlite3.Dialect)
+
+	err := s.run(strings.NewReader(`
+INSERT INTO t (id, name)
+VALUES (1, 'a;
+b'), (2, NULL);
+
+SELECT id, name
+  FROM t ORDER BY id;
+\format vertical
+SELECT COUNT(*) AS n FROM t`), false)
+	require.NoError(t, err)
+	assert.Equal(t, "id,name\n"+
+		"1,\"a;\nb\"\n"+
+		"2,\\N\n"+
+		"*************************** 1. row ***************************\n"+
+		"n: 2\n", out.String())
+	assert.Empty(t, s.history, "history is saved only in interactive mode")
+}
+
+func TestShellTransactions(t *testing.T) {
+	// PostgreSQL aborts transaction on error
+	s, out := newTestShell(t, postgresql.Dialect)
+
+	err := s.run(strings.NewReader(`BEGIN;
+INSERT INTO t (id) VALUES (1);
+SELECT * FROM no_such_table;
+SELECT
+COUNT(*) AS n FROM t;
+COMMIT;
+SELECT COUNT(*) AS n FROM t;
+ROLLBACK;
+begin transaction;
+BEGIN;
+INSERT INTO t (id) VALUES (2);
+commit;
+SELECT COUNT(*) AS n FROM t;
+`), true)
+	require.NoError(t, err)
+	assert.Nil(t, s.tx)
+	assert.False(t, s.failed)
+
+	// prompts show open (*) and aborted (!) transactions and continued statements (-);
+	// output of a statement follows the prompt of its last line
+	assert.Equal(t, strings.Join([]string{
+		"postgresql=> ",  // BEGIN
+		"postgresql=*> ", // INSERT
+		"postgresql=*> ", // error
+		"postgresql=!> ",
+		"postgresql-!> n\n1\n", // SQLite still executes it
+		"postgresql=!> ",       // aborted transaction is rolled back on COMMIT
+		"postgresql=> n\n0\n",
+		"postgresql=> ", // ROLLBACK without transaction
+		"postgresql=> ",
+		"postgresql=*> ", // nested BEGIN
+		"postgresql=*> ",
+		"postgresql=*> ",
+		"postgresql=> n\n1\n",
+		"postgresql=> \n",
+	}, ""), out.String())
+}
+
+func TestShellTransactionsSQLite(t *testing.T) {
+	s, out := newTestShell(t, sqlite3.Dialect)
+
+	// errors do not abort transactions of other databases
+	err := s.run(strings.NewReader(`BEGIN;
+INSERT INTO t (id) VALUES (1);
+SELECT * FROM no_such_table;
+COMMIT;
+BEGIN;
+INSERT INTO t (id) VALUES (2);
+ROLLBACK;
+SELECT COUNT(*) AS n FROM t;
+BEGIN;
+INSERT INTO t (id) VALUES (3);
+`), false)
+	require.NoError(t, err)
+	assert.Equal(t, "n\n1\n", out.String())
+
+	// transaction is left open at EOF, cmdShell rolls it back
+	require.NotNil(t, s.tx)
+	assert.False(t, s.failed)
+	require.NoError(t, s.tx.Rollback())
+}
+
+func TestShellMeta(t *testing.T) {
+	s, out := newTestShell(t, sqlite3.Dialect)
+	script := filepath.Join(t.TempDir(), "script.sql")
+	require.NoError(t, ioutil.WriteFile(script, []byte("INSERT INTO t (id, name) VALUES (1, 'a');\n\\q\nINSERT INTO t (id) VALUES (2);\n"), 0600))
+
+	run := func(input string) (string, error) {
+		out.Reset()
+		err := s.run(strings.NewReader(input), false)
+		return out.String(), err
+	}
+
+	res, err := run(`\?`)
+	require.NoError(t, err)
+	assert.Equal(t, shellHelp, res)
+
+	res, err = run("\\format\n\\format xml\n\\format\n\\format markdown extra\n\\format tsv\n\\format")
+	require.NoError(t, err)
+	assert.Equal(t, "Output format is csv.\nOutput format is csv.\nOutput format is tsv.\n", res)
+
+	res, err = run("\\timing\n\\timing maybe\n\\timing off\n\\timing on\n")
+	require.NoError(t, err)
+	assert.Equal(t, "Timing is on.\nTiming is off.\nTiming is on.\n", res)
+	assert.True(t, s.timing)
+
+	res, err = run("SELECT 1 AS one;\n\\timing off")
+	require.NoError(t, err)
+	assert.Regexp(t, `^one\n1\nTime: \d+\.\d{3} ms\nTiming is off.\n$`, res)
+
+	// \q in included file stops the shell
+	res, err = run(`\i ` + script + "\nINSERT INTO t (id) VALUES (3);\n")
+	assert.Equal(t, errQuit, err)
+	assert.Empty(t, res)
+
+	res, err = run("\\i no_such_file.sql\n\\unknown\n\\d\n\\format csv\n\\dt\n\\d t\nSELECT id FROM t;")
+	require.NoError(t, err)
+	assert.Equal(t, "name,type\n"+
+		"t,table\n"+
+		"column,type,nullable\n"+
+		"id,integer,true\n"+
+		"name,text,true\n"+
+		"id\n"+
+		"1\n", res)
+}
+
+func TestShellHistory(t *testing.T) {
+	s, out := newTestShell(t, sqlite3.Dialect)
+	name := filepath.Join(t.TempDir(), "history")
+	require.NoError(t, ioutil.WriteFile(name, []byte("SELECT 0;\n"), 0600))
+	require.NoError(t, s.openHistory(name))
+	defer s.historyFile.Close()
+
+	err := s.run(strings.NewReader("SELECT\n  1 AS one;\n\\format\n\\s\n"), true)
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(out.String(), "    1  SELECT 0;\n    2  SELECT 1 AS one;\n    3  \\format\n    4  \\s\nsqlite3=> \n"), out.String())
+
+	b, err := ioutil.ReadFile(name)
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT 0;\nSELECT 1 AS one;\n\\format\n\\s\n", string(b))
+}
@@ -36,6 +36,12 @@ func init() {
 		fmt.Fprintf(os.Stderr, "\nCommands:\n")
 		fmt.Fprintf(os.Stderr, "  exec  - executes SQL queries from given files or stdin\n")
 		fmt.Fprintf(os.Stderr, "  query - executes SQL queries from given files or stdin, and returns results\n")
+		fmt.Fprintf(os.Stderr, "  shell - starts interactive SQL shell\n")
+		fmt.Fprintf(os.Stderr, "  explain - prints execution plans of SQL queries from given files or stdin\n")
+		fmt.Fprintf(os.Stderr, "  dump  - writes rows of a table in CSV, JSON Lines or SQL format\n")
+		fmt.Fprintf(os.Stderr, "  load  - inserts rows into a table from CSV, JSON Lines or SQL files\n")
+		fmt.Fprintf(os.Stderr, "  diff  - compares Go models with existing database schema\n")
+		fmt.Fprintf(os.Stderr, "  migrate - applies and rolls back versioned schema migrations\n")
 		fmt.Fprintf(os.Stderr, "  init  - generates Go model files for existing database schema\n\n")
 		fmt.Fprintf(os.Stderr, "Registered database drivers: %s.", strings.Join(sql.Drivers(), ", "))
 	}
@@ -84,6 +90,36 @@ func main() {
 		queryFlags.Parse(flag.Args()[1:])
 		cmdQuery(getDB(), queryFlags.Args())
 
+	case "shell":
+		shellFlags.Parse(flag.Args()[1:])
+		if shellFlags.NArg() != 0 {
+			logger.Fatalf("Unexpected arguments for %q: %v", "shell", shellFlags.Args())
+		}
+		cmdShell(getDB())
+
+	case "explain":
+		explainFlags.Parse(flag.Args()[1:])
+		cmdExplain(getDB(), explainFlags.Args())
+
+	case "dump":
+		dumpFlags.Parse(flag.Args()[1:])
+		if dumpFlags.NArg() != 0 {
+			logger.Fatalf("Unexpected arguments for %q: %v", "dump", dumpFlags.Args())
+		}
+		cmdDump(getDB(), os.Stdout)
+
+	case "load":
+		loadFlags.Parse(flag.Args()[1:])
+		cmdLoad(getDB(), loadFlags.Args())
+
+	case "diff":
+		diffFlags.Parse(flag.Args()[1:])
+		cmdDiff(getDB(), diffFlags.Args())
+
+	case "migrate":
+		migrateFlags.Parse(flag.Args()[1:])
+		cmdMigrate(migrateFlags.Args())
+
 	case "init":
 		initFlags.Parse(flag.Args()[1:])
 
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xaionaro/reform"
+	"github.com/xaionaro/reform/dialects/mssql"
+	"github.com/xaionaro/reform/dialects/mysql"
+	"github.com/xaionaro/reform/dialects/postgresql"
+	"github.com/xaionaro/reform/dialects/sqlserver"
+)
+
+// valueKind is a kind of column values which need special encoding.
+type valueKind int
+
+const (
+	otherKind valueKind = iota
+	binaryKind
+	timeKind
+	boolKind
+)
+
+// timeFormat is used for time values in CSV and JSON Lines.
+const timeFormat = time.RFC3339Nano
+
+// kindOfType returns a kind of values of column with given SQL type.
+func kindOfType(sqlType string) valueKind {
+	t := strings.ToLower(sqlType)
+	switch {
+	case strings.Contains(t, "bytea"), strings.Contains(t, "blob"), strings.Contains(t, "binary"), t == "image":
+		return binaryKind
+	case strings.Contains(t, "interval"):
+		return otherKind
+	case strings.Contains(t, "date"), strings.Contains(t, "time"):
+		return timeKind
+	case strings.HasPrefix(t, "bool"), t == "bit", t == "tinyint(1)":
+		return boolKind
+	default:
+		return otherKind
+	}
+}
+
+// textValue returns a text representation of value scanned from the column of given kind.
+// False is returned for NULL.
+func textValue(v interface{}, kind valueKind) (string, bool) {
+	switch v := v.(type) {
+	case nil:
+		return "", false
+	case []byte:
+		if kind == binaryKind {
+			return `\x` + hex.EncodeToString(v), true
+		}
+		return string(v), true
+	case string:
+		return v, true
+	case time.Time:
+		return v.Format(timeFormat), true
+	case bool:
+		return strconv.FormatBool(v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), true
+	default:
+		return fmt.Sprint(v), true
+	}
+}
+
+// jsonValue returns a value scanned from the column of given kind for encoding/json:
+// binary values are encoded with base64, time values with timeFormat.
+func jsonValue(v interface{}, kind valueKind) interface{} {
+	switch v := v.(type) {
+	case []byte:
+		if kind == binaryKind {
+			return v
+		}
+		return string(v)
+	case time.Time:
+		return v.Format(timeFormat)
+	default:
+		return v
+	}
+}
+
+// jsonObject returns JSON object with given keys and values, keeping the order of keys.
+func jsonObject(keys []string, values []interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, v := range values {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(keys[i])
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// sqlLiteral returns SQL literal of value scanned from the column of given kind for the dialect.
+func sqlLiteral(dialect reform.Dialect, v interface{}, kind valueKind) string {
+	quote := func(s string) string {
+		s = strings.Replace(s, "'", "''", -1)
+		switch dialect {
+		case mysql.Dialect:
+			s = strings.Replace(s, `\`, `\\`, -1)
+		case mssql.Dialect, sqlserver.Dialect:
+			return "N'" + s + "'"
+		}
+		return "'" + s + "'"
+	}
+
+	switch v := v.(type) {
+	case nil:
+		return "NULL"
+	case []byte:
+		if kind != binaryKind {
+			return quote(string(v))
+		}
+		switch dialect {
+		case postgresql.Dialect:
+			return `'\x` + hex.EncodeToString(v) + "'"
+		case mssql.Dialect, sqlserver.Dialect:
+			return "0x" + hex.EncodeToString(v)
+		default:
+			return "X'" + hex.EncodeToString(v) + "'"
+		}
+	case time.Time:
+		switch dialect {
+		case mysql.Dialect, mssql.Dialect, sqlserver.Dialect:
+			return quote(v.Format("2006-01-02 15:04:05.999999"))
+		default:
+			return quote(v.Format("2006-01-02 15:04:05.999999999-07:00"))
+		}
+	case bool:
+		if dialect == postgresql.Dialect {
+			return strings.ToUpper(strconv.FormatBool(v))
+		}
+		if v {
+			return "1"
+		}
+		return "0"
+	case int64, float64:
+		s, _ := textValue(v, kind)
+		return s
+	default:
+		s, _ := textValue(v, kind)
+		return quote(s)
+	}
+}
+
+// parseText converts text representation of value made by textValue for the column of given kind.
+// Values which can't be parsed are passed to the database as is.
+func parseText(s string, kind valueKind) interface{} {
+	switch kind {
+	case binaryKind:
+		if strings.HasPrefix(s, `\x`) {
+			if b, err := hex.DecodeString(s[2:]); err == nil {
+				return b
+			}
+		}
+		return []byte(s)
+	case timeKind:
+		if t, err := time.Parse(timeFormat, s); err == nil {
+			return t
+		}
+	case boolKind:
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b
+		}
+	}
+	return s
+}
+
+// parseJSON converts value decoded from JSON (with UseNumber) for the column of given kind.
+func parseJSON(v interface{}, kind valueKind) (interface{}, error) {
+	switch v := v.(type) {
+	case nil, bool:
+		return v, nil
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i, nil
+		}
+		return v.Float64()
+	case string:
+		if kind == binaryKind {
+			return base64.StdEncoding.DecodeString(v)
+		}
+		return parseText(v, kind), nil
+	default:
+		// objects and arrays for JSON columns
+		b, err := json.Marshal(v)
+		return string(b), err
+	}
+}
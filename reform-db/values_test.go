@@ -0,0 +1,181 @@
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xaionaro/reform"
+	"github.com/xaionaro/reform/dialects/mssql"
+	"github.com/xaionaro/reform/dialects/mysql"
+	"github.com/xaionaro/reform/dialects/postgresql"
+	"github.com/xaionaro/reform/dialects/sqlite3"
+	"github.com/xaionaro/reform/dialects/sqlserver"
+)
+
+func TestKindOfType(t *testing.T) {
+	for sqlType, kind := range map[string]valueKind{
+		"bytea":                    binaryKind,
+		"BLOB":                     binaryKind,
+		"varbinary(16)":            binaryKind,
+		"image":                    binaryKind,
+		"timestamp with time zone": timeKind,
+		"DATETIME":                 timeKind,
+		"date":                     timeKind,
+		"interval":                 otherKind,
+		"boolean":                  boolKind,
+		"bit":                      boolKind,
+		"tinyint(1)":               boolKind,
+		"tinyint(4)":               otherKind,
+		"integer":                  otherKind,
+		"character varying(255)":   otherKind,
+		"":                         otherKind,
+	} {
+		assert.Equal(t, kind, kindOfType(sqlType), "%q", sqlType)
+	}
+}
+
+// roundTripValues are values scanned from the database with their column kinds.
+var roundTripValues = []struct {
+	name  string
+	v     interface{}
+	kind  valueKind
+	text  string
+	valid bool
+}{
+	{"NULL", nil, otherKind, "", false},
+	{"binary", []byte{0, 'a', 0xff, '\''}, binaryKind, `\x0061ff27`, true},
+	{"text bytes", []byte("it's"), otherKind, "it's", true},
+	{"time", time.Date(2026, 10, 18, 12, 30, 45, 123456789, time.UTC), timeKind, "2026-10-18T12:30:45.123456789Z", true},
+	{"true", true, boolKind, "true", true},
+	{"false", false, boolKind, "false", true},
+	{"int", int64(-42), otherKind, "-42", true},
+	{"float", 1.5, otherKind, "1.5", true},
+}
+
+func TestTextRoundTrip(t *testing.T) {
+	for _, tc := range roundTripValues {
+		t.Run(tc.name, func(t *testing.T) {
+			s, valid := textValue(tc.v, tc.kind)
+			assert.Equal(t, tc.text, s)
+			assert.Equal(t, tc.valid, valid)
+			if !valid {
+				return
+			}
+
+			expected := tc.v
+			switch v := tc.v.(type) {
+			case []byte:
+				if tc.kind != binaryKind {
+					expected = string(v)
+				}
+			case int64, float64:
+				// numbers are passed to the database as text
+				expected = s
+			}
+			assert.Equal(t, expected, parseText(s, tc.kind))
+		})
+	}
+
+	// values which can't be parsed are passed as is
+	assert.Equal(t, "now", parseText("now", timeKind))
+	assert.Equal(t, "yes", parseText("yes", boolKind))
+	assert.Equal(t, []byte("raw"), parseText("raw", binaryKind))
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	for _, tc := range roundTripValues {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := jsonObject([]string{"v"}, []interface{}{jsonValue(tc.v, tc.kind)})
+			require.NoError(t, err)
+
+			d := json.NewDecoder(bytes.NewReader(b))
+			d.UseNumber()
+			var object map[string]interface{}
+			require.NoError(t, d.Decode(&object))
+			actual, err := parseJSON(object["v"], tc.kind)
+			require.NoError(t, err)
+
+			expected := tc.v
+			if v, ok := tc.v.([]byte); ok && tc.kind != binaryKind {
+				expected = string(v)
+			}
+			assert.Equal(t, expected, actual, "%s", b)
+		})
+	}
+
+	// objects and arrays of JSON columns are passed as text
+	v, err := parseJSON(map[string]interface{}{"a": []interface{}{json.Number("1")}}, otherKind)
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":[1]}`, v)
+
+	_, err = parseJSON("not base64!", binaryKind)
+	assert.Error(t, err)
+}
+
+func TestJSONObjectKeepsOrder(t *testing.T) {
+	b, err := jsonObject([]string{"z", "a", "m"}, []interface{}{1, nil, "x"})
+	require.NoError(t, err)
+	assert.Equal(t, `{"z":1,"a":null,"m":"x"}`, string(b))
+}
+
+func TestSQLLiteral(t *testing.T) {
+	tm := time.Date(2026, 10, 18, 12, 30, 45, 123456000, time.UTC)
+	for _, tc := range []struct {
+		dialect reform.Dialect
+		binary  string
+		time    string
+		bool    string
+		text    string
+	}{
+		{postgresql.Dialect, `'\x00ff'`, `'2026-10-18 12:30:45.123456+00:00'`, "TRUE", `'it''s \n'`},
+		{mysql.Dialect, `X'00ff'`, `'2026-10-18 12:30:45.123456'`, "1", `'it''s \\n'`},
+		{sqlite3.Dialect, `X'00ff'`, `'2026-10-18 12:30:45.123456+00:00'`, "1", `'it''s \n'`},
+		{mssql.Dialect, `0x00ff`, `N'2026-10-18 12:30:45.123456'`, "1", `N'it''s \n'`},
+		{sqlserver.Dialect, `0x00ff`, `N'2026-10-18 12:30:45.123456'`, "1", `N'it''s \n'`},
+	} {
+		t.Run(tc.dialect.String(), func(t *testing.T) {
+			assert.Equal(t, "NULL", sqlLiteral(tc.dialect, nil, binaryKind))
+			assert.Equal(t, tc.binary, sqlLiteral(tc.dialect, []byte{0, 0xff}, binaryKind))
+			assert.Equal(t, tc.time, sqlLiteral(tc.dialect, tm, timeKind))
+			assert.Equal(t, tc.bool, sqlLiteral(tc.dialect, true, boolKind))
+			assert.Equal(t, tc.text, sqlLiteral(tc.dialect, []byte(`it's \n`), otherKind))
+			assert.Equal(t, tc.text, sqlLiteral(tc.dialect, `it's \n`, otherKind))
+			assert.Equal(t, "-42", sqlLiteral(tc.dialect, int64(-42), otherKind))
+			assert.Equal(t, "1.5", sqlLiteral(tc.dialect, 1.5, otherKind))
+		})
+	}
+	assert.Equal(t, "FALSE", sqlLiteral(postgresql.Dialect, false, boolKind))
+	assert.Equal(t, "0", sqlLiteral(mysql.Dialect, false, boolKind))
+
+	// SQLite reads literals back
+	sqlDB, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer sqlDB.Close()
+	for _, tc := range roundTripValues {
+		var actual interface{}
+		err = sqlDB.QueryRow("SELECT " + sqlLiteral(sqlite3.Dialect, tc.v, tc.kind)).Scan(&actual)
+		require.NoError(t, err)
+		expected := tc.v
+		switch v := tc.v.(type) {
+		case []byte:
+			if tc.kind != binaryKind {
+				expected = string(v)
+			}
+		case time.Time:
+			expected = "2026-10-18 12:30:45.123456789+00:00"
+		case bool:
+			expected = int64(0)
+			if v {
+				expected = int64(1)
+			}
+		}
+		assert.Equal(t, expected, actual, tc.name)
+	}
+}
@@ -47,8 +47,13 @@ func processFile(path, file, pack string) error {
 		return err
 	}
 
-	logger.Debugf("%#v", structs)
-	if len(structs) == 0 {
+	enums, err := parse.Enums(srcFilePath)
+	if err != nil {
+		return err
+	}
+
+	logger.Debugf("%#v %#v", structs, enums)
+	if len(structs) == 0 && len(enums) == 0 {
 		return nil
 	}
 
@@ -61,10 +66,16 @@ func processFile(path, file, pack string) error {
 	if _, err = f.WriteString("package " + pack + "\n"); err != nil {
 		return err
 	}
-	if err = prologTemplate.Execute(f, nil); err != nil {
+	if err = prologTemplate.Execute(f, PrologData{HasStructs: len(structs) > 0, HasEnums: len(enums) > 0}); err != nil {
 		return err
 	}
 
+	for _, enum := range enums {
+		if err = enumTemplate.Execute(f, enum); err != nil {
+			return err
+		}
+	}
+
 	sds := make([]StructData, 0, len(structs))
 	for _, str := range structs {
 		// decide about view/table suffix
@@ -114,6 +125,9 @@ func processFile(path, file, pack string) error {
 		}
 	}
 
+	if len(sds) == 0 {
+		return nil
+	}
 	return initTemplate.Execute(f, sds)
 }
 
@@ -23,19 +23,98 @@ type StructData struct {
 	SkipMethodOrder     bool
 }
 
+// PrologData represents information about XXX_reform.go file contents needed for imports.
+type PrologData struct {
+	HasStructs bool
+	HasEnums   bool
+}
+
 var (
 	prologTemplate = template.Must(template.New("prolog").Parse(`
 // Generated with gopkg.in/reform.v1. Do not edit by hand.
 
 import (
+	{{- if .HasStructs }}
+	"context"
+	{{- end }}
 	"database/sql"
+	{{- if .HasEnums }}
+	"database/sql/driver"
+	{{- end }}
 	"fmt"
+	{{- if .HasStructs }}
 	"reflect"
 	"strings"
 	"time"
+	{{- end }}
 
 	"github.com/xaionaro/reform"
 )
+`))
+
+	enumTemplate = template.Must(template.New("enum").Parse(`
+// IsValid returns true if e is one of {{ .Type }} constants.
+func (e {{ .Type }}) IsValid() bool {
+	switch e {
+	case {{ range $i, $c := .Consts }}{{ if $i }}, {{ end }}{{ $c }}{{ end }}:
+		return true
+	default:
+		return false
+	}
+}
+
+{{- if .IsString }}
+
+// String returns the value of e.
+func (e {{ .Type }}) String() string {
+	return string(e)
+}
+{{- else }}
+
+// String returns the name of {{ .Type }} constant, or the value for invalid e.
+func (e {{ .Type }}) String() string {
+	switch e {
+	{{- range .Consts }}
+	case {{ . }}:
+		return "{{ . }}"
+	{{- end }}
+	default:
+		return fmt.Sprintf("{{ .Type }}(%d)", {{ .Kind }}(e))
+	}
+}
+{{- end }}
+
+// EnumValues returns values of all {{ .Type }} constants as stored in the database.
+func ({{ .Type }}) EnumValues() []string {
+	return []string{ {{- range $i, $v := .Values }}{{ if $i }}, {{ end }}{{ printf "%q" $v }}{{ end -}} }
+}
+
+// Value implements driver.Valuer. Values other than {{ .Type }} constants are rejected.
+func (e {{ .Type }}) Value() (driver.Value, error) {
+	if !e.IsValid() {
+		return nil, fmt.Errorf("reform: invalid {{ .Type }} value {{ if .IsString }}%q{{ else }}%d{{ end }}", {{ .Kind }}(e))
+	}
+	return {{ if .IsString }}string(e){{ else }}int64(e){{ end }}, nil
+}
+
+// Scan implements sql.Scanner. Values other than {{ .Type }} constants are rejected.
+func (e *{{ .Type }}) Scan(src interface{}) error {
+	v, err := reform.Enum{{ if .IsString }}String{{ else }}Int{{ end }}(src)
+	if err != nil {
+		return fmt.Errorf("reform: failed to scan {{ .Type }}: %s", err)
+	}
+	if !{{ .Type }}(v).IsValid() {
+		return fmt.Errorf("reform: invalid {{ .Type }} value {{ if .IsString }}%q{{ else }}%d{{ end }}", v)
+	}
+	*e = {{ .Type }}(v)
+	return nil
+}
+
+// check interfaces
+var (
+	_ reform.Enum = {{ .Type }}({{ if .IsString }}""{{ else }}0{{ end }})
+	_ sql.Scanner = (*{{ .Type }})(nil)
+)
 `))
 
 	structTemplate = template.Must(template.New("struct").Parse(`
@@ -43,6 +122,7 @@ type {{ .ScopeType }} struct {
 	item *{{ .Type }}
 
 	db           reform.ReformDBTX
+	sharded      *reform.ShardedDB
 	where        [][]interface{}
 	order        []string
 	groupBy      []string
@@ -50,6 +130,7 @@ type {{ .ScopeType }} struct {
 	tableQuery   *string
 	fieldsFilter []string
 	appendTail   string
+	cacheTTL     *time.Duration
 
 	loggingEnabled  bool
 	loggingAuthor  *string
@@ -170,6 +251,14 @@ func (v *{{ .LogTableType }}) PKColumnIndex() uint {
 
 {{- end }}
 
+// CreateTableIfNotExists creates "{{ .SQLName }}_log" table if it does not exist, see Log().
+func (v {{ .LogTableType }}) CreateTableIfNotExists(db *reform.DB) (bool, error) {
+	if db == nil {
+		db = defaultDB_{{ .Type }}
+	}
+	return db.CreateLogTableIfNotExists(v.s)
+}
+
 var {{ .LogTableVar }} = &{{ .LogTableType }} {
 	s: {{ printf "%#v" .StructInfo.ToLog.UnPointer }},
 	z: new({{ .LogType }}).Values(),
@@ -232,7 +321,7 @@ func (s *{{ .LogType }}) FieldPointersByNames(fieldNames []string) (fieldPointer
 func (s *{{ .Type }}) FieldPointerByName(fieldName string) interface{} {
 	switch (fieldName) {
 	{{- range $i, $f := .Fields }}
-	case "{{ $f.Name }}": return &s.{{ $f.FullName }}
+	case "{{ $f.Name }}": return {{ if $f.IsJSON }}reform.JSON{V: &s.{{ $f.FullName }}}{{ else if $f.IsArray }}reform.Array{V: &s.{{ $f.FullName }}}{{ else if $f.Encryption.Enabled }}reform.Encrypted{V: &s.{{ $f.FullName }}, Table: {{ printf "%q" $.SQLName }}, Column: {{ printf "%q" $f.Column }}}{{ else if $f.NullZero.ScansNullAsZero }}reform.NullZero{V: &s.{{ $f.FullName }}}{{ else }}&s.{{ $f.FullName }}{{ end }}
 	{{- end }}
 	}
 
@@ -242,7 +331,7 @@ func (s *{{ .Type }}) FieldPointerByName(fieldName string) interface{} {
 func (s *{{ .LogType }}) FieldPointerByName(fieldName string) interface{} {
 	switch (fieldName) {
 	{{- range $i, $f := .Fields }}
-	case "{{ $f.Name }}": return &s.{{ $f.FullName }}
+	case "{{ $f.Name }}": return {{ if $f.IsJSON }}reform.JSON{V: &s.{{ $f.FullName }}}{{ else if $f.IsArray }}reform.Array{V: &s.{{ $f.FullName }}}{{ else if $f.Encryption.Enabled }}reform.Encrypted{V: &s.{{ $f.FullName }}, Table: {{ printf "%q" $.SQLName }}, Column: {{ printf "%q" $f.Column }}}{{ else if $f.NullZero.ScansNullAsZero }}reform.NullZero{V: &s.{{ $f.FullName }}}{{ else }}&s.{{ $f.FullName }}{{ end }}
 	{{- end }}
 	case "LogAuthor": return &s.LogAuthor
 	case "LogAction": return &s.LogAction
@@ -273,7 +362,7 @@ func (s {{ .LogType }}) String() string {
 // Returned interface{} values are never untyped nils.
 func (s *{{ .Type }}) Values() []interface{} {
 	return []interface{}{ {{- range .Fields }}
-		s.{{ .FullName }}, {{- end }}
+		{{ if .IsJSON }}reform.JSON{V: s.{{ .FullName }}}{{ else if .IsArray }}reform.Array{V: s.{{ .FullName }}}{{ else if .Encryption.Enabled }}reform.Encrypted{V: s.{{ .FullName }}{{ if .Encryption.IsDeterministic }}, Deterministic: true{{ end }}, Table: {{ printf "%q" $.SQLName }}, Column: {{ printf "%q" .Column }}}{{ else if .NullZero.WritesZeroAsNull }}reform.NullZero{V: s.{{ .FullName }}}{{ else }}s.{{ .FullName }}{{ end }}, {{- end }}
 	}
 }
 func (s *{{ .LogType }}) Values() []interface{} {
@@ -289,7 +378,7 @@ func (s *{{ .LogType }}) Values() []interface{} {
 // Returned interface{} values are never untyped nils.
 func (s *{{ .Type }}) Pointers() []interface{} {
 	return []interface{}{ {{- range .Fields }}
-		&s.{{ .FullName }}, {{- end }}
+		{{ if .IsJSON }}reform.JSON{V: &s.{{ .FullName }}}{{ else if .IsArray }}reform.Array{V: &s.{{ .FullName }}}{{ else if .Encryption.Enabled }}reform.Encrypted{V: &s.{{ .FullName }}, Table: {{ printf "%q" $.SQLName }}, Column: {{ printf "%q" .Column }}}{{ else if .NullZero.ScansNullAsZero }}reform.NullZero{V: &s.{{ .FullName }}}{{ else }}&s.{{ .FullName }}{{ end }}, {{- end }}
 	}
 }
 func (s *{{ .LogType }}) Pointers() []interface{} {
@@ -333,6 +422,57 @@ func (s *{{ .ScopeType }}) DB(db reform.ReformDBTX) *{{ .ScopeType }} {
 	return s
 }
 
+// Tags sets sqlcommenter tags which are appended to every statement of the scope
+func (s {{ .Type }}) Tags(tags reform.Tags) (scope *{{ .ScopeType }}) { return s.Scope().Tags(tags) }
+func (s {{ .ScopeType }}) Tags(tags reform.Tags) *{{ .ScopeType }} {
+	s.db = reform.WithQuerier(s.db, func(q *reform.Querier) *reform.Querier { return q.WithTags(tags) })
+	return &s
+}
+
+// WithContext sets a context for queries of the scope (tags stored by reform.ContextWithTags are appended to every statement)
+func (s {{ .Type }}) WithContext(ctx context.Context) (scope *{{ .ScopeType }}) { return s.Scope().WithContext(ctx) }
+func (s {{ .ScopeType }}) WithContext(ctx context.Context) *{{ .ScopeType }} {
+	s.db = reform.WithQuerier(s.db, func(q *reform.Querier) *reform.Querier { return q.WithContext(ctx) })
+	return &s
+}
+
+// InSchema sets a schema used instead of the model's one by every statement of the scope (see reform.Querier.WithSchema)
+func (s {{ .Type }}) InSchema(schema string) (scope *{{ .ScopeType }}) { return s.Scope().InSchema(schema) }
+func (s {{ .ScopeType }}) InSchema(schema string) *{{ .ScopeType }} {
+	s.db = reform.WithQuerier(s.db, func(q *reform.Querier) *reform.Querier { return q.WithSchema(schema) })
+	return &s
+}
+
+// WithTableResolver sets a resolver of schema and table names for every statement of the scope (see reform.Querier.WithTableResolver)
+func (s {{ .Type }}) WithTableResolver(resolver reform.TableResolver) (scope *{{ .ScopeType }}) { return s.Scope().WithTableResolver(resolver) }
+func (s {{ .ScopeType }}) WithTableResolver(resolver reform.TableResolver) *{{ .ScopeType }} {
+	s.db = reform.WithQuerier(s.db, func(q *reform.Querier) *reform.Querier { return q.WithTableResolver(resolver) })
+	return &s
+}
+
+// Sharded sets a sharded database for the scope: Select, First, Count and Each fan out across all shards,
+// and Insert, Replace, Save, Update and Delete are routed to the shard of the record
+func (s {{ .Type }}) Sharded(db *reform.ShardedDB) (scope *{{ .ScopeType }}) { return s.Scope().Sharded(db) }
+func (s {{ .ScopeType }}) Sharded(db *reform.ShardedDB) *{{ .ScopeType }} {
+	s.sharded = db
+	s.db = db.Shards()[0]
+	return &s
+}
+
+// ForTenant limits every statement of the scope to rows of given tenant (see reform.Querier.WithTenant)
+func (s {{ .Type }}) ForTenant(tenant interface{}) (scope *{{ .ScopeType }}) { return s.Scope().ForTenant(tenant) }
+func (s {{ .ScopeType }}) ForTenant(tenant interface{}) *{{ .ScopeType }} {
+	s.db = reform.WithQuerier(s.db, func(q *reform.Querier) *reform.Querier { return q.WithTenant(tenant) })
+	return &s
+}
+
+// WithoutTenant allows cross-tenant access for statements of the scope (see reform.Querier.WithoutTenant)
+func (s {{ .Type }}) WithoutTenant() (scope *{{ .ScopeType }}) { return s.Scope().WithoutTenant() }
+func (s {{ .ScopeType }}) WithoutTenant() *{{ .ScopeType }} {
+	s.db = reform.WithQuerier(s.db, func(q *reform.Querier) *reform.Querier { return q.WithoutTenant() })
+	return &s
+}
+
 // Gets DB
 func (s {{ .Type }}) Get{{ if eq .ImitateGorm true }}Reform{{ end }}DB() (db *reform.DB) { return s.Scope().Get{{ if eq .ImitateGorm true }}Reform{{ end }}DB() }
 func (s {{ .ScopeType }}) Get{{ if eq .ImitateGorm true }}Reform{{ end }}DB() *reform.DB {
@@ -404,8 +544,8 @@ func (s {{ .ScopeType }}) parseWhereTailComponent(in_args []interface{}, placeho
 		switch arg := in_args[0].(type) {
 {{- if .IsTable }}
 		case int:
-			tail = "{{ .PKField.Column }} "+s.db.OperatorAndPlaceholderOfValueForSQL(in_args[0], *placeholderCounter)
 			*placeholderCounter++
+			tail = "{{ .PKField.Column }} "+s.db.OperatorAndPlaceholderOfValueForSQL(in_args[0], *placeholderCounter)
 			args = s.db.ValueForSQL(in_args[0])
 {{- end }}
 		case string:
@@ -419,8 +559,8 @@ func (s {{ .ScopeType }}) parseWhereTailComponent(in_args []interface{}, placeho
 				newArgs := s.db.ValueForSQL(rawNewArgs)
 				newTailWords := []string{}
 				for range newArgs {
-					newTailWords = append(newTailWords, s.db.GetDialect().Placeholder(*placeholderCounter))
 					*placeholderCounter++
+					newTailWords = append(newTailWords, s.db.GetDialect().Placeholder(*placeholderCounter))
 				}
 				tail += tailWords[idx] + strings.Join(newTailWords, ",")
 				args = append(args, newArgs...)
@@ -584,7 +724,7 @@ func (s *{{ .ScopeType }}) SelectRows(query string, queryArgs ...interface{}) (r
 		return
 	}
 
-	return s.db.Query("SELECT "+query+" FROM ` + "`" + `"+{{ .TableVar }}.Name()+"` + "`" + ` "+tail, append(queryArgs, args...)...)
+	return s.db.FlexSelectColumnsRows({{ .TableVar }}, s.tableQuery, query, queryArgs, tail, args...)
 }
 
 func (s *{{ .ScopeType }}) callStructMethod(str *{{ .Type }}, methodName string) error {
@@ -630,6 +770,31 @@ func (s {{ .ScopeType }}) checkDb() {
 // Select is a handy wrapper for SelectRows() and NextRow(): it makes a query and collects the result into a slice
 func (s {{ .Type }}) Select(args ...interface{}) (result []{{.Type}}, err error) { return s.Scope().Select(args...) }
 func (s {{ .ScopeType }}) Select(args ...interface{}) (result []{{.Type}}, err error) {
+	if s.cacheTTL != nil {
+		var cached interface{}
+		cached, err = s.cached("select", func() (interface{}, error) {
+			s.cacheTTL = nil
+			return s.Select(args...)
+		}, args...)
+		if err != nil {
+			return nil, err
+		}
+		return append([]{{.Type}}(nil), cached.([]{{.Type}})...), nil
+	}
+
+	err = s.Each(func(item {{ .Type }}) error {
+		result = append(result, item)
+		return nil
+	}, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// Each calls f for every record which Select() would return, without collecting them into a slice; it stops on the first error of f
+func (s {{ .ScopeType }}) Each(f func({{ .Type }}) error, args ...interface{}) (err error) {
 	s.checkDb()
 
 	if len(args) > 0 {
@@ -640,6 +805,19 @@ func (s {{ .ScopeType }}) Select(args ...interface{}) (result []{{.Type}}, err e
 		return
 	}
 
+	if s.sharded != nil {
+		query := reform.ShardedQuery{
+			View:              {{ .TableVar }},
+			Tail:              tail,
+			Args:              args,
+			Order:             s.getShardedOrder(),
+			Limit:             s.limit,
+			ForceAnotherTable: s.tableQuery,
+			ForceFields:       s.fieldsFilter,
+		}
+		return s.sharded.Each(query, func(str reform.Struct) error { return f(*str.(*{{ .Type }})) })
+	}
+
 	rows, err := s.db.FlexSelectRows({{ .TableVar }}, s.tableQuery, s.fieldsFilter, tail, args...)
 	if err != nil {
 		return
@@ -648,21 +826,92 @@ func (s {{ .ScopeType }}) Select(args ...interface{}) (result []{{.Type}}, err e
 
 	for rows.Next() {
 		item := {{ .Type }}{}
-		err = rows.Scan(item.FieldPointersByNames(s.fieldsFilter)...)
+		err = s.db.ScanRow(rows, &item, s.fieldsFilter)
 		if err != nil {
 			return
 		}
 
 		s.callStructMethod(&item, "AfterFind")
 
-		result = append(result, item)
+		if err = f(item); err != nil {
+			return
+		}
 	}
 
-	err = rows.Err()
+	return rows.Err()
+}
+
+// Count returns the number of records which Select() would return, ignoring Order() and Limit()
+func (s {{ .ScopeType }}) Count(args ...interface{}) (count int, err error) {
+	s.checkDb()
+
+	if s.cacheTTL != nil {
+		var cached interface{}
+		cached, err = s.cached("count", func() (interface{}, error) {
+			s.cacheTTL = nil
+			return s.Count(args...)
+		}, args...)
+		if err != nil {
+			return 0, err
+		}
+		return cached.(int), nil
+	}
+
+	if len(args) > 0 {
+		s = *s.Where(args[0], args[1:]...)
+	}
+	s.order, s.limit = nil, 0
+	tail, args, err := s.getTail()
 	if err != nil {
 		return
 	}
 
+	if s.sharded != nil {
+		return s.sharded.Count({{ .TableVar }}, tail, args...)
+	}
+	return s.db.Count({{ .TableVar }}, tail, args...)
+}
+
+// Cache makes Select(), First() and Count() return results cached for ttl (zero means no expiration) in the query cache of DB
+func (s {{ .ScopeType }}) Cache(ttl time.Duration) *{{ .ScopeType }} {
+	s.cacheTTL = &ttl
+	return &s
+}
+
+// cached returns the result of load for given kind of query, cached if Cache() was used
+func (s {{ .ScopeType }}) cached(kind string, load func() (interface{}, error), args ...interface{}) (interface{}, error) {
+	if s.sharded != nil {
+		return load()
+	}
+
+	if len(args) > 0 {
+		s = *s.Where(args[0], args[1:]...)
+	}
+	switch kind {
+	case "count":
+		s.order, s.limit = nil, 0
+	case "first":
+		s.limit = 1
+	}
+	tail, args, err := s.getTail()
+	if err != nil {
+		return nil, err
+	}
+	return s.db.CachedQuery({{ .TableVar }}, kind, s.tableQuery, s.fieldsFilter, tail, args, *s.cacheTTL, load)
+}
+
+// getShardedOrder returns columns of Order() to merge sorted results of shards
+func (s *{{ .ScopeType }}) getShardedOrder() (order []reform.ShardedOrder) {
+	for i := 0; i+1 < len(s.order); i += 2 {
+		column := s.order[i]
+		if idx := strings.LastIndex(column, "."); idx >= 0 {
+			column = column[idx+1:]
+		}
+		order = append(order, reform.ShardedOrder{
+			Column: strings.Trim(column, "\x60\"[] "),
+			Desc:   strings.EqualFold(strings.TrimSpace(s.order[i+1]), "DESC"),
+		})
+	}
 	return
 }
 func (s {{ .Type }}) SelectI(args ...interface{}) (result interface{}, err error) { return s.Scope().Select(args...) }
@@ -673,6 +922,38 @@ func (s {{ .Type }}) First(args ...interface{}) (result {{.Type}}, err error) {
 func (s {{ .ScopeType }}) First(args ...interface{}) (result {{.Type}}, err error) {
 	s.checkDb()
 
+	if s.cacheTTL != nil {
+		var cached interface{}
+		cached, err = s.cached("first", func() (interface{}, error) {
+			s.cacheTTL = nil
+			return s.First(args...)
+		}, args...)
+		if err != nil {
+			return
+		}
+		return cached.({{.Type}}), nil
+	}
+
+	if s.sharded != nil {
+		var found bool
+		err = s.Limit(1).Each(func(item {{ .Type }}) error {
+			result, found = item, true
+			return nil
+		}, args...)
+		if err == nil && !found {
+			err = reform.ErrNoRows
+		}
+		return
+	}
+{{- if .IsTable }}
+
+	// primary key lookups may use record cache (see reform.DB.UseRecordCache)
+	if pk, ok := s.primaryKeyLookup(args); ok {
+		err = s.db.FindByPrimaryKeyTo(&result, pk)
+		return
+	}
+{{- end }}
+
 	if len(args) > 0 {
 		s = *s.Where(args[0], args[1:]...)
 	}
@@ -685,9 +966,36 @@ func (s {{ .ScopeType }}) First(args ...interface{}) (result {{.Type}}, err erro
 
 	return
 }
+{{- if .IsTable }}
+
+// primaryKeyLookup returns the primary key if First(args...) selects the row only by it.
+func (s {{ .ScopeType }}) primaryKeyLookup(args []interface{}) (pk int, ok bool) {
+	if len(args) != 1 || len(s.where) > 0 || len(s.groupBy) > 0 || s.tableQuery != nil || len(s.fieldsFilter) > 0 || s.appendTail != "" {
+		return
+	}
+	pk, ok = args[0].(int)
+	return
+}
+{{- end }}
 func (s {{ .Type }}) FirstI(args ...interface{}) (result interface{}, err error) { return s.Scope().First(args...) }
 func (s {{ .ScopeType }}) FirstI(args ...interface{}) (result interface{}, err error) { return s.First(args...) }
 
+// Explain returns the execution plan of the query which Select() would run with the same arguments
+func (s {{ .Type }}) Explain(args ...interface{}) (plan *reform.Plan, err error) { return s.Scope().Explain(args...) }
+func (s {{ .ScopeType }}) Explain(args ...interface{}) (plan *reform.Plan, err error) {
+	s.checkDb()
+
+	if len(args) > 0 {
+		s = *s.Where(args[0], args[1:]...)
+	}
+	tail, args, err := s.getTail()
+	if err != nil {
+		return
+	}
+
+	return s.db.FlexExplain({{ .TableVar }}, s.tableQuery, s.fieldsFilter, tail, args...)
+}
+
 // Sets "GROUP BY".
 func (s {{ .Type }}) Group(args ...interface{}) (scope *{{ .ScopeType }}) { return s.Scope().Group(args...) }
 func (s {{ .ScopeType }}) Group(argsI ...interface{}) (*{{ .ScopeType }}) {
@@ -800,6 +1108,11 @@ func (s *{{ .ScopeType }}) {{ if eq .ImitateGorm true }}Reform{{ end }}Create()
 func (s *{{ .Type }}) {{ if eq .ImitateGorm true }}Reform{{ end }}Insert() (err error) { return s.PtrScope().{{ if eq .ImitateGorm true }}Reform{{ end }}Insert() }
 func (s *{{ .ScopeType }}) {{ if eq .ImitateGorm true }}Reform{{ end }}Insert() (err error) {
 	s.checkDb()
+	if s.sharded != nil {
+		if s.db, err = s.sharded.ShardOf(s.item); err != nil {
+			return
+		}
+	}
 	err = s.db.Insert(s.item)
 	if err == nil {
 		s.doLog("INSERT")
@@ -811,6 +1124,11 @@ func (s *{{ .ScopeType }}) {{ if eq .ImitateGorm true }}Reform{{ end }}Insert()
 func (s *{{ .Type }}) {{ if eq .ImitateGorm true }}Reform{{ end }}Replace() (err error) { return s.PtrScope().{{ if eq .ImitateGorm true }}Reform{{ end }}Replace() }
 func (s *{{ .ScopeType }}) {{ if eq .ImitateGorm true }}Reform{{ end }}Replace() (err error) {
 	s.checkDb()
+	if s.sharded != nil {
+		if s.db, err = s.sharded.ShardOf(s.item); err != nil {
+			return
+		}
+	}
 	err = s.db.Replace(s.item)
 	if err == nil {
 		s.doLog("REPLACE")
@@ -822,6 +1140,11 @@ func (s *{{ .ScopeType }}) {{ if eq .ImitateGorm true }}Reform{{ end }}Replace()
 func (s *{{ .Type }}) {{ if eq .ImitateGorm true }}Reform{{ end }}Save() (err error) { return s.PtrScope().{{ if eq .ImitateGorm true }}Reform{{ end }}Save() }
 func (s *{{ .ScopeType }}) {{ if eq .ImitateGorm true }}Reform{{ end }}Save() (err error) {
 	s.checkDb()
+	if s.sharded != nil {
+		if s.db, err = s.sharded.ShardOf(s.item); err != nil {
+			return
+		}
+	}
 	err = s.db.Save(s.item)
 	if err == nil {
 		s.doLog("INSERT")
@@ -833,6 +1156,11 @@ func (s *{{ .ScopeType }}) {{ if eq .ImitateGorm true }}Reform{{ end }}Save() (e
 func (s {{ .Type }}) {{ if eq .ImitateGorm true }}Reform{{ end }}Update() (err error) { return s.Scope().{{ if eq .ImitateGorm true }}Reform{{ end }}Update() }
 func (s *{{ .ScopeType }}) {{ if eq .ImitateGorm true }}Reform{{ end }}Update() (err error) {
 	s.checkDb()
+	if s.sharded != nil {
+		if s.db, err = s.sharded.ShardOf(s.item); err != nil {
+			return
+		}
+	}
 	err = s.db.Update(s.item)
 	if err == nil {
 		s.doLog("UPDATE")
@@ -844,6 +1172,11 @@ func (s *{{ .ScopeType }}) {{ if eq .ImitateGorm true }}Reform{{ end }}Update()
 func (s {{ .Type }}) {{ if eq .ImitateGorm true }}Reform{{ end }}Delete() (err error) { return s.Scope().{{ if eq .ImitateGorm true }}Reform{{ end }}Delete() }
 func (s *{{ .ScopeType }}) {{ if eq .ImitateGorm true }}Reform{{ end }}Delete() (err error) {
 	s.checkDb()
+	if s.sharded != nil {
+		if s.db, err = s.sharded.ShardOf(s.item); err != nil {
+			return
+		}
+	}
 	err = s.db.Delete(s.item)
 	if err == nil {
 		s.doLog("DELETE")
@@ -869,6 +1202,7 @@ func (s *{{ .ScopeType }}) doLog(requestType string) {
 // Enables logging to table "{{ .SQLName }}_log". This table should has the same schema, except:
 // - Unique/Primary keys should be removed
 // - Should be added next fields: "log_author" (nullable string), "log_date" (timestamp), "log_action" (enum("INSERT", "UPDATE", "DELETE")), "log_comment" (string)
+// Such table can be created with {{ .LogTableVar }}.CreateTableIfNotExists().
 func (s *{{ .Type }}) Log(enableLogging bool, author *string, commentFormat string, commentArgs ...interface{}) (scope *{{ .ScopeType }}) { return s.Scope().Log(enableLogging, author, commentFormat, commentArgs...) }
 func (s *{{ .ScopeType }}) Log(enableLogging bool, author *string, commentFormat string, commentArgs ...interface{}) (scope *{{ .ScopeType }}) {
 	s.loggingEnabled = enableLogging
@@ -891,7 +1225,7 @@ func (s {{ .Type }}) PKValue() interface{} {
 
 // PKPointer returns a pointer to primary key field for that record.
 // Returned interface{} value is never untyped nil.
-func (s {{ .Type }}) PKPointer() interface{} {
+func (s *{{ .Type }}) PKPointer() interface{} {
 	return &s.{{ .PKField.Name }}
 }
 
@@ -900,14 +1234,10 @@ func (s {{ .Type }}) HasPK() bool {
 	return s.{{ .PKField.Name }} != {{ .TableVar }}.z[{{ .TableVar }}.s.PKFieldIndex]
 }
 
-// SetPK sets record primary key.
+// SetPK sets record primary key, converting it to the field type if needed (see reform.AssignPK).
 func (s *{{ .FilterType }}) SetPK(pk interface{}) { (*{{ .Type }})(s).SetPK(pk) }
 func (s *{{ .Type }}) SetPK(pk interface{}) {
-	if i64, ok := pk.(int64); ok {
-		s.{{ .PKField.Name }} = {{ .PKField.Type }}(i64)
-	} else {
-		s.{{ .PKField.Name }} = pk.({{ .PKField.Type }})
-	}
+	reform.AssignPK(&s.{{ .PKField.Name }}, pk)
 }
 
 {{- end }}
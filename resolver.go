@@ -0,0 +1,68 @@
+package reform
+
+// TableResolver returns schema and name of the table or view actually used by queries,
+// given schema and name from the model. It may, for example, select a tenant's schema
+// or a date-partitioned table like raw_records_2026_10.
+type TableResolver func(schema, name string) (string, string)
+
+// WithTableResolver returns a copy of Querier which uses given resolver for every table and view
+// in generated queries and commands (including FindByPrimaryKey, scopes and log tables) and schema management.
+// nil resets the resolver. Returned Querier is tied to the same DB or TX.
+func (q *Querier) WithTableResolver(resolver TableResolver) *Querier {
+	newQ := q.clone()
+	newQ.tableResolver = resolver
+	return newQ
+}
+
+// WithSchema returns a copy of Querier which uses given schema for every table and view
+// instead of the model's one (see WithTableResolver). It is applied after the current resolver, if any,
+// so table names chosen by it are kept. Returned Querier is tied to the same DB or TX.
+func (q *Querier) WithSchema(schema string) *Querier {
+	prev := q.tableResolver
+	return q.WithTableResolver(func(s, name string) (string, string) {
+		if prev != nil {
+			_, name = prev(s, name)
+		}
+		return schema, name
+	})
+}
+
+// WithTableResolver returns a copy of DB with given table resolver. See Querier.WithTableResolver.
+func (db *DB) WithTableResolver(resolver TableResolver) *DB {
+	return db.withQuerier(db.Querier.WithTableResolver(resolver))
+}
+
+// WithSchema returns a copy of DB with given schema. See Querier.WithSchema.
+func (db *DB) WithSchema(schema string) *DB {
+	return db.withQuerier(db.Querier.WithSchema(schema))
+}
+
+// WithTableResolver returns a copy of TX with given table resolver. See Querier.WithTableResolver.
+func (tx *TX) WithTableResolver(resolver TableResolver) *TX {
+	return &TX{Querier: tx.Querier.WithTableResolver(resolver), tx: tx.tx}
+}
+
+// WithSchema returns a copy of TX with given schema. See Querier.WithSchema.
+func (tx *TX) WithSchema(schema string) *TX {
+	return &TX{Querier: tx.Querier.WithSchema(schema), tx: tx.tx}
+}
+
+// resolveTable returns schema and name of the table to use, considering Querier's resolver.
+func (q *Querier) resolveTable(schema, name string) (string, string) {
+	if q.tableResolver == nil {
+		return schema, name
+	}
+	return q.tableResolver(schema, name)
+}
+
+// viewName returns unqualified name of the view, considering Querier's resolver.
+func (q *Querier) viewName(view View) string {
+	_, name := q.resolveTable(view.Schema(), view.Name())
+	return name
+}
+
+// resolveStruct returns a copy of StructInfo with schema and name resolved by Querier's resolver.
+func (q *Querier) resolveStruct(s StructInfo) StructInfo {
+	s.SQLSchema, s.SQLName = q.resolveTable(s.SQLSchema, s.SQLName)
+	return s
+}
@@ -0,0 +1,79 @@
+package reform_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xaionaro/reform"
+	"github.com/xaionaro/reform/dialects/postgresql"
+)
+
+func TestTableResolver(t *testing.T) {
+	view := structInfoTable{s: reform.StructInfo{SQLSchema: "public", SQLName: "raw_records"}}
+	view.Table = &resolverTable{view.s}
+	db := reform.NewDB(nil, postgresql.Dialect, nil)
+	assert.Equal(t, `"public"."raw_records"`, db.QualifiedView(view))
+	assert.Equal(t, `"tenant_42"."raw_records"`, db.WithSchema("tenant_42").QualifiedView(view))
+
+	partitioned := db.WithTableResolver(func(schema, name string) (string, string) {
+		return schema, name + "_2026_10"
+	})
+	assert.Equal(t, `"public"."raw_records_2026_10"`, partitioned.QualifiedView(view))
+	assert.Equal(t, `"tenant_42"."raw_records_2026_10"`, partitioned.WithSchema("tenant_42").QualifiedView(view))
+	assert.Equal(t, `"public"."raw_records"`, partitioned.WithTableResolver(nil).QualifiedView(view))
+	assert.Equal(t, `"public"."raw_records"`, db.QualifiedView(view), "original DB is not changed")
+}
+
+func TestTableResolverSchema(t *testing.T) {
+	db := newSQLite3DB(t, "ATTACH DATABASE ':memory:' AS tenant_42")
+
+	records := structInfoTable{s: reform.StructInfo{
+		Type:    "RawRecord",
+		SQLName: "raw_records",
+		Fields: []reform.FieldInfo{
+			{Name: "ID", Type: "int", Column: "id", IsPK: true},
+			{Name: "Source", Type: "*string", Column: "source", HasIndex: true},
+		},
+	}}
+	records.Table = &resolverTable{records.s}
+
+	tenant := db.WithSchema("tenant_42")
+	report, err := tenant.AutoMigrate(records)
+	require.NoError(t, err)
+	require.Len(t, report.Applied, 1)
+	assert.Equal(t, "tenant_42.raw_records", report.Applied[0].Table)
+
+	_, err = tenant.Exec("INSERT INTO " + tenant.QualifiedView(records) + " (id) VALUES (1)")
+	require.NoError(t, err)
+	var count int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM tenant_42.raw_records").Scan(&count))
+	assert.Equal(t, 1, count)
+	_, err = db.Exec("SELECT * FROM main.raw_records")
+	assert.Error(t, err, "table is created in tenant's schema only")
+
+	created, err := db.WithTableResolver(func(schema, name string) (string, string) {
+		return schema, name + "_2026_10"
+	}).CreateTableIfNotExists(records.s)
+	require.NoError(t, err)
+	assert.True(t, created)
+	_, err = db.Exec("SELECT * FROM main.raw_records_2026_10")
+	assert.NoError(t, err)
+}
+
+// resolverTable is a minimal Table implementation for table resolver tests.
+type resolverTable struct {
+	s reform.StructInfo
+}
+
+func (t *resolverTable) Schema() string                      { return t.s.SQLSchema }
+func (t *resolverTable) Name() string                        { return t.s.SQLName }
+func (t *resolverTable) Columns() []string                   { return []string{"id"} }
+func (t *resolverTable) ColumnNameByFieldName(string) string { return "id" }
+func (t *resolverTable) NewStruct() reform.Struct            { return nil }
+func (t *resolverTable) NewRecord() reform.Record            { return nil }
+func (t *resolverTable) PKColumnIndex() uint                 { return 0 }
+func (t *resolverTable) CreateTableIfNotExists(db *reform.DB) (bool, error) {
+	return db.CreateTableIfNotExists(t.s)
+}
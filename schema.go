@@ -0,0 +1,377 @@
+package reform
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ColumnSchema represents a column of existing table.
+type ColumnSchema struct {
+	Name     string
+	Type     string // normalized by dialect to the form returned by ColumnTypeDialect.ColumnTypeForField, e.g. varchar(255)
+	Nullable bool
+}
+
+// IndexSchema represents an index or a primary key of existing table.
+type IndexSchema struct {
+	Name    string
+	Columns []string
+	Unique  bool
+	Primary bool
+}
+
+// TableSchema represents a schema of existing table.
+type TableSchema struct {
+	Schema  string
+	Name    string
+	Columns []ColumnSchema
+	Indexes []IndexSchema
+}
+
+// Column returns a column with given name, or nil.
+func (t *TableSchema) Column(name string) *ColumnSchema {
+	for i, c := range t.Columns {
+		if strings.EqualFold(c.Name, name) {
+			return &t.Columns[i]
+		}
+	}
+	return nil
+}
+
+// PrimaryKey returns a primary key, or nil if table has none.
+func (t *TableSchema) PrimaryKey() *IndexSchema {
+	for i, index := range t.Indexes {
+		if index.Primary {
+			return &t.Indexes[i]
+		}
+	}
+	return nil
+}
+
+// hasIndex returns true if table has an index starting with given columns
+// (exactly on them for unique indexes). Primary key is considered a unique index.
+func (t *TableSchema) hasIndex(columns []string, unique bool) bool {
+	for _, index := range t.Indexes {
+		if len(index.Columns) < len(columns) || (unique && (!index.Unique || len(index.Columns) != len(columns))) {
+			continue
+		}
+		matches := true
+		for i, c := range columns {
+			if !strings.EqualFold(index.Columns[i], c) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+// SchemaDialect is implemented by dialects which can inspect existing tables and alter them.
+type SchemaDialect interface {
+	Dialect
+
+	// InspectTable returns a schema of existing table, or nil if table does not exist.
+	// Empty schema means the current (default) one.
+	InspectTable(q DBTX, schema, table string) (*TableSchema, error)
+
+	// AlterTableQueries returns queries applying given change of columns or primary key
+	// to the table with given quoted name. Nil is returned if the change can't be
+	// expressed with ALTER TABLE in this dialect.
+	AlterTableQueries(table string, change SchemaChange) []string
+}
+
+// SchemaChangeKind is a kind of difference between a model and existing table.
+type SchemaChangeKind int
+
+const (
+	// MissingTable means the table does not exist.
+	MissingTable SchemaChangeKind = iota
+
+	// MissingColumn means the column does not exist.
+	MissingColumn
+
+	// ExtraColumn means the column exists, but the model has no field for it.
+	ExtraColumn
+
+	// ColumnTypeMismatch means the column type differs.
+	ColumnTypeMismatch
+
+	// ColumnNullabilityMismatch means the column is nullable while the field is not a pointer, or vice versa.
+	ColumnNullabilityMismatch
+
+	// UniqueMismatch means the field is unique while there is no unique index on the column, or vice versa.
+	UniqueMismatch
+
+	// MissingIndex means the field has an index while there is no index on the column.
+	MissingIndex
+
+	// PrimaryKeyMismatch means the primary key differs.
+	PrimaryKeyMismatch
+)
+
+func (k SchemaChangeKind) String() string {
+	switch k {
+	case MissingTable:
+		return "missing table"
+	case MissingColumn:
+		return "missing column"
+	case ExtraColumn:
+		return "extra column"
+	case ColumnTypeMismatch:
+		return "column type mismatch"
+	case ColumnNullabilityMismatch:
+		return "column nullability mismatch"
+	case UniqueMismatch:
+		return "unique mismatch"
+	case MissingIndex:
+		return "missing index"
+	case PrimaryKeyMismatch:
+		return "primary key mismatch"
+	default:
+		return fmt.Sprintf("SchemaChangeKind(%d)", int(k))
+	}
+}
+
+// SchemaChange represents a single difference between a model and existing table.
+type SchemaChange struct {
+	Kind     SchemaChangeKind
+	Field    FieldInfo // expected field, zero value for MissingTable and ExtraColumn
+	Column   string    // column name, empty for MissingTable
+	Index    string    // name of index to create, or name of existing primary key for PrimaryKeyMismatch
+	Expected string    // expected type, nullability, etc.
+	Actual   string    // actual type, nullability, etc.
+
+	// Queries applying this change; empty if it can't be applied automatically or
+	// can lead to a loss of data (like dropping extra columns).
+	Queries []string
+}
+
+// String returns a human-readable description of the change.
+func (c SchemaChange) String() string {
+	res := c.Kind.String()
+	if c.Column != "" {
+		res = c.Column + ": " + res
+	}
+	if c.Expected != "" || c.Actual != "" {
+		res += fmt.Sprintf(": expected %s, got %s", c.Expected, c.Actual)
+	}
+	return res
+}
+
+// SchemaDiff represents differences between a model and existing table.
+type SchemaDiff struct {
+	Table   string // table name, qualified with schema if it is set
+	Changes []SchemaChange
+}
+
+// Queries returns queries applying all changes.
+func (d *SchemaDiff) Queries() []string {
+	var res []string
+	for _, c := range d.Changes {
+		res = append(res, c.Queries...)
+	}
+	return res
+}
+
+// String returns changes as SQL script: descriptions as comments followed by queries.
+// It is ready to be used as a migration file.
+func (d *SchemaDiff) String() string {
+	var b strings.Builder
+	for _, c := range d.Changes {
+		fmt.Fprintf(&b, "-- %s: %s\n", d.Table, c)
+		if len(c.Queries) == 0 && c.Kind != ExtraColumn {
+			b.WriteString("-- can't be applied automatically\n")
+		}
+		for _, q := range c.Queries {
+			b.WriteString(q + ";\n")
+		}
+	}
+	return b.String()
+}
+
+// qualifiedTable returns quoted table name qualified with schema, if it is set.
+func (q *Querier) qualifiedTable(schema, name string) string {
+	if schema == "" {
+		return q.QuoteIdentifier(name)
+	}
+	return q.QuoteIdentifier(schema) + "." + q.QuoteIdentifier(name)
+}
+
+// nullability returns "NULL" or "NOT NULL".
+func nullability(nullable bool) string {
+	if nullable {
+		return "NULL"
+	}
+	return "NOT NULL"
+}
+
+// IndexSchemaDialect is implemented by dialects which qualify index name with schema in CREATE INDEX
+// statement instead of table name, like SQLite3.
+type IndexSchemaDialect interface {
+	Dialect
+
+	// QualifiesIndexWithSchema returns true if index name (and not table name) is qualified with schema.
+	QualifiesIndexWithSchema() bool
+}
+
+// DDLTransactionDialect is implemented by dialects which can't roll back DDL statements in a transaction,
+// like MySQL which commits the current transaction implicitly. Other dialects are expected to support it.
+type DDLTransactionDialect interface {
+	Dialect
+
+	// TransactionalDDL returns true if DDL statements can be rolled back in a transaction.
+	TransactionalDDL() bool
+}
+
+// createIndexQuery returns a query creating given index on table with given schema and name.
+func (q *Querier) createIndexQuery(schema, name string, index IndexInfo) string {
+	table, indexName := q.qualifiedTable(schema, name), q.QuoteIdentifier(index.Name)
+	if d, ok := q.Dialect.(IndexSchemaDialect); ok && d.QualifiesIndexWithSchema() {
+		table, indexName = q.QuoteIdentifier(name), q.qualifiedTable(schema, index.Name)
+	}
+
+	var unique string
+	if index.Unique {
+		unique = "UNIQUE "
+	}
+	columns := make([]string, len(index.Columns))
+	for i, c := range index.Columns {
+		columns[i] = q.QuoteIdentifier(c)
+	}
+	query := fmt.Sprintf("CREATE %sINDEX %s ON %s (%s)", unique, indexName, table, strings.Join(columns, ", "))
+	if index.Where != "" {
+		query += " WHERE " + index.Where
+	}
+	return query
+}
+
+// createTableQueries returns queries creating native enum types used by the model, the table and its indexes.
+func (q *Querier) createTableQueries(s StructInfo) []string {
+	table := q.qualifiedTable(s.SQLSchema, s.SQLName)
+	res := append(q.createTypeQueries(s), fmt.Sprintf("CREATE TABLE %s (\n\t%s\n)", table, strings.Join(q.ColumnDefinitionsOfStruct(s), ",\n\t")))
+	for _, index := range s.Indexes() {
+		res = append(res, q.createIndexQuery(s.SQLSchema, s.SQLName, index))
+	}
+	return res
+}
+
+// DiffSchema compares the model described by given StructInfo with existing table.
+// Dialect should implement SchemaDialect.
+func (q *Querier) DiffSchema(s StructInfo) (*SchemaDiff, error) {
+	dialect, ok := q.Dialect.(SchemaDialect)
+	if !ok {
+		return nil, fmt.Errorf("reform: dialect %s does not support schema inspection", q.Dialect)
+	}
+	return q.diffSchema(dialect, q.resolveStruct(s))
+}
+
+// diffSchema implements DiffSchema for StructInfo with already resolved schema and name.
+func (q *Querier) diffSchema(dialect SchemaDialect, s StructInfo) (*SchemaDiff, error) {
+	diff := &SchemaDiff{Table: s.SQLName}
+	if s.SQLSchema != "" {
+		diff.Table = s.SQLSchema + "." + s.SQLName
+	}
+	actual, err := dialect.InspectTable(q, s.SQLSchema, s.SQLName)
+	if err != nil {
+		return nil, err
+	}
+	if actual == nil {
+		diff.Changes = []SchemaChange{{Kind: MissingTable, Queries: q.createTableQueries(s)}}
+		return diff, nil
+	}
+
+	table := q.qualifiedTable(s.SQLSchema, s.SQLName)
+	alter := func(c SchemaChange) SchemaChange {
+		c.Queries = dialect.AlterTableQueries(table, c)
+		return c
+	}
+
+	indexes := s.Indexes()
+	unique := make(map[string]bool)
+	for _, index := range indexes {
+		if index.Unique && len(index.Columns) == 1 {
+			unique[strings.ToLower(index.Columns[0])] = true
+		}
+	}
+
+	known := make(map[string]bool, len(s.Fields))
+	for _, f := range s.Fields {
+		if f.Column == "" {
+			continue
+		}
+		known[strings.ToLower(f.Column)] = true
+
+		column := actual.Column(f.Column)
+		if column == nil {
+			diff.Changes = append(diff.Changes, alter(SchemaChange{Kind: MissingColumn, Field: f, Column: f.Column}))
+			continue
+		}
+
+		// types are not compared if dialect can't tell the expected one
+		if typeDialect, ok := q.Dialect.(ColumnTypeDialect); ok {
+			expectedType := typeDialect.ColumnTypeForField(f)
+			if !strings.EqualFold(expectedType, column.Type) {
+				diff.Changes = append(diff.Changes, alter(SchemaChange{
+					Kind: ColumnTypeMismatch, Field: f, Column: f.Column, Expected: expectedType, Actual: column.Type,
+				}))
+			}
+		}
+
+		// primary key columns are always NOT NULL, but some databases report them as nullable
+		if !f.IsPK && f.IsNullable() != column.Nullable {
+			diff.Changes = append(diff.Changes, alter(SchemaChange{
+				Kind: ColumnNullabilityMismatch, Field: f, Column: f.Column,
+				Expected: nullability(f.IsNullable()), Actual: nullability(column.Nullable),
+			}))
+		}
+
+		if !f.IsPK && !unique[strings.ToLower(f.Column)] && actual.hasIndex([]string{f.Column}, true) {
+			diff.Changes = append(diff.Changes, SchemaChange{
+				Kind: UniqueMismatch, Field: f, Column: f.Column, Expected: "not unique", Actual: "unique",
+			})
+		}
+	}
+
+	for _, index := range indexes {
+		if actual.hasIndex(index.Columns, index.Unique) {
+			continue
+		}
+		change := SchemaChange{
+			Kind:    MissingIndex,
+			Column:  strings.Join(index.Columns, ", "),
+			Index:   index.Name,
+			Queries: []string{q.createIndexQuery(s.SQLSchema, s.SQLName, index)},
+		}
+		if index.Unique {
+			change.Kind, change.Expected, change.Actual = UniqueMismatch, "unique", "not unique"
+		}
+		diff.Changes = append(diff.Changes, change)
+	}
+
+	for _, c := range actual.Columns {
+		if !known[strings.ToLower(c.Name)] {
+			diff.Changes = append(diff.Changes, SchemaChange{Kind: ExtraColumn, Column: c.Name})
+		}
+	}
+
+	var expectedPK, actualPK, pkName string
+	if s.IsTable() {
+		expectedPK = s.PKField().Column
+	}
+	if pk := actual.PrimaryKey(); pk != nil {
+		actualPK = strings.Join(pk.Columns, ", ")
+		pkName = pk.Name
+	}
+	if !strings.EqualFold(expectedPK, actualPK) {
+		change := SchemaChange{Kind: PrimaryKeyMismatch, Column: expectedPK, Index: pkName, Expected: expectedPK, Actual: actualPK}
+		if s.IsTable() {
+			change.Field = s.PKField()
+		}
+		diff.Changes = append(diff.Changes, alter(change))
+	}
+
+	return diff, nil
+}
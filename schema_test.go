@@ -0,0 +1,229 @@
+package reform_test
+
+import (
+	"database/sql"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xaionaro/reform"
+	"github.com/xaionaro/reform/dialects/mssql"
+	"github.com/xaionaro/reform/dialects/mysql"
+	"github.com/xaionaro/reform/dialects/postgresql"
+	"github.com/xaionaro/reform/dialects/sqlite3"
+)
+
+func TestDiffSchema(t *testing.T) {
+	db := newSQLite3DB(t, `CREATE TABLE people (id integer PRIMARY KEY, name text NOT NULL, email text, nickname text, group_id integer)`)
+
+	info := reform.StructInfo{
+		Type:    "Person",
+		SQLName: "people",
+		Fields: []reform.FieldInfo{
+			{Name: "ID", Type: "int", Column: "id", IsPK: true},
+			{Name: "Name", Type: "string", Column: "name"},
+			{Name: "Email", Type: "*string", Column: "email"},
+			{Name: "Nickname", Type: "*string", Column: "nickname", HasIndex: true},
+			{Name: "Age", Type: "*int", Column: "age"},
+		},
+	}
+
+	diff, err := db.DiffSchema(info)
+	require.NoError(t, err)
+	kinds := make(map[string]reform.SchemaChangeKind)
+	for _, c := range diff.Changes {
+		if c.Kind == reform.ExtraColumn || c.Kind == reform.MissingColumn || c.Kind == reform.MissingIndex {
+			kinds[c.Column] = c.Kind
+		}
+		assert.NotEqual(t, reform.PrimaryKeyMismatch, c.Kind, "%s", c)
+	}
+	assert.Equal(t, reform.MissingIndex, kinds["nickname"])
+	assert.Equal(t, reform.ExtraColumn, kinds["group_id"])
+	assert.Equal(t, reform.MissingColumn, kinds["age"])
+	assert.NotEmpty(t, diff.Queries())
+
+	// applied queries resolve missing columns and indexes
+	for _, query := range diff.Queries() {
+		_, err = db.Exec(query)
+		require.NoError(t, err)
+	}
+	diff, err = db.DiffSchema(info)
+	require.NoError(t, err)
+	for _, c := range diff.Changes {
+		assert.NotContains(t, []reform.SchemaChangeKind{reform.MissingColumn, reform.MissingIndex}, c.Kind, "%s", c)
+	}
+
+	info.SQLName = "no_such_table"
+	diff, err = db.DiffSchema(info)
+	require.NoError(t, err)
+	require.Len(t, diff.Changes, 1)
+	assert.Equal(t, reform.MissingTable, diff.Changes[0].Kind)
+	assert.Len(t, diff.Changes[0].Queries, 2)
+}
+
+func TestCreateTableIfNotExists(t *testing.T) {
+	var ddl []string
+	db := newSQLite3DB(t)
+	db.Use(reform.InterceptorFuncs{
+		OnExec: func(query string, args []interface{}, next reform.ExecFunc) (sql.Result, error) {
+			if strings.HasPrefix(query, "CREATE ") {
+				ddl = append(ddl, query)
+			}
+			return next(query, args)
+		},
+	})
+
+	info := reform.StructInfo{
+		Type:    "Gadget",
+		SQLName: "gadgets",
+		Fields: []reform.FieldInfo{
+			{Name: "ID", Type: "int", Column: "id", IsPK: true},
+			{Name: "Serial", Type: "string", Column: "serial", IsUnique: true},
+			{Name: "Name", Type: "*string", Column: "name", HasIndex: true, SQLSize: 100},
+			{Name: "Kind", Type: "string", Column: "kind", SQLType: "varchar(10)", Default: "'basic'", Indexes: []reform.FieldIndex{{Name: "idx_gadgets_kind_price"}}},
+			{Name: "Price", Type: "int", Column: "price", Default: "0", Check: "price >= 0", Indexes: []reform.FieldIndex{
+				{Name: "idx_gadgets_kind_price"},
+				{Name: "idx_gadgets_paid", Unique: true, Where: "price > 0"},
+			}},
+		},
+	}
+
+	created, err := db.CreateTableIfNotExists(info)
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, []string{
+		"CREATE TABLE \"gadgets\" (\n" +
+			"\t\"id\" integer PRIMARY KEY AUTOINCREMENT NOT NULL,\n" +
+			"\t\"serial\" text NOT NULL,\n" +
+			"\t\"name\" text,\n" +
+			"\t\"kind\" varchar(10) NOT NULL DEFAULT 'basic',\n" +
+			"\t\"price\" integer NOT NULL DEFAULT 0 CHECK (price >= 0)\n" +
+			")",
+		`CREATE UNIQUE INDEX "uniq_gadgets_serial" ON "gadgets" ("serial")`,
+		`CREATE INDEX "idx_gadgets_name" ON "gadgets" ("name")`,
+		`CREATE INDEX "idx_gadgets_kind_price" ON "gadgets" ("kind", "price")`,
+		`CREATE UNIQUE INDEX "idx_gadgets_paid" ON "gadgets" ("price") WHERE price > 0`,
+	}, ddl)
+
+	_, err = db.Exec(`INSERT INTO gadgets (id, serial) VALUES (1, 'A')`)
+	require.NoError(t, err)
+	var kind string
+	var price int
+	require.NoError(t, db.QueryRow(`SELECT kind, price FROM gadgets WHERE id = 1`).Scan(&kind, &price))
+	assert.Equal(t, "basic", kind)
+	assert.Equal(t, 0, price)
+	_, err = db.Exec(`INSERT INTO gadgets (id, serial, price) VALUES (2, 'B', -1)`)
+	assert.Error(t, err, "check constraint")
+	_, err = db.Exec(`INSERT INTO gadgets (id, serial) VALUES (2, 'A')`)
+	assert.Error(t, err, "unique index")
+
+	ddl = nil
+	created, err = db.CreateTableIfNotExists(info)
+	assert.NoError(t, err)
+	assert.False(t, created)
+	assert.Empty(t, ddl)
+
+	diff, err := db.DiffSchema(info)
+	require.NoError(t, err)
+	assert.Empty(t, diff.Changes)
+
+	created, err = db.CreateLogTableIfNotExists(*info.ToLog())
+	require.NoError(t, err)
+	assert.True(t, created)
+
+	query := "INSERT INTO " + db.QuoteIdentifier("gadgets_log") + " (id, serial, log_action, log_date, log_comment) VALUES (" +
+		strings.Join(db.Placeholders(1, 5), ", ") + ")"
+	for i := 0; i < 2; i++ {
+		_, err = db.Exec(query, 1, "A", "INSERT", time.Now(), "")
+		assert.NoError(t, err)
+	}
+}
+
+func TestConsiderSQLTag(t *testing.T) {
+	var f reform.FieldInfo
+	err := f.ConsiderTag(false, "GroupID", reflect.StructTag(`reform:"group_id" sql:"index:idx_group_role,priority:2,`+
+		`where:deleted IN (0, 1),references:app.groups(id),on_delete:set null,default:0,check:group_id >= 0,type:bigint"`))
+	require.NoError(t, err)
+	assert.Equal(t, reform.FieldInfo{
+		Column:           "group_id",
+		HasIndex:         true,
+		Indexes:          []reform.FieldIndex{{Name: "idx_group_role", Priority: 2, Where: "deleted IN (0, 1)"}},
+		SQLType:          "bigint",
+		Default:          "0",
+		Check:            "group_id >= 0",
+		ReferencesTable:  "app.groups",
+		ReferencesColumn: "id",
+		OnDelete:         "SET NULL",
+	}, f)
+
+	for _, tag := range []string{
+		`sql:"priority:1"`,
+		`sql:"index,priority:first"`,
+		`sql:"references:groups"`,
+		`sql:"on_delete:cascade"`,
+		`sql:"references:groups(id),on_delete:drop"`,
+		`sql:"not null"`,
+		`sql_size:"big"`,
+	} {
+		var f reform.FieldInfo
+		assert.Error(t, f.ConsiderTag(false, "Name", reflect.StructTag(`reform:"name" `+tag)), "%s", tag)
+	}
+}
+
+func TestStructInfoIndexes(t *testing.T) {
+	info := reform.StructInfo{
+		SQLName: "members",
+		Fields: []reform.FieldInfo{
+			{Column: "id", IsPK: true, IsUnique: true},
+			{Column: "group_id", Indexes: []reform.FieldIndex{{Name: "idx_group_role", Priority: 2}, {}}},
+			{Column: "role", Indexes: []reform.FieldIndex{{Name: "idx_group_role", Priority: 1, Where: "role <> ''"}}},
+			{Column: "email", IsUnique: true},
+			{Column: "name", HasIndex: true},
+		},
+	}
+	assert.Equal(t, []reform.IndexInfo{
+		{Name: "idx_group_role", Columns: []string{"role", "group_id"}, Where: "role <> ''"},
+		{Name: "idx_members_group_id", Columns: []string{"group_id"}},
+		{Name: "uniq_members_email", Columns: []string{"email"}, Unique: true},
+		{Name: "idx_members_name", Columns: []string{"name"}},
+	}, info.Indexes())
+}
+
+func TestColumnTypeForField(t *testing.T) {
+	fields := []reform.FieldInfo{
+		{Type: "int8"},
+		{Type: "*Integer", Kind: "int32"},
+		{Type: "uint64"},
+		{Type: "float32"},
+		{Type: "float64", SQLPrecision: 10, SQLScale: 2},
+		{Type: "Flag", Kind: "bool"},
+		{Type: "[]uint8"},
+		{Type: "[16]uint8"},
+		{Type: "sql.NullInt64"},
+		{Type: "sql.NullTime"},
+	}
+	for _, tc := range []struct {
+		dialect  reform.ColumnTypeDialect
+		expected []string
+	}{
+		{sqlite3.Dialect, []string{"integer", "integer", "integer", "real", "decimal(10,2)", "boolean", "blob", "blob", "integer", "datetime"}},
+		{postgresql.Dialect, []string{"smallint", "integer", "numeric(20,0)", "real", "numeric(10,2)", "boolean", "bytea", "bytea", "bigint", "timestamptz"}},
+		{mysql.Dialect, []string{"tinyint", "int", "bigint unsigned", "float", "decimal(10,2)", "tinyint(1)", "blob", "binary(16)", "bigint", "datetime"}},
+		{mssql.Dialect, []string{"smallint", "int", "bigint", "real", "decimal(10,2)", "bit", "varbinary(max)", "binary(16)", "bigint", "datetime2"}},
+	} {
+		for i, f := range fields {
+			assert.Equal(t, tc.expected[i], tc.dialect.ColumnTypeForField(f), "%s: %s", tc.dialect, f.Type)
+		}
+	}
+
+	assert.True(t, reform.FieldInfo{Type: "sql.NullString"}.IsNullable())
+	assert.True(t, reform.FieldInfo{Type: "*Integer", Kind: "int32"}.IsNullable())
+	assert.False(t, reform.FieldInfo{Type: "Integer", Kind: "int32"}.IsNullable())
+	assert.True(t, reform.FieldInfo{Type: "ID", Kind: "uint32", IsPK: true}.IsAutoIncrement())
+	assert.Equal(t, `"id" bigserial NOT NULL PRIMARY KEY`,
+		postgresql.Dialect.ColumnDefinitionForField(reform.FieldInfo{Type: "uint64", Column: "id", IsPK: true}))
+}
@@ -0,0 +1,389 @@
+package reform
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ShardFunc returns the index of the shard in [0, number of shards) for given value, which is a record
+// for Insert, Update, Save and Delete, or a primary key for FindByPrimaryKeyTo and FindByPrimaryKeyFrom
+// (or any other value, like a filter value, passed to ShardedDB.Shard).
+type ShardFunc func(value interface{}) int
+
+// ShardByPK returns ShardFunc distributing records among n shards by hash of the primary key.
+// Primary keys should be known before insert, so tables should use "pkgen:" option, not auto-increment.
+func ShardByPK(n int) ShardFunc {
+	return func(value interface{}) int {
+		if record, ok := value.(Record); ok {
+			value = record.PKValue()
+		}
+		return HashShard(value, n)
+	}
+}
+
+// HashShard returns the index of the shard in [0, n) for given key by its hash.
+// Keys with the same string representation (fmt.Sprint) get the same shard.
+func HashShard(key interface{}, n int) int {
+	h := fnv.New32a()
+	fmt.Fprint(h, key)
+	return int(h.Sum32() % uint32(n))
+}
+
+// ShardedDB routes commands to one of several databases (shards) with the same schema,
+// and fans out queries across all shards.
+type ShardedDB struct {
+	shards []*DB
+	shard  ShardFunc
+}
+
+// NewShardedDB creates a new ShardedDB for given shards and ShardFunc.
+func NewShardedDB(shards []*DB, shard ShardFunc) *ShardedDB {
+	if len(shards) == 0 {
+		panic("reform: NewShardedDB: no shards")
+	}
+	return &ShardedDB{shards: shards, shard: shard}
+}
+
+// Shards returns all shards.
+func (s *ShardedDB) Shards() []*DB {
+	return s.shards
+}
+
+// Shard returns the shard for given value (see ShardFunc).
+func (s *ShardedDB) Shard(value interface{}) *DB {
+	i := s.shard(value)
+	if i < 0 || i >= len(s.shards) {
+		panic(fmt.Sprintf("reform: ShardFunc returned %d for %d shards", i, len(s.shards)))
+	}
+	return s.shards[i]
+}
+
+// ShardOf returns the shard for given struct. If it is a record of the table with "pkgen:" option
+// without primary key, the key is generated first (using the first shard), so ShardByPK can be used for inserts.
+func (s *ShardedDB) ShardOf(str Struct) (*DB, error) {
+	if _, err := s.shards[0].generatePK(str); err != nil {
+		return nil, err
+	}
+	return s.Shard(str), nil
+}
+
+// Insert inserts a struct into the shard selected by it (see ShardOf). See Querier.Insert.
+func (s *ShardedDB) Insert(str Struct) error {
+	db, err := s.ShardOf(str)
+	if err != nil {
+		return err
+	}
+	return db.Insert(str)
+}
+
+// Update updates a record in the shard selected by it. See Querier.Update.
+func (s *ShardedDB) Update(record Record) error {
+	return s.Shard(record).Update(record)
+}
+
+// UpdateColumns updates specified columns of a record in the shard selected by it. See Querier.UpdateColumns.
+func (s *ShardedDB) UpdateColumns(record Record, columns ...string) error {
+	return s.Shard(record).UpdateColumns(record, columns...)
+}
+
+// Save saves a record in the shard selected by it (see ShardOf). See Querier.Save.
+func (s *ShardedDB) Save(record Record) error {
+	db, err := s.ShardOf(record)
+	if err != nil {
+		return err
+	}
+	return db.Save(record)
+}
+
+// Delete deletes a record from the shard selected by it. See Querier.Delete.
+func (s *ShardedDB) Delete(record Record) error {
+	return s.Shard(record).Delete(record)
+}
+
+// FindByPrimaryKeyTo queries the shard selected by primary key. See Querier.FindByPrimaryKeyTo.
+func (s *ShardedDB) FindByPrimaryKeyTo(record Record, pk interface{}) error {
+	return s.Shard(pk).FindByPrimaryKeyTo(record, pk)
+}
+
+// FindByPrimaryKeyFrom queries the shard selected by primary key. See Querier.FindByPrimaryKeyFrom.
+func (s *ShardedDB) FindByPrimaryKeyFrom(table Table, pk interface{}) (Record, error) {
+	return s.Shard(pk).FindByPrimaryKeyFrom(table, pk)
+}
+
+// ShardedOrder describes a column which results of shards are sorted by.
+type ShardedOrder struct {
+	Column string
+	Desc   bool
+}
+
+// ShardedQuery describes a SELECT query fanned out to all shards.
+// Tail (with ORDER BY and LIMIT, if any) and Args are the same for every shard;
+// sorted results of shards are merged by Order, and Limit is applied to the merged result.
+// ForceAnotherTable and ForceFields have the same meaning as for Querier.FlexSelectRows.
+type ShardedQuery struct {
+	View              View
+	Tail              string
+	Args              []interface{}
+	Order             []ShardedOrder
+	Limit             int // 0 means no limit
+	ForceAnotherTable *string
+	ForceFields       []string
+}
+
+// NullsOrderDialect is implemented by dialects which sort NULL values after all other values
+// in ascending order (and before them in descending order), like PostgreSQL.
+// NULL values of other dialects go first in ascending order.
+type NullsOrderDialect interface {
+	Dialect
+
+	// NullsLast returns true if NULL values go after all other values in ascending order.
+	NullsLast() bool
+}
+
+// shardCursor is an open result of one shard with the current row.
+type shardCursor struct {
+	q    *Querier
+	rows *sql.Rows
+	str  Struct
+	err  error
+}
+
+// next scans the next row into a new Struct, it sets str to nil when there are no more rows.
+func (c *shardCursor) next(query *ShardedQuery) error {
+	c.str = nil
+	if !c.rows.Next() {
+		return c.rows.Err()
+	}
+	str := query.View.NewStruct()
+	if err := c.q.ScanRow(c.rows, str, query.ForceFields); err != nil {
+		return err
+	}
+	if err := c.q.callStructMethod(str, "AfterFind"); err != nil {
+		return err
+	}
+	c.str = str
+	return nil
+}
+
+// Each runs the query on all shards in parallel and calls f for every row of the merged result, in order.
+// Strings are compared bytewise, so Order should not use columns with other collations.
+// NULL values are placed as the dialect places them by default (see NullsOrderDialect),
+// so Tail should not use NULLS FIRST or NULLS LAST for Order columns.
+// If f returns an error, iteration stops and that error is returned.
+func (s *ShardedDB) Each(query ShardedQuery, f func(Struct) error) error {
+	cursors := make([]*shardCursor, len(s.shards))
+	var wg sync.WaitGroup
+	for i, db := range s.shards {
+		cursors[i] = &shardCursor{q: db.Querier}
+		wg.Add(1)
+		go func(c *shardCursor) {
+			defer wg.Done()
+			c.rows, c.err = c.q.FlexSelectRows(query.View, query.ForceAnotherTable, query.ForceFields, query.Tail, query.Args...)
+			if c.err == nil {
+				c.err = c.next(&query)
+			}
+		}(cursors[i])
+	}
+	wg.Wait()
+	defer func() {
+		for _, c := range cursors {
+			if c.rows != nil {
+				c.rows.Close()
+			}
+		}
+	}()
+	for _, c := range cursors {
+		if c.err != nil {
+			return c.err
+		}
+	}
+
+	var nullsLast bool
+	if d, ok := s.shards[0].Dialect.(NullsOrderDialect); ok {
+		nullsLast = d.NullsLast()
+	}
+
+	columns := query.View.Columns()
+	order := make([]int, len(query.Order))
+	for i, o := range query.Order {
+		order[i] = -1
+		for j, c := range columns {
+			if strings.EqualFold(c, o.Column) {
+				order[i] = j
+			}
+		}
+		if order[i] < 0 {
+			return fmt.Errorf("reform: unknown order column %s of %s", o.Column, query.View.Name())
+		}
+	}
+
+	for n := 0; query.Limit <= 0 || n < query.Limit; n++ {
+		// without order results of shards are returned one after another
+		var min *shardCursor
+		for _, c := range cursors {
+			if c.str != nil && (min == nil || shardLess(c.str.Values(), min.str.Values(), order, query.Order, nullsLast)) {
+				min = c
+			}
+		}
+		if min == nil {
+			return nil
+		}
+		if err := f(min.str); err != nil {
+			return err
+		}
+		if err := min.next(&query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SelectAll runs the query on all shards in parallel and returns the merged result. See Each.
+func (s *ShardedDB) SelectAll(query ShardedQuery) ([]Struct, error) {
+	var res []Struct
+	err := s.Each(query, func(str Struct) error {
+		res = append(res, str)
+		return nil
+	})
+	return res, err
+}
+
+// Count returns the total number of rows of view with tail and args in all shards, counted in parallel.
+// See Querier.Count.
+func (s *ShardedDB) Count(view View, tail string, args ...interface{}) (int, error) {
+	counts := make([]int, len(s.shards))
+	errs := make([]error, len(s.shards))
+	var wg sync.WaitGroup
+	for i, db := range s.shards {
+		wg.Add(1)
+		go func(i int, db *DB) {
+			defer wg.Done()
+			counts[i], errs[i] = db.Count(view, tail, args...)
+		}(i, db)
+	}
+	wg.Wait()
+
+	var total int
+	for i, err := range errs {
+		if err != nil {
+			return 0, err
+		}
+		total += counts[i]
+	}
+	return total, nil
+}
+
+// shardLess returns true if row a goes before row b in given order.
+func shardLess(a, b []interface{}, columns []int, order []ShardedOrder, nullsLast bool) bool {
+	for i, c := range columns {
+		cmp := compareValues(a[c], b[c], nullsLast)
+		if order[i].Desc {
+			cmp = -cmp
+		}
+		if cmp != 0 {
+			return cmp < 0
+		}
+	}
+	return false
+}
+
+// compareValues compares values of the same column, NULL goes first unless nullsLast is true.
+func compareValues(a, b interface{}, nullsLast bool) int {
+	a, b = comparableValue(a), comparableValue(b)
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return compareOrdered(!nullsLast, nullsLast)
+	case b == nil:
+		return compareOrdered(nullsLast, !nullsLast)
+	}
+
+	switch a := a.(type) {
+	case int64:
+		if b, ok := b.(int64); ok {
+			return compareOrdered(a < b, a > b)
+		}
+	case uint64:
+		if b, ok := b.(uint64); ok {
+			return compareOrdered(a < b, a > b)
+		}
+	case float64:
+		if b, ok := b.(float64); ok {
+			return compareOrdered(a < b, a > b)
+		}
+	case string:
+		if b, ok := b.(string); ok {
+			return strings.Compare(a, b)
+		}
+	case []byte:
+		if b, ok := b.([]byte); ok {
+			return bytes.Compare(a, b)
+		}
+	case bool:
+		if b, ok := b.(bool); ok {
+			return compareOrdered(!a && b, a && !b)
+		}
+	case time.Time:
+		if b, ok := b.(time.Time); ok {
+			return compareOrdered(a.Before(b), a.After(b))
+		}
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+func compareOrdered(less, greater bool) int {
+	switch {
+	case less:
+		return -1
+	case greater:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// comparableValue converts field value to int64, uint64, float64, string, []byte, bool, time.Time or nil.
+func comparableValue(v interface{}) interface{} {
+	if valuer, ok := v.(driver.Valuer); ok {
+		if dv, err := valuer.Value(); err == nil {
+			v = dv
+		}
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return rv.Uint()
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	case reflect.String:
+		return rv.String()
+	case reflect.Bool:
+		return rv.Bool()
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return rv.Bytes()
+		}
+	}
+	if t, ok := rv.Interface().(time.Time); ok {
+		return t
+	}
+	return rv.Interface()
+}
@@ -0,0 +1,113 @@
+package reform_test
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xaionaro/reform"
+	"github.com/xaionaro/reform/dialects/postgresql"
+	"github.com/xaionaro/reform/dialects/sqlite3"
+)
+
+func TestShardedDB(t *testing.T) {
+	shards := make([]*reform.DB, 3)
+	for i := range shards {
+		shards[i] = newSQLite3DB(t, `CREATE TABLE docs (id integer PRIMARY KEY, tenant_id integer NOT NULL, title text NOT NULL)`).WithoutTenant()
+	}
+	db := reform.NewShardedDB(shards, reform.ShardByPK(len(shards)))
+
+	var titles []string
+	for i := 1; i <= 30; i++ {
+		title := fmt.Sprintf("doc %02d", (i*7)%30)
+		titles = append(titles, title)
+		require.NoError(t, db.Insert(&tenantDoc{ID: int64(i), Title: title}))
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(titles)))
+
+	for i, shard := range shards {
+		count, err := shard.Count(tenantDocs, "")
+		require.NoError(t, err)
+		assert.NotZero(t, count, "shard %d", i)
+	}
+	count, err := db.Count(tenantDocs, "WHERE title < ?", "doc 10")
+	require.NoError(t, err)
+	assert.Equal(t, 10, count)
+
+	docs, err := db.SelectAll(reform.ShardedQuery{
+		View:  tenantDocs,
+		Tail:  "ORDER BY title DESC LIMIT 7",
+		Order: []reform.ShardedOrder{{Column: "title", Desc: true}},
+		Limit: 7,
+	})
+	require.NoError(t, err)
+	require.Len(t, docs, 7)
+	for i, str := range docs {
+		assert.Equal(t, titles[i], str.(*tenantDoc).Title)
+	}
+
+	record, err := db.FindByPrimaryKeyFrom(tenantDocs, int64(12))
+	require.NoError(t, err)
+	assert.Equal(t, "doc 24", record.(*tenantDoc).Title)
+	record.(*tenantDoc).Title = "renamed"
+	require.NoError(t, db.Update(record.(*tenantDoc)))
+	doc := new(tenantDoc)
+	require.NoError(t, db.FindByPrimaryKeyTo(doc, int64(12)))
+	assert.Equal(t, "renamed", doc.Title)
+	require.NoError(t, db.Delete(doc))
+	assert.Equal(t, reform.ErrNoRows, db.FindByPrimaryKeyTo(doc, int64(12)))
+
+	stop := errors.New("stop")
+	var n int
+	err = db.Each(reform.ShardedQuery{View: tenantDocs}, func(reform.Struct) error {
+		if n++; n == 5 {
+			return stop
+		}
+		return nil
+	})
+	assert.Equal(t, stop, err)
+	assert.Equal(t, 5, n)
+
+	_, err = db.SelectAll(reform.ShardedQuery{View: tenantDocs, Order: []reform.ShardedOrder{{Column: "nope"}}})
+	assert.Error(t, err)
+}
+
+func TestShardLessNulls(t *testing.T) {
+	null, one := []interface{}{sql.NullString{}}, []interface{}{sql.NullString{String: "1", Valid: true}}
+	for _, tc := range []struct {
+		desc, nullsLast bool
+		nullFirst       bool
+	}{
+		{desc: false, nullsLast: false, nullFirst: true}, // SQLite, MySQL, SQL Server ASC
+		{desc: true, nullsLast: false, nullFirst: false}, // SQLite, MySQL, SQL Server DESC
+		{desc: false, nullsLast: true, nullFirst: false}, // PostgreSQL ASC
+		{desc: true, nullsLast: true, nullFirst: true},   // PostgreSQL DESC
+	} {
+		order := []reform.ShardedOrder{{Column: "c", Desc: tc.desc}}
+		assert.Equal(t, tc.nullFirst, reform.ShardLess(null, one, []int{0}, order, tc.nullsLast), "%+v", tc)
+		assert.Equal(t, !tc.nullFirst, reform.ShardLess(one, null, []int{0}, order, tc.nullsLast), "%+v", tc)
+		assert.False(t, reform.ShardLess(null, null, []int{0}, order, tc.nullsLast), "%+v", tc)
+	}
+
+	var d interface{} = postgresql.Dialect
+	assert.True(t, d.(reform.NullsOrderDialect).NullsLast())
+	d = sqlite3.Dialect
+	_, ok := d.(reform.NullsOrderDialect)
+	assert.False(t, ok)
+}
+
+func TestHashShard(t *testing.T) {
+	seen := make(map[int]bool)
+	for i := 0; i < 100; i++ {
+		shard := reform.HashShard(i, 4)
+		assert.True(t, shard >= 0 && shard < 4)
+		assert.Equal(t, shard, reform.HashShard(i, 4))
+		seen[shard] = true
+	}
+	assert.Len(t, seen, 4)
+}
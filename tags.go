@@ -0,0 +1,160 @@
+package reform
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// Tags are key/value pairs attached to SQL statements as sqlcommenter-style comments,
+// for example: /*request_id='42',route='%2Fusers'*/.
+// See https://google.github.io/sqlcommenter/spec/ for details.
+type Tags map[string]string
+
+// String returns tags in sqlcommenter format without comment delimiters,
+// for example: request_id='42',route='%2Fusers'. Keys are sorted.
+func (t Tags) String() string {
+	keys := make([]string, 0, len(t))
+	for k := range t {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		v := strings.Replace(sqlcommenterEscape(t[k]), "'", `\'`, -1)
+		parts[i] = sqlcommenterEscape(k) + "='" + v + "'"
+	}
+	return strings.Join(parts, ",")
+}
+
+// merge returns a new Tags with pairs from both t and other, other wins in case of conflicts.
+func (t Tags) merge(other Tags) Tags {
+	res := make(Tags, len(t)+len(other))
+	for k, v := range t {
+		res[k] = v
+	}
+	for k, v := range other {
+		res[k] = v
+	}
+	return res
+}
+
+// sqlcommenterEscape URL-encodes s the same way as JavaScript's encodeURIComponent does.
+func sqlcommenterEscape(s string) string {
+	const hex = "0123456789ABCDEF"
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+			b.WriteByte(c)
+		case strings.IndexByte("-_.!~*'()", c) >= 0:
+			b.WriteByte(c)
+		default:
+			b.WriteByte('%')
+			b.WriteByte(hex[c>>4])
+			b.WriteByte(hex[c&15])
+		}
+	}
+	return b.String()
+}
+
+// appendTags appends tags comment to the end of query (before trailing semicolon, if any).
+// It is called before interceptors, so they see the same query as the database.
+func appendTags(query string, tags Tags) string {
+	if len(tags) == 0 {
+		return query
+	}
+
+	comment := "/*" + tags.String() + "*/"
+	trimmed := strings.TrimRight(query, " \t\r\n")
+	semicolon := strings.HasSuffix(trimmed, ";")
+	if semicolon {
+		trimmed = trimmed[:len(trimmed)-1]
+	}
+
+	// start a new line if the last one has "--" comment, otherwise it would contain tags too
+	sep := " "
+	if strings.Contains(trimmed[strings.LastIndexByte(trimmed, '\n')+1:], "--") {
+		sep = "\n"
+	}
+	res := trimmed + sep + comment
+	if semicolon {
+		res += ";"
+	}
+	return res
+}
+
+type tagsContextKey struct{}
+
+// ContextWithTags returns a copy of ctx with given tags added to the tags already stored in ctx.
+// Querier created with WithContext adds them to every statement.
+func ContextWithTags(ctx context.Context, tags Tags) context.Context {
+	return context.WithValue(ctx, tagsContextKey{}, TagsFromContext(ctx).merge(tags))
+}
+
+// TagsFromContext returns tags stored in ctx by ContextWithTags, or nil.
+func TagsFromContext(ctx context.Context) Tags {
+	tags, _ := ctx.Value(tagsContextKey{}).(Tags)
+	return tags
+}
+
+// statementTags returns all tags for statements of this Querier: ones from its context and ones set by WithTags.
+func (q *Querier) statementTags() Tags {
+	if q.ctx == nil {
+		return q.tags
+	}
+	ctxTags := TagsFromContext(q.ctx)
+	if len(ctxTags) == 0 {
+		return q.tags
+	}
+	return ctxTags.merge(q.tags)
+}
+
+// WithTags returns a copy of Querier with given sqlcommenter tags added to already set ones.
+// Tags are appended to every statement executed by returned Querier, including raw Exec and Query calls.
+// Returned Querier is tied to the same DB or TX.
+func (q *Querier) WithTags(tags Tags) *Querier {
+	newQ := q.clone()
+	newQ.tags = q.tags.merge(tags)
+	return newQ
+}
+
+// WithContext returns a copy of Querier with set context. Tags stored in ctx by ContextWithTags
+// are appended to every statement, and ctx is passed to the underlying database driver.
+// Returned Querier is tied to the same DB or TX.
+func (q *Querier) WithContext(ctx context.Context) *Querier {
+	newQ := q.clone()
+	newQ.ctx = ctx
+	return newQ
+}
+
+// Context returns a context set by WithContext, or context.Background().
+func (q *Querier) Context() context.Context {
+	if q.ctx == nil {
+		return context.Background()
+	}
+	return q.ctx
+}
+
+// WithTags returns a copy of DB with given sqlcommenter tags. See Querier.WithTags.
+func (db *DB) WithTags(tags Tags) *DB {
+	return db.withQuerier(db.Querier.WithTags(tags))
+}
+
+// WithContext returns a copy of DB with set context. See Querier.WithContext.
+func (db *DB) WithContext(ctx context.Context) *DB {
+	return db.withQuerier(db.Querier.WithContext(ctx))
+}
+
+// WithTags returns a copy of TX with given sqlcommenter tags. See Querier.WithTags.
+func (tx *TX) WithTags(tags Tags) *TX {
+	return &TX{Querier: tx.Querier.WithTags(tags), tx: tx.tx}
+}
+
+// WithContext returns a copy of TX with set context. See Querier.WithContext.
+func (tx *TX) WithContext(ctx context.Context) *TX {
+	return &TX{Querier: tx.Querier.WithContext(ctx), tx: tx.tx}
+}
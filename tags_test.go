@@ -0,0 +1,53 @@
+package reform_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xaionaro/reform"
+)
+
+func TestTagsString(t *testing.T) {
+	tags := reform.Tags{
+		"route":      "/users/{id}",
+		"request_id": "42",
+		"comment":    "it's",
+	}
+	assert.Equal(t, `comment='it\'s',request_id='42',route='%2Fusers%2F%7Bid%7D'`, tags.String())
+	assert.Equal(t, "", reform.Tags(nil).String())
+}
+
+func TestContextWithTags(t *testing.T) {
+	ctx := reform.ContextWithTags(context.Background(), reform.Tags{"a": "1", "b": "2"})
+	ctx = reform.ContextWithTags(ctx, reform.Tags{"b": "3"})
+	assert.Equal(t, reform.Tags{"a": "1", "b": "3"}, reform.TagsFromContext(ctx))
+	assert.Nil(t, reform.TagsFromContext(context.Background()))
+}
+
+func TestStatementTags(t *testing.T) {
+	var queries []string
+	db := newSQLite3DB(t).WithInterceptors(reform.InterceptorFuncs{
+		OnExec: func(query string, args []interface{}, next reform.ExecFunc) (sql.Result, error) {
+			queries = append(queries, query)
+			return next(query, args)
+		},
+		OnQueryRow: func(query string, args []interface{}, next reform.QueryRowFunc) *sql.Row {
+			queries = append(queries, query)
+			return next(query, args)
+		},
+	}).WithTags(reform.Tags{"route": "/docs"})
+
+	// interceptors see tagged queries, trailing comments do not hide tags
+	_, err := db.Exec("CREATE TABLE docs (id integer PRIMARY KEY);\n")
+	require.NoError(t, err)
+	var n int
+	require.NoError(t, db.QueryRow("SELECT count(*) FROM docs -- all of them").Scan(&n))
+	assert.Equal(t, []string{
+		"CREATE TABLE docs (id integer PRIMARY KEY) /*route='%2Fdocs'*/;",
+		"SELECT count(*) FROM docs -- all of them\n/*route='%2Fdocs'*/",
+	}, queries)
+}
@@ -0,0 +1,176 @@
+package reform
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrNoTenant is returned by queries and commands for tables with tenant field (see FieldInfo.IsTenant)
+// when neither tenant is set (with WithTenant or ContextWithTenant), nor cross-tenant access is allowed with WithoutTenant.
+var ErrNoTenant = errors.New("reform: tenant is not set, use WithTenant() or WithoutTenant()")
+
+// isTenantField returns true if the field has "tenant" option in "reform:" (or "gorm:") tag.
+func isTenantField(tag reflect.StructTag, imitateGorm bool) bool {
+	parts := strings.Split(tag.Get("reform"), ",")[1:]
+	if imitateGorm {
+		parts = strings.Split(tag.Get("gorm"), ";")
+	}
+	for _, part := range parts {
+		if strings.TrimSpace(part) == "tenant" {
+			return true
+		}
+	}
+	return false
+}
+
+// TenantField returns tenant field (see FieldInfo.IsTenant), or nil if there is none.
+func (s *StructInfo) TenantField() *FieldInfo {
+	for i, f := range s.Fields {
+		if f.IsTenant {
+			return &s.Fields[i]
+		}
+	}
+	return nil
+}
+
+type tenantContextKey struct{}
+
+// ContextWithTenant returns a copy of ctx with given tenant. Queries of Querier with that context
+// (see WithContext) are limited to rows of that tenant, unless the Querier has its own tenant.
+func ContextWithTenant(ctx context.Context, tenant interface{}) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenant)
+}
+
+// TenantFromContext returns tenant stored in ctx by ContextWithTenant, or nil.
+func TenantFromContext(ctx context.Context) interface{} {
+	return ctx.Value(tenantContextKey{})
+}
+
+// WithTenant returns a copy of Querier which limits queries and commands for tables with tenant field
+// to rows of given tenant: SELECT, UPDATE and DELETE get "tenant_id = ?" predicate, and INSERT stamps
+// the tenant field of the record. Raw Exec, Query and QueryRow are not changed.
+// Returned Querier is tied to the same DB or TX.
+func (q *Querier) WithTenant(tenant interface{}) *Querier {
+	newQ := q.clone()
+	newQ.tenant = tenant
+	newQ.withoutTenant = false
+	return newQ
+}
+
+// WithoutTenant returns a copy of Querier which allows cross-tenant access to tables with tenant field.
+// Returned Querier is tied to the same DB or TX.
+func (q *Querier) WithoutTenant() *Querier {
+	newQ := q.clone()
+	newQ.tenant = nil
+	newQ.withoutTenant = true
+	return newQ
+}
+
+// WithTenant returns a copy of DB with given tenant. See Querier.WithTenant.
+func (db *DB) WithTenant(tenant interface{}) *DB {
+	return db.withQuerier(db.Querier.WithTenant(tenant))
+}
+
+// WithoutTenant returns a copy of DB allowing cross-tenant access. See Querier.WithoutTenant.
+func (db *DB) WithoutTenant() *DB {
+	return db.withQuerier(db.Querier.WithoutTenant())
+}
+
+// WithTenant returns a copy of TX with given tenant. See Querier.WithTenant.
+func (tx *TX) WithTenant(tenant interface{}) *TX {
+	return &TX{Querier: tx.Querier.WithTenant(tenant), tx: tx.tx}
+}
+
+// WithoutTenant returns a copy of TX allowing cross-tenant access. See Querier.WithoutTenant.
+func (tx *TX) WithoutTenant() *TX {
+	return &TX{Querier: tx.Querier.WithoutTenant(), tx: tx.tx}
+}
+
+// Tenant returns the current tenant set by WithTenant or stored in the context, or nil.
+func (q *Querier) Tenant() interface{} {
+	if q.tenant != nil || q.withoutTenant || q.ctx == nil {
+		return q.tenant
+	}
+	return TenantFromContext(q.ctx)
+}
+
+// tenantFilter returns tenant field of the view and the current tenant.
+// Nil field is returned if the view has no tenant field or cross-tenant access is allowed.
+func (q *Querier) tenantFilter(view View) (*FieldInfo, interface{}, error) {
+	if q.withoutTenant {
+		return nil, nil, nil
+	}
+	t, ok := view.(interface{ StructInfo() StructInfo })
+	if !ok {
+		return nil, nil, nil
+	}
+	s := t.StructInfo()
+	field := s.TenantField()
+	if field == nil {
+		return nil, nil, nil
+	}
+	tenant := q.Tenant()
+	if tenant == nil {
+		return nil, nil, ErrNoTenant
+	}
+	return field, tenant, nil
+}
+
+// argBefore returns a placeholder for argument which precedes args in the query text, and new args.
+func (q *Querier) argBefore(arg interface{}, args []interface{}) (string, []interface{}) {
+	if q.Placeholder(1) == q.Placeholder(2) {
+		// positional placeholders like ?
+		return q.Placeholder(1), append([]interface{}{arg}, args...)
+	}
+	return q.Placeholder(len(args) + 1), append(args[:len(args):len(args)], arg)
+}
+
+// stampTenant sets the tenant field of str to the current tenant. It returns an error if the field
+// is already set to another tenant.
+func (q *Querier) stampTenant(str Struct) error {
+	field, tenant, err := q.tenantFilter(str.View())
+	if field == nil {
+		return err
+	}
+
+	v, t, err := tenantFieldValue(str, field, tenant)
+	if err != nil {
+		return err
+	}
+	switch {
+	case v.IsZero():
+		v.Set(t)
+	case !reflect.DeepEqual(v.Interface(), t.Interface()):
+		return fmt.Errorf("reform: %s belongs to tenant %v, not to %v", str.View().Name(), v.Interface(), tenant)
+	}
+	return nil
+}
+
+// tenantFieldValue returns settable value of the tenant field of str (allocating nil pointer),
+// and tenant converted to its type.
+func tenantFieldValue(str Struct, field *FieldInfo, tenant interface{}) (v, t reflect.Value, err error) {
+	p := str.FieldPointerByName(field.Name)
+	switch w := p.(type) {
+	case NullZero:
+		p = w.V
+	case Encrypted:
+		p = w.V
+	}
+	v = reflect.ValueOf(p).Elem()
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+
+	t = reflect.ValueOf(tenant)
+	if !t.Type().ConvertibleTo(v.Type()) || (t.Kind() == reflect.String) != (v.Kind() == reflect.String) {
+		err = fmt.Errorf("reform: can't use tenant %v (%T) for field %s of type %s", tenant, tenant, field.Name, v.Type())
+		return
+	}
+	return v, t.Convert(v.Type()), nil
+}
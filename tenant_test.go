@@ -0,0 +1,193 @@
+package reform_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xaionaro/reform"
+	"github.com/xaionaro/reform/dialects/postgresql"
+)
+
+// tenantDoc is a hand-written record with tenant field, like generated ones.
+type tenantDoc struct {
+	ID       int64
+	TenantID int64
+	Title    string
+}
+
+type tenantDocTable struct{}
+
+var tenantDocs = &tenantDocTable{}
+
+func (*tenantDocTable) Schema() string                                  { return "" }
+func (*tenantDocTable) Name() string                                    { return "docs" }
+func (*tenantDocTable) Columns() []string                               { return []string{"id", "tenant_id", "title"} }
+func (*tenantDocTable) ColumnNameByFieldName(string) string             { return "" }
+func (*tenantDocTable) NewStruct() reform.Struct                        { return new(tenantDoc) }
+func (*tenantDocTable) NewRecord() reform.Record                        { return new(tenantDoc) }
+func (*tenantDocTable) PKColumnIndex() uint                             { return 0 }
+func (*tenantDocTable) CreateTableIfNotExists(*reform.DB) (bool, error) { return false, nil }
+func (*tenantDocTable) StructInfo() reform.StructInfo {
+	return reform.StructInfo{Type: "tenantDoc", SQLName: "docs", PKFieldIndex: 0, Fields: []reform.FieldInfo{
+		{Name: "ID", Type: "int64", Column: "id", IsPK: true},
+		{Name: "TenantID", Type: "int64", Column: "tenant_id", IsTenant: true},
+		{Name: "Title", Type: "string", Column: "title"},
+	}}
+}
+
+func (d *tenantDoc) String() string          { return fmt.Sprintf("%+v", *d) }
+func (d *tenantDoc) Values() []interface{}   { return []interface{}{d.ID, d.TenantID, d.Title} }
+func (d *tenantDoc) Pointers() []interface{} { return []interface{}{&d.ID, &d.TenantID, &d.Title} }
+func (d *tenantDoc) FieldPointerByName(name string) interface{} {
+	return reflect.ValueOf(d).Elem().FieldByName(name).Addr().Interface()
+}
+func (d *tenantDoc) FieldPointersByNames(names []string) []interface{} {
+	if len(names) == 0 {
+		return d.Pointers()
+	}
+	res := make([]interface{}, len(names))
+	for i, name := range names {
+		res[i] = d.FieldPointerByName(name)
+	}
+	return res
+}
+func (d *tenantDoc) View() reform.View      { return tenantDocs }
+func (d *tenantDoc) Table() reform.Table    { return tenantDocs }
+func (d *tenantDoc) PKValue() interface{}   { return d.ID }
+func (d *tenantDoc) PKPointer() interface{} { return &d.ID }
+func (d *tenantDoc) HasPK() bool            { return d.ID != 0 }
+func (d *tenantDoc) SetPK(pk interface{})   { reform.AssignPK(&d.ID, pk) }
+
+func TestTenantTag(t *testing.T) {
+	var f reform.FieldInfo
+	require.NoError(t, f.ConsiderTag(false, "TenantID", reflect.StructTag(`reform:"tenant_id,tenant"`)))
+	assert.Equal(t, "tenant_id", f.Column)
+	assert.True(t, f.IsTenant)
+	require.NoError(t, f.ConsiderTag(true, "TenantID", reflect.StructTag(`gorm:"tenant"`)))
+	assert.True(t, f.IsTenant)
+	assert.Error(t, f.ConsiderTag(false, "ID", reflect.StructTag(`reform:"id,pk,tenant"`)))
+}
+
+func TestTenant(t *testing.T) {
+	db := newSQLite3DB(t, `CREATE TABLE docs (id integer PRIMARY KEY AUTOINCREMENT, tenant_id integer NOT NULL, title text NOT NULL)`)
+
+	assert.Equal(t, reform.ErrNoTenant, db.Insert(&tenantDoc{Title: "x"}))
+	_, err := db.SelectAllFrom(tenantDocs, "")
+	assert.Equal(t, reform.ErrNoTenant, err)
+
+	t1 := db.WithTenant(int64(1))
+	t2 := db.WithContext(reform.ContextWithTenant(context.Background(), 2))
+	assert.Equal(t, 2, t2.Tenant())
+	a, b := &tenantDoc{Title: "a"}, &tenantDoc{Title: "b"}
+	require.NoError(t, t1.Insert(a))
+	require.NoError(t, t2.Insert(b))
+	assert.Equal(t, int64(1), a.TenantID)
+	assert.Equal(t, int64(2), b.TenantID)
+	assert.EqualError(t, t1.Insert(&tenantDoc{Title: "c", TenantID: 2}), "reform: docs belongs to tenant 2, not to 1")
+	require.NoError(t, t1.InsertColumns(&tenantDoc{Title: "d"}, "title"))
+
+	docs, err := t1.SelectAllFrom(tenantDocs, "WHERE title <> ? ORDER BY id", "b")
+	require.NoError(t, err)
+	assert.Len(t, docs, 2)
+	_, err = t1.FindByPrimaryKeyFrom(tenantDocs, b.ID)
+	assert.Equal(t, reform.ErrNoRows, err)
+	_, err = db.WithoutTenant().FindByPrimaryKeyFrom(tenantDocs, b.ID)
+	assert.NoError(t, err)
+	_, err = t1.FlexSelectRows(tenantDocs, new(string), nil, "")
+	assert.Error(t, err)
+
+	assert.Equal(t, reform.ErrNoRows, t1.Update(&tenantDoc{ID: b.ID, Title: "hacked"}))
+	assert.Equal(t, reform.ErrNoRows, t1.Delete(&tenantDoc{ID: b.ID}))
+	n, err := t1.DeleteFrom(tenantDocs, "WHERE title = ?", "b")
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	n, err = t1.DeleteFrom(tenantDocs, "WHERE title = ?", "d")
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), n)
+
+	var title string
+	require.NoError(t, db.QueryRow("SELECT title FROM docs WHERE id = ?", b.ID).Scan(&title))
+	assert.Equal(t, "b", title)
+
+	// LIMIT is applied to rows of the current tenant only
+	require.NoError(t, t2.Insert(&tenantDoc{Title: "e"}))
+	n, err = t2.DeleteFrom(tenantDocs, "ORDER BY id LIMIT 1")
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), n)
+	_, err = db.WithoutTenant().FindByPrimaryKeyFrom(tenantDocs, a.ID)
+	assert.NoError(t, err)
+	_, err = db.WithoutTenant().FindByPrimaryKeyFrom(tenantDocs, b.ID)
+	assert.Equal(t, reform.ErrNoRows, err)
+	docs, err = t2.SelectAllFrom(tenantDocs, "")
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.Equal(t, "e", docs[0].(*tenantDoc).Title)
+}
+
+func TestTenantInterceptors(t *testing.T) {
+	db := newSQLite3DB(t, `CREATE TABLE docs (id integer PRIMARY KEY, tenant_id integer NOT NULL, title text NOT NULL)`)
+	require.NoError(t, db.WithoutTenant().Insert(&tenantDoc{ID: 1, TenantID: 1, Title: "a"}))
+
+	var queries []string
+	record := reform.InterceptorFuncs{
+		OnQuery: func(query string, args []interface{}, next reform.QueryFunc) (*sql.Rows, error) {
+			queries = append(queries, query)
+			return next(query, args)
+		},
+		OnQueryRow: func(query string, args []interface{}, next reform.QueryRowFunc) *sql.Row {
+			queries = append(queries, query)
+			return next(query, args)
+		},
+	}
+
+	// interceptors see queries limited to the tenant
+	q := db.WithTenant(int64(1)).WithInterceptors(record)
+	rows, err := q.SelectRows(tenantDocs, "")
+	require.NoError(t, err)
+	assert.NoError(t, rows.Close())
+	_, err = q.FindByPrimaryKeyFrom(tenantDocs, 1)
+	assert.NoError(t, err)
+	require.Len(t, queries, 2)
+	for _, query := range queries {
+		assert.Contains(t, query, `(SELECT * FROM "docs" WHERE "tenant_id" = ?) AS "docs"`)
+	}
+
+	queries = nil
+	_, err = db.WithoutTenant().WithInterceptors(record).FindByPrimaryKeyFrom(tenantDocs, 1)
+	assert.NoError(t, err)
+	require.Len(t, queries, 1)
+	assert.NotContains(t, queries[0], "tenant_id\" =")
+}
+
+func TestTenantNumberedPlaceholders(t *testing.T) {
+	var queries []string
+	var args [][]interface{}
+	db := reform.NewDB(nil, postgresql.Dialect, nil)
+	db.Use(reform.InterceptorFuncs{
+		OnQuery: func(query string, a []interface{}, next reform.QueryFunc) (*sql.Rows, error) {
+			queries, args = append(queries, query), append(args, a)
+			return nil, sql.ErrConnDone
+		},
+		OnExec: func(query string, a []interface{}, next reform.ExecFunc) (sql.Result, error) {
+			queries, args = append(queries, query), append(args, a)
+			return nil, sql.ErrConnDone
+		},
+	})
+
+	tenant := db.WithTenant(int64(7))
+	_, err := tenant.SelectRows(tenantDocs, "WHERE title = $1", "a")
+	assert.Equal(t, sql.ErrConnDone, err)
+	_, err = tenant.DeleteFrom(tenantDocs, "WHERE title = $1", "a")
+	assert.Equal(t, sql.ErrConnDone, err)
+	assert.Equal(t, []string{
+		`SELECT "docs"."id", "docs"."tenant_id", "docs"."title" FROM (SELECT * FROM "docs" WHERE "tenant_id" = $2) AS "docs" WHERE title = $1`,
+		`DELETE FROM "docs" WHERE "id" IN (SELECT "id" FROM (SELECT "id" FROM (SELECT * FROM "docs" WHERE "tenant_id" = $2) AS "docs" WHERE title = $1) AS "reform_tenant_rows")`,
+	}, queries)
+	assert.Equal(t, [][]interface{}{{"a", int64(7)}, {"a", int64(7)}}, args)
+}
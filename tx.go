@@ -44,6 +44,7 @@ func (tx *TX) Commit() error {
 	start := time.Now()
 	err := tx.tx.Commit()
 	tx.logAfter("COMMIT", nil, time.Since(start), err)
+	tx.txCache.finish(err == nil)
 	return err
 }
 
@@ -53,6 +54,7 @@ func (tx *TX) Rollback() error {
 	start := time.Now()
 	err := tx.tx.Rollback()
 	tx.logAfter("ROLLBACK", nil, time.Since(start), err)
+	tx.txCache.finish(false)
 	return err
 }
 
@@ -0,0 +1,132 @@
+package reform
+
+import (
+	"crypto/rand"
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// UUID is a universally unique identifier. It is stored as text (PostgreSQL uuid column),
+// or as 16 bytes for dialects implementing UUIDDialect (MySQL BINARY(16) column).
+type UUID [16]byte
+
+// NewUUIDv4 returns a new random UUID (version 4).
+func NewUUIDv4() UUID {
+	var u UUID
+	if _, err := rand.Read(u[:]); err != nil {
+		panic(err)
+	}
+	u[6] = u[6]&0x0f | 0x40
+	u[8] = u[8]&0x3f | 0x80
+	return u
+}
+
+// NewUUIDv7 returns a new time-ordered UUID (version 7): Unix time in milliseconds followed by random bits,
+// so values generated later sort after earlier ones (with millisecond precision).
+func NewUUIDv7() UUID {
+	u := NewUUIDv4()
+	var ms [8]byte
+	binary.BigEndian.PutUint64(ms[:], uint64(time.Now().UnixNano()/int64(time.Millisecond)))
+	copy(u[:6], ms[2:])
+	u[6] = u[6]&0x0f | 0x70
+	return u
+}
+
+// ParseUUID parses UUID in canonical form like 6ba7b810-9dad-11d1-80b4-00c04fd430c8,
+// with or without dashes and braces.
+func ParseUUID(s string) (UUID, error) {
+	var u UUID
+	if len(s) == 38 && s[0] == '{' && s[37] == '}' {
+		s = s[1:37]
+	}
+	switch len(s) {
+	case 36:
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return u, fmt.Errorf("reform: invalid UUID %q", s)
+		}
+		s = s[:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+	case 32:
+	default:
+		return u, fmt.Errorf("reform: invalid UUID %q", s)
+	}
+	if _, err := hex.Decode(u[:], []byte(s)); err != nil {
+		return u, fmt.Errorf("reform: invalid UUID %q", s)
+	}
+	return u, nil
+}
+
+// String returns UUID in canonical form.
+func (u UUID) String() string {
+	var b [36]byte
+	hex.Encode(b[0:8], u[0:4])
+	hex.Encode(b[9:13], u[4:6])
+	hex.Encode(b[14:18], u[6:8])
+	hex.Encode(b[19:23], u[8:10])
+	hex.Encode(b[24:], u[10:])
+	b[8], b[13], b[18], b[23] = '-', '-', '-', '-'
+	return string(b[:])
+}
+
+// IsZero returns true for zero UUID.
+func (u UUID) IsZero() bool {
+	return u == UUID{}
+}
+
+// Value implements driver.Valuer. It returns UUID in canonical form;
+// Querier replaces UUID arguments with 16 bytes for dialects implementing UUIDDialect.
+func (u UUID) Value() (driver.Value, error) {
+	return u.String(), nil
+}
+
+// Scan implements sql.Scanner. It accepts 16 bytes and text forms.
+func (u *UUID) Scan(src interface{}) error {
+	var s string
+	switch src := src.(type) {
+	case []byte:
+		if len(src) == 16 {
+			copy(u[:], src)
+			return nil
+		}
+		s = string(src)
+	case string:
+		s = src
+	default:
+		return fmt.Errorf("reform: can't scan %T into UUID", src)
+	}
+
+	parsed, err := ParseUUID(s)
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+// IsUUID returns true if the field type is reform.UUID or uuid.UUID (like github.com/google/uuid).
+func (f FieldInfo) IsUUID() bool {
+	switch strings.TrimPrefix(f.Type, "*") {
+	case "reform.UUID", "uuid.UUID":
+		return true
+	default:
+		return false
+	}
+}
+
+// UUIDDialect is implemented by dialects which store UUID in the form other than text,
+// like MySQL BINARY(16) column.
+type UUIDDialect interface {
+	Dialect
+
+	// UUIDValue returns the value of UUID query argument.
+	UUIDValue(u UUID) interface{}
+}
+
+// check interfaces
+var (
+	_ driver.Valuer = UUID{}
+	_ fmt.Stringer  = UUID{}
+)